@@ -8,6 +8,13 @@ import (
 	"players/app/models"
 	)
 
+// ApiKeyContextKey holds the *models.ApiKey of a request authenticated with an API key
+const ApiKeyContextKey = "api_key"
+
+// ImpersonationContextKey holds the *models.Impersonation of a request whose access token is an
+// impersonation session, as marked by middleware.JwtAuth
+const ImpersonationContextKey = "impersonation"
+
 // PermissionHelper provides permission checking utilities
 type PermissionHelper struct {
 	permissionService *PermissionService
@@ -22,6 +29,13 @@ func NewPermissionHelper() *PermissionHelper {
 
 // GetAuthenticatedUser gets the current authenticated user with roles
 func (h *PermissionHelper) GetAuthenticatedUser(ctx http.Context) *models.User {
+	// Requests authenticated by middleware.ApiKeyAuth act as the key's owner within its scopes
+	if apiKey, ok := ctx.Value(ApiKeyContextKey).(*models.ApiKey); ok && apiKey.User != nil {
+		owner := *apiKey.User
+		owner.ApiKeyScopes = apiKey.ScopeList()
+		return &owner
+	}
+
 	var user models.User
 	err := facades.Auth(ctx).User(&user)
 	if err != nil || user.ID == 0 {
@@ -38,6 +52,9 @@ func (h *PermissionHelper) GetAuthenticatedUser(ctx http.Context) *models.User {
 	if err != nil {
 		return nil
 	}
+	if impersonation, ok := ctx.Value(ImpersonationContextKey).(*models.Impersonation); ok {
+		userWithRoles.ImpersonatedBy = impersonation.ImpersonatorID
+	}
 	
 	return &userWithRoles
 }
@@ -70,6 +87,24 @@ func (h *PermissionHelper) RequirePermission(ctx http.Context, permission string
 	return user, nil
 }
 
+// RequirePermissionOn ensures user has permission on a loaded resource, including ownership
+// of it when the permission requires ownership. A nil resource only checks the permission.
+func (h *PermissionHelper) RequirePermissionOn(ctx http.Context, permission string, resource interface{}) (*models.User, error) {
+	user, err := h.RequireAuthentication(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !h.permissionService.CanAccessModel(user, permission, resource) {
+		if h.permissionService.HasPermission(user, permission) {
+			return nil, fmt.Errorf("%s is only allowed on your own records", permission)
+		}
+		return nil, fmt.Errorf("insufficient permissions: %s required", permission)
+	}
+
+	return user, nil
+}
+
 // RequireRole ensures user has specific role
 func (h *PermissionHelper) RequireRole(ctx http.Context, role string) (*models.User, error) {
 	user, err := h.RequireAuthentication(ctx)
@@ -128,55 +163,56 @@ func (h *PermissionHelper) CheckResourceAccess(ctx http.Context, action string,
 	return h.permissionService.CanAccessResource(user, action, resourceType, resourceID)
 }
 
-// BuildPermissionsMap builds a permission map for frontend using the new service_action format
+// permissionMapKeys are the frontend flags BuildPermissionsMap fills, by the action each one checks
+var permissionMapKeys = map[string]CorePermissionAction{
+	"canCreate":     PermissionCreate,
+	"canEdit":       PermissionUpdate,
+	"canDelete":     PermissionDelete,
+	"canManage":     PermissionManage,
+	"canExport":     PermissionExport,
+	"canBulkUpdate": PermissionBulkUpdate,
+	"canBulkDelete": PermissionBulkDelete,
+}
+
+// BuildPermissionsMap builds a permission map for frontend from the user's actual grants on
+// resourceType (the plural permission prefix, e.g. "books"). Slugs match in either the
+// books_create or books.create style, so generated resources are covered too.
 func (h *PermissionHelper) BuildPermissionsMap(ctx http.Context, resourceType string) map[string]bool {
+	perms := make(map[string]bool, len(permissionMapKeys)+4)
 	user := h.GetAuthenticatedUser(ctx)
 	if user == nil {
-		return map[string]bool{
-			"canView":   false,
-			"canCreate": false,
-			"canEdit":   false,
-			"canDelete": false,
-			"canManage": false,
+		for key := range permissionMapKeys {
+			perms[key] = false
 		}
+		perms["canView"] = false
+		perms["canViewReports"] = false
+		perms["isAdmin"] = false
+		perms["isSuperAdmin"] = false
+		return perms
 	}
-	
-	// Use the new service_action format
-	viewSlug := BuildPermissionSlug(ServiceRegistry(resourceType), PermissionView)
-	readSlug := BuildPermissionSlug(ServiceRegistry(resourceType), PermissionRead)
-	createSlug := BuildPermissionSlug(ServiceRegistry(resourceType), PermissionCreate)
-	updateSlug := BuildPermissionSlug(ServiceRegistry(resourceType), PermissionUpdate)
-	deleteSlug := BuildPermissionSlug(ServiceRegistry(resourceType), PermissionDelete)
-	
-	fmt.Printf("DEBUG BuildPermissionsMap for %s: checking permissions %s, %s, %s, %s, %s\n", 
-		resourceType, viewSlug, readSlug, createSlug, updateSlug, deleteSlug)
-	
-	// Get all user permissions for debugging
-	userPerms := h.permissionService.GetUserPermissions(user)
-	fmt.Printf("DEBUG BuildPermissionsMap - User %s permissions: %v\n", user.Email, userPerms)
-	
-	perms := map[string]bool{
-		// Use 'view' permission for listing/viewing, 'read' for accessing individual items
-		"canView":   h.permissionService.HasPermission(user, viewSlug) || h.permissionService.HasPermission(user, readSlug),
-		"canCreate": h.permissionService.HasPermission(user, createSlug),
-		"canEdit":   h.permissionService.HasPermission(user, updateSlug),
-		"canDelete": h.permissionService.HasPermission(user, deleteSlug),
-		"canManage": h.permissionService.HasPermission(user, BuildPermissionSlug(ServiceRegistry(resourceType), PermissionManage)),
-		
-		// Additional permissions
-		"canExport":     h.permissionService.HasPermission(user, BuildPermissionSlug(ServiceRegistry(resourceType), PermissionExport)),
-		"canBulkUpdate": h.permissionService.HasPermission(user, BuildPermissionSlug(ServiceRegistry(resourceType), PermissionBulkUpdate)),
-		"canBulkDelete": h.permissionService.HasPermission(user, BuildPermissionSlug(ServiceRegistry(resourceType), PermissionBulkDelete)),
-		
-		// Special report permissions
-		"canViewReports": h.permissionService.HasPermission(user, BuildPermissionSlug(ServiceReports, PermissionView)),
-		
-		// Admin permissions (legacy)
-		"isAdmin":      user.IsAdmin(),
-		"isSuperAdmin": user.IsSuperAdminUser(),
+
+	service := ServiceRegistry(resourceType)
+	for key, action := range permissionMapKeys {
+		perms[key] = h.permissionService.HasPermission(user, BuildPermissionSlug(service, action))
 	}
-	
-	fmt.Printf("DEBUG BuildPermissionsMap result for %s: %+v\n", resourceType, perms)
+
+	// Use 'view' permission for listing/viewing, 'read' for accessing individual items
+	perms["canView"] = h.permissionService.HasPermission(user, BuildPermissionSlug(service, PermissionView)) ||
+		h.permissionService.HasPermission(user, BuildPermissionSlug(service, PermissionRead)) ||
+		h.permissionService.HasPermission(user, string(service)+".viewAny")
+
+	// Special report permissions
+	perms["canViewReports"] = h.permissionService.HasPermission(user, BuildPermissionSlug(ServiceReports, PermissionView))
+
+	// Admin permissions (legacy)
+	perms["isAdmin"] = user.IsAdmin()
+	perms["isSuperAdmin"] = user.IsSuperAdminUser()
+
+	facades.Log().With(map[string]interface{}{
+		"user_id":     user.ID,
+		"resource":    resourceType,
+		"permissions": perms,
+	}).Debug("RBAC built permissions map")
 	return perms
 }
 
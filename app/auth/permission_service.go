@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -10,6 +13,18 @@ import (
 	"players/app/models"
 )
 
+// Errors returned by role and permission assignment so callers can map them to a response
+var (
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrRoleAboveAssigner      = errors.New("cannot assign role higher than your own")
+	ErrPermissionNotDelegable = errors.New("permission cannot be delegated")
+	ErrRoleAssignDenied       = errors.New("insufficient permissions to assign roles")
+)
+
+// ImpersonationDeniedPermissions are never granted to an impersonation session, whatever the
+// impersonated user holds: the session must not start another one or mint credentials outliving it
+var ImpersonationDeniedPermissions = []string{"users.impersonate", "api_keys.manage"}
+
 // PermissionService handles role-based access control
 type PermissionService struct {
 	// Cache for performance
@@ -37,33 +52,41 @@ func NewPermissionService() *PermissionService {
 // HasPermission checks if a user has a specific permission
 func (s *PermissionService) HasPermission(user *models.User, permission string) bool {
 	if user == nil {
-		fmt.Printf("DEBUG HasPermission: user is nil, returning false\n")
+		return false
+	}
+	
+	// An API key only carries the permissions its scopes name, whoever owns it
+	if !s.ScopeAllows(user, permission) {
 		return false
 	}
 	
 	// Super admin has all permissions
 	if user.IsSuperAdminUser() {
-		fmt.Printf("DEBUG HasPermission: user %d is super admin, returning true for %s\n", user.ID, permission)
 		return true
 	}
 	
-	// Always load fresh permissions (disable cache for debugging)
-	permissions := s.loadUserPermissions(user)
-	fmt.Printf("DEBUG HasPermission: user %d has permissions: %v\n", user.ID, permissions)
-	fmt.Printf("DEBUG HasPermission: checking permission: %s\n", permission)
+	permissions := s.getCachedUserPermissions(user)
 	
-	// Check direct permission match
+	// Check direct permission match in either slug style, then wildcard permissions
+	granted := false
+	key := models.PermissionKey(permission)
 	for _, perm := range permissions {
-		if perm == permission {
-			fmt.Printf("DEBUG HasPermission: found direct match for %s\n", permission)
-			return true
+		if models.PermissionKey(perm) == key {
+			granted = true
+			break
 		}
 	}
+	if !granted {
+		granted = s.hasWildcardPermission(permissions, permission)
+	}
+	
+	facades.Log().With(map[string]interface{}{
+		"user_id":    user.ID,
+		"permission": permission,
+		"granted":    granted,
+	}).Debug("RBAC permission check")
 	
-	// Check wildcard permissions
-	hasWildcard := s.hasWildcardPermission(permissions, permission)
-	fmt.Printf("DEBUG HasPermission: wildcard check for %s: %t\n", permission, hasWildcard)
-	return hasWildcard
+	return granted
 }
 
 // HasRole checks if a user has a specific role
@@ -104,6 +127,27 @@ func (s *PermissionService) CanAccessResource(user *models.User, action string,
 	return false
 }
 
+// ownerFields are the model fields naming the user a record belongs to, checked in order
+var ownerFields = []string{"CreatedByID", "OwnerID", "UserID"}
+
+// CanAccessModel checks permission for user on an already loaded model. When the permission
+// requires ownership, the model's CreatedByID, OwnerID or UserID must be the user's ID.
+func (s *PermissionService) CanAccessModel(user *models.User, permission string, resource interface{}) bool {
+	if !s.HasPermission(user, permission) {
+		return false
+	}
+
+	// Super admin can access any record
+	if resource == nil || user.IsSuperAdminUser() {
+		return true
+	}
+
+	if s.requiresOwnership(user, permission) {
+		return isModelOwner(user, resource)
+	}
+	return true
+}
+
 // CanManageUser checks if user can manage another user
 func (s *PermissionService) CanManageUser(manager *models.User, target *models.User) bool {
 	if manager == nil || target == nil {
@@ -124,6 +168,19 @@ func (s *PermissionService) CanManageUser(manager *models.User, target *models.U
 	return manager.CanManageUser(target)
 }
 
+// OutranksRole reports whether actor sits above role in the hierarchy and so may grant or remove
+// it: super admins always do, anyone else needs a highest role of a strictly greater level
+func (s *PermissionService) OutranksRole(actor *models.User, role *models.Role) bool {
+	if actor == nil || role == nil {
+		return false
+	}
+	if actor.IsSuperAdminUser() {
+		return true
+	}
+	highest := actor.GetHighestRole()
+	return highest != nil && highest.IsHigherThan(role)
+}
+
 // AssignRole assigns a role to a user
 func (s *PermissionService) AssignRole(user *models.User, roleSlug string, assignedBy *models.User) error {
 	if user == nil {
@@ -132,7 +189,7 @@ func (s *PermissionService) AssignRole(user *models.User, roleSlug string, assig
 	
 	// Check if assigner has permission
 	if assignedBy != nil && !s.HasPermission(assignedBy, "roles.assign") {
-		return fmt.Errorf("insufficient permissions to assign roles")
+		return ErrRoleAssignDenied
 	}
 	
 	// Get role
@@ -146,12 +203,9 @@ func (s *PermissionService) AssignRole(user *models.User, roleSlug string, assig
 		return fmt.Errorf("user already has role: %s", roleSlug)
 	}
 	
-	// Check role hierarchy (can't assign higher role than your own)
-	if assignedBy != nil {
-		assignerHighest := assignedBy.GetHighestRole()
-		if assignerHighest == nil || !assignerHighest.IsHigherThan(role) {
-			return fmt.Errorf("cannot assign role higher than your own")
-		}
+	// Check role hierarchy (can't assign higher role than your own); super admins can assign any role
+	if assignedBy != nil && !s.OutranksRole(assignedBy, role) {
+		return ErrRoleAboveAssigner
 	}
 	
 	// Create user-role assignment
@@ -177,6 +231,147 @@ func (s *PermissionService) AssignRole(user *models.User, roleSlug string, assig
 	return nil
 }
 
+// RoleAssignmentResult reports what AssignRoleToUsers did with each requested user ID
+type RoleAssignmentResult struct {
+	Assigned []uint `json:"assigned"`
+	Skipped  []uint `json:"skipped"`   // already held the role
+	Denied   []uint `json:"denied"`    // not below the assigner in the role hierarchy
+	NotFound []uint `json:"not_found"` // no such user
+}
+
+// AssignRoleToUsers grants one role to many users in a single transaction. Users who already
+// hold the role are skipped. The AssignRole hierarchy rules apply: the role must be below the
+// assigner's highest role, and each target must be a user the assigner can manage.
+// Super admins may assign any role to anyone.
+func (s *PermissionService) AssignRoleToUsers(roleID uint, userIDs []uint, assignedBy *models.User) (*RoleAssignmentResult, error) {
+	if assignedBy == nil || !s.HasPermission(assignedBy, "roles.assign") {
+		return nil, fmt.Errorf("insufficient permissions to assign roles")
+	}
+
+	var role models.Role
+	if err := facades.Orm().Query().Where("id = ? AND is_active = ?", roleID, true).First(&role); err != nil || role.ID == 0 {
+		return nil, ErrRoleNotFound
+	}
+
+	superAdmin := assignedBy.IsSuperAdminUser()
+	if !superAdmin {
+		assignerHighest := assignedBy.GetHighestRole()
+		if assignerHighest == nil || !assignerHighest.IsHigherThan(&role) {
+			return nil, ErrRoleAboveAssigner
+		}
+	}
+
+	result := &RoleAssignmentResult{
+		Assigned: []uint{},
+		Skipped:  []uint{},
+		Denied:   []uint{},
+		NotFound: []uint{},
+	}
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	var users []models.User
+	if err := facades.Orm().Query().Where("id IN ?", userIDs).With("Roles").Find(&users); err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+	usersByID := make(map[uint]*models.User, len(users))
+	for i := range users {
+		usersByID[users[i].ID] = &users[i]
+	}
+
+	var existing []models.UserRole
+	if err := facades.Orm().Query().Where("role_id = ? AND user_id IN ? AND is_active = ?", role.ID, userIDs, true).Find(&existing); err != nil {
+		return nil, fmt.Errorf("failed to load role assignments: %w", err)
+	}
+	holders := make(map[uint]bool, len(existing))
+	for _, assignment := range existing {
+		holders[assignment.UserID] = true
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	seen := make(map[uint]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		user, ok := usersByID[userID]
+		switch {
+		case !ok:
+			result.NotFound = append(result.NotFound, userID)
+			continue
+		case holders[userID]:
+			result.Skipped = append(result.Skipped, userID)
+			continue
+		case !superAdmin && !assignedBy.CanManageUser(user):
+			result.Denied = append(result.Denied, userID)
+			continue
+		}
+
+		userRole := models.UserRole{
+			UserID:       userID,
+			RoleID:       role.ID,
+			AssignedByID: &assignedBy.ID,
+			AssignedAt:   time.Now(),
+			IsActive:     true,
+		}
+		if err := tx.Create(&userRole); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to assign role to user %d: %w", userID, err)
+		}
+		result.Assigned = append(result.Assigned, userID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit role assignments: %w", err)
+	}
+
+	for _, userID := range result.Assigned {
+		s.clearUserCache(userID)
+	}
+	return result, nil
+}
+
+// GetAssignableRoles returns the active roles user may assign: every role for super admins,
+// otherwise only roles strictly below the user's highest role, matching the AssignRole checks
+func (s *PermissionService) GetAssignableRoles(user *models.User) ([]models.Role, error) {
+	if user == nil || !s.HasPermission(user, "roles.assign") {
+		return []models.Role{}, nil
+	}
+
+	var roles []models.Role
+	err := facades.Orm().Query().
+		Where("is_active = ?", true).
+		Order("level DESC").
+		Order("name ASC").
+		Find(&roles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roles: %w", err)
+	}
+
+	if user.IsSuperAdminUser() {
+		return roles, nil
+	}
+
+	highest := user.GetHighestRole()
+	assignable := make([]models.Role, 0, len(roles))
+	if highest == nil {
+		return assignable, nil
+	}
+	for i := range roles {
+		if highest.IsHigherThan(&roles[i]) {
+			assignable = append(assignable, roles[i])
+		}
+	}
+	return assignable, nil
+}
+
 // RemoveRole removes a role from a user
 func (s *PermissionService) RemoveRole(user *models.User, roleSlug string, removedBy *models.User) error {
 	if user == nil {
@@ -185,7 +380,7 @@ func (s *PermissionService) RemoveRole(user *models.User, roleSlug string, remov
 	
 	// Check permissions
 	if removedBy != nil && !s.HasPermission(removedBy, "roles.assign") {
-		return fmt.Errorf("insufficient permissions to remove roles")
+		return ErrRoleAssignDenied
 	}
 	
 	// Get role
@@ -194,6 +389,11 @@ func (s *PermissionService) RemoveRole(user *models.User, roleSlug string, remov
 		return fmt.Errorf("role not found: %w", err)
 	}
 	
+	// Taking a role away follows the same hierarchy as granting it
+	if removedBy != nil && !s.OutranksRole(removedBy, role) {
+		return ErrRoleAboveAssigner
+	}
+	
 	// Remove user-role assignment
 	_, err = facades.Orm().Query().Where("user_id = ? AND role_id = ?", user.ID, role.ID).Delete(&models.UserRole{})
 	if err != nil {
@@ -212,7 +412,99 @@ func (s *PermissionService) GetUserPermissions(user *models.User) []string {
 		return []string{}
 	}
 	
-	return s.loadUserPermissions(user)
+	return s.getCachedUserPermissions(user)
+}
+
+// EffectivePermission is a permission a user holds and the roles that grant it
+type EffectivePermission struct {
+	Slug      string   `json:"slug"`
+	GrantedBy []string `json:"granted_by"`
+}
+
+// GetEffectivePermissions returns every permission a user holds through their active roles,
+// with wildcard grants expanded against the known permissions. Super admins hold every
+// active permission.
+func (s *PermissionService) GetEffectivePermissions(user *models.User) ([]EffectivePermission, error) {
+	if user == nil {
+		return []EffectivePermission{}, nil
+	}
+	
+	var allPermissions []models.Permission
+	if err := facades.Orm().Query().Where("is_active = ?", true).Find(&allPermissions); err != nil {
+		return nil, fmt.Errorf("failed to load permissions: %w", err)
+	}
+	
+	grants := make(map[string][]string)
+	grant := func(slug, source string) {
+		for _, existing := range grants[slug] {
+			if existing == source {
+				return
+			}
+		}
+		grants[slug] = append(grants[slug], source)
+	}
+	
+	if user.IsSuperAdminUser() {
+		for _, permission := range allPermissions {
+			grant(permission.Slug, "super-admin")
+		}
+	}
+	
+	var userWithRoles models.User
+	err := facades.Orm().Query().
+		Where("id = ?", user.ID).
+		With("Roles").
+		First(&userWithRoles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user roles: %w", err)
+	}
+	
+	for _, role := range userWithRoles.Roles {
+		if !role.IsActive {
+			continue
+		}
+		
+		slugs, err := s.loadRolePermissionSlugs(role.ID)
+		if err != nil {
+			return nil, err
+		}
+		
+		for _, slug := range slugs {
+			grant(slug, role.Slug)
+			if !strings.Contains(slug, "*") {
+				continue
+			}
+			// Expand wildcards into the concrete permissions they cover
+			for _, permission := range allPermissions {
+				if permission.Slug != slug && s.matchesWildcard(slug, permission.Slug) {
+					grant(permission.Slug, role.Slug)
+				}
+			}
+		}
+	}
+	
+	effective := make([]EffectivePermission, 0, len(grants))
+	for slug, sources := range grants {
+		sort.Strings(sources)
+		effective = append(effective, EffectivePermission{Slug: slug, GrantedBy: sources})
+	}
+	sort.Slice(effective, func(i, j int) bool {
+		return effective[i].Slug < effective[j].Slug
+	})
+	
+	return effective, nil
+}
+
+// ClearUserCache forces a user's permissions to be reloaded on their next check.
+// Call this after changing the user's role assignments.
+func (s *PermissionService) ClearUserCache(userID uint) {
+	s.clearUserCache(userID)
+}
+
+// ClearCache drops every cached role and user permission set.
+// Call this after changing roles, permissions or role-permission grants.
+func (s *PermissionService) ClearCache() {
+	s.refreshCache()
 }
 
 // CreateRole creates a new role
@@ -265,7 +557,31 @@ func (s *PermissionService) CreatePermission(name, slug, category, action, resou
 	return permission, nil
 }
 
-// GrantPermissionToRole grants a permission to a role
+// CanDelegatePermission reports whether user may grant or revoke permission on a role. Super
+// admins always may; anyone else must hold the permission and it must be marked can_delegate.
+func (s *PermissionService) CanDelegatePermission(user *models.User, permission *models.Permission) bool {
+	if user == nil || permission == nil {
+		return false
+	}
+	if user.IsSuperAdminUser() {
+		return true
+	}
+	return permission.CanDelegate && s.HasPermission(user, permission.Slug)
+}
+
+// UndelegablePermissions returns the slugs in permissions that user may not grant or revoke
+func (s *PermissionService) UndelegablePermissions(user *models.User, permissions []models.Permission) []string {
+	denied := make([]string, 0)
+	for i := range permissions {
+		if !s.CanDelegatePermission(user, &permissions[i]) {
+			denied = append(denied, permissions[i].Slug)
+		}
+	}
+	return denied
+}
+
+// GrantPermissionToRole grants a permission to a role. When grantedBy is set the grant is
+// subject to CanDelegatePermission; a nil grantedBy is a system grant (seeders, generators).
 func (s *PermissionService) GrantPermissionToRole(roleSlug, permissionSlug string, grantedBy *models.User) error {
 	// Get role and permission
 	role, err := s.getRoleBySlug(roleSlug)
@@ -278,6 +594,10 @@ func (s *PermissionService) GrantPermissionToRole(roleSlug, permissionSlug strin
 		return fmt.Errorf("permission not found: %w", err)
 	}
 	
+	if grantedBy != nil && !s.CanDelegatePermission(grantedBy, permission) {
+		return fmt.Errorf("%w: %s", ErrPermissionNotDelegable, permission.Slug)
+	}
+	
 	// Check if already granted
 	var count int64
 	facades.Orm().Query().Model(&models.RolePermission{}).Where("role_id = ? AND permission_id = ?", role.ID, permission.ID).Count(&count)
@@ -308,13 +628,64 @@ func (s *PermissionService) GrantPermissionToRole(roleSlug, permissionSlug strin
 	return nil
 }
 
+// RevokePermissionFromRole removes a permission from a role, under the same delegation rule as
+// GrantPermissionToRole
+func (s *PermissionService) RevokePermissionFromRole(roleSlug, permissionSlug string, revokedBy *models.User) error {
+	role, err := s.getRoleBySlug(roleSlug)
+	if err != nil {
+		return fmt.Errorf("role not found: %w", err)
+	}
+	
+	permission, err := s.getPermissionBySlug(permissionSlug)
+	if err != nil {
+		return fmt.Errorf("permission not found: %w", err)
+	}
+	
+	if revokedBy != nil && !s.CanDelegatePermission(revokedBy, permission) {
+		return fmt.Errorf("%w: %s", ErrPermissionNotDelegable, permission.Slug)
+	}
+	
+	// Revoked grants are hard-deleted
+	if _, err := facades.Orm().Query().
+		Where("role_id = ? AND permission_id = ?", role.ID, permission.ID).
+		ForceDelete(&models.RolePermission{}); err != nil {
+		return fmt.Errorf("failed to revoke permission: %w", err)
+	}
+	
+	s.refreshCache()
+	
+	return nil
+}
+
 // Private helper methods
 
+// getCachedUserPermissions returns the user's permissions from the cache, loading them on a miss
+func (s *PermissionService) getCachedUserPermissions(user *models.User) []string {
+	if s.isCacheExpired() {
+		s.refreshCache()
+	}
+
+	userKey := fmt.Sprintf("user_%d", user.ID)
+	s.cacheMutex.RLock()
+	permissions, exists := s.permissionCache[userKey]
+	s.cacheMutex.RUnlock()
+
+	if exists {
+		return permissions
+	}
+
+	permissions = s.loadUserPermissions(user)
+
+	s.cacheMutex.Lock()
+	s.permissionCache[userKey] = permissions
+	s.cacheMutex.Unlock()
+
+	return permissions
+}
+
 func (s *PermissionService) loadUserPermissions(user *models.User) []string {
 	var permissions []string
 	
-	fmt.Printf("DEBUG loadUserPermissions: loading permissions for user %d\n", user.ID)
-	
 	// First, load user with roles (without permissions to avoid the many2many issue)
 	var userWithRoles models.User
 	err := facades.Orm().Query().
@@ -323,55 +694,33 @@ func (s *PermissionService) loadUserPermissions(user *models.User) []string {
 		First(&userWithRoles)
 	
 	if err != nil {
-		fmt.Printf("DEBUG loadUserPermissions: error loading user with roles: %v\n", err)
+		facades.Log().With(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Debug("RBAC failed to load user roles")
 		return permissions
 	}
 	
-	fmt.Printf("DEBUG loadUserPermissions: user has %d roles\n", len(userWithRoles.Roles))
-	
 	// Collect all permissions from all roles through the pivot table
 	permissionMap := make(map[string]bool)
 	
 	for _, role := range userWithRoles.Roles {
-		fmt.Printf("DEBUG loadUserPermissions: checking role %s (active: %t)\n", role.Slug, role.IsActive)
 		if !role.IsActive {
 			continue
 		}
 		
-		// Load permissions through the pivot table to respect is_active status
-		var rolePermissions []models.RolePermission
-		err := facades.Orm().Query().
-			Where("role_id = ? AND is_active = ?", role.ID, true).
-			Find(&rolePermissions)
-		
+		slugs, err := s.loadRolePermissionSlugs(role.ID)
 		if err != nil {
-			fmt.Printf("DEBUG loadUserPermissions: error loading role permissions: %v\n", err)
+			facades.Log().With(map[string]interface{}{
+				"user_id": user.ID,
+				"role_id": role.ID,
+				"error":   err.Error(),
+			}).Debug("RBAC failed to load role permissions")
 			continue
 		}
 		
-		fmt.Printf("DEBUG loadUserPermissions: role %s has %d active permission assignments\n", role.Slug, len(rolePermissions))
-		
-		// Now load the actual permissions
-		if len(rolePermissions) > 0 {
-			permissionIDs := make([]uint, 0)
-			for _, rp := range rolePermissions {
-				permissionIDs = append(permissionIDs, rp.PermissionID)
-			}
-			
-			var perms []models.Permission
-			err = facades.Orm().Query().
-				Where("id IN ? AND is_active = ?", permissionIDs, true).
-				Find(&perms)
-			
-			if err != nil {
-				fmt.Printf("DEBUG loadUserPermissions: error loading permissions: %v\n", err)
-				continue
-			}
-			
-			for _, permission := range perms {
-				fmt.Printf("DEBUG loadUserPermissions: permission %s (active: %t)\n", permission.Slug, permission.IsActive)
-				permissionMap[permission.Slug] = true
-			}
+		for _, slug := range slugs {
+			permissionMap[slug] = true
 		}
 	}
 	
@@ -380,10 +729,50 @@ func (s *PermissionService) loadUserPermissions(user *models.User) []string {
 		permissions = append(permissions, permission)
 	}
 	
-	fmt.Printf("DEBUG loadUserPermissions: final permissions list: %v\n", permissions)
+	facades.Log().With(map[string]interface{}{
+		"user_id":     user.ID,
+		"roles":       len(userWithRoles.Roles),
+		"permissions": permissions,
+	}).Debug("RBAC loaded user permissions")
 	return permissions
 }
 
+// loadRolePermissionSlugs returns the slugs of the active permissions granted to a role
+func (s *PermissionService) loadRolePermissionSlugs(roleID uint) ([]string, error) {
+	// Load permissions through the pivot table to respect is_active status
+	var rolePermissions []models.RolePermission
+	err := facades.Orm().Query().
+		Where("role_id = ? AND is_active = ?", roleID, true).
+		Find(&rolePermissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load role permission assignments: %w", err)
+	}
+	
+	if len(rolePermissions) == 0 {
+		return []string{}, nil
+	}
+	
+	permissionIDs := make([]uint, 0, len(rolePermissions))
+	for _, rp := range rolePermissions {
+		permissionIDs = append(permissionIDs, rp.PermissionID)
+	}
+	
+	var perms []models.Permission
+	err = facades.Orm().Query().
+		Where("id IN ? AND is_active = ?", permissionIDs, true).
+		Find(&perms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load permissions: %w", err)
+	}
+	
+	slugs := make([]string, 0, len(perms))
+	for _, permission := range perms {
+		slugs = append(slugs, permission.Slug)
+	}
+	
+	return slugs, nil
+}
+
 func (s *PermissionService) hasWildcardPermission(permissions []string, targetPermission string) bool {
 	for _, perm := range permissions {
 		if strings.Contains(perm, "*") {
@@ -396,9 +785,33 @@ func (s *PermissionService) hasWildcardPermission(permissions []string, targetPe
 	return false
 }
 
+// ScopeAllows reports whether the session's limits allow permission: the request's API key, if it
+// was made with one, must be scoped for it, and impersonation sessions never get
+// ImpersonationDeniedPermissions. Checks that bypass HasPermission, such as the super-admin guards,
+// must still ask this, or a key owned by a super admin would act with every permission.
+func (s *PermissionService) ScopeAllows(user *models.User, permission string) bool {
+	if user.ImpersonatedBy != 0 && s.hasScope(ImpersonationDeniedPermissions, permission) {
+		return false
+	}
+	return user.ApiKeyScopes == nil || s.hasScope(user.ApiKeyScopes, permission)
+}
+
+// hasScope reports whether an API key's scopes include permission, directly in either slug style
+// or through a wildcard scope such as books.*
+func (s *PermissionService) hasScope(scopes []string, permission string) bool {
+	key := models.PermissionKey(permission)
+	for _, scope := range scopes {
+		if models.PermissionKey(scope) == key {
+			return true
+		}
+	}
+	return s.hasWildcardPermission(scopes, permission)
+}
+
+// matchesWildcard compares dotted segments, so service_action slugs are read as service.action
 func (s *PermissionService) matchesWildcard(pattern, target string) bool {
-	patternParts := strings.Split(pattern, ".")
-	targetParts := strings.Split(target, ".")
+	patternParts := strings.Split(models.DottedPermissionSlug(pattern), ".")
+	targetParts := strings.Split(models.DottedPermissionSlug(target), ".")
 	
 	if len(patternParts) != len(targetParts) {
 		return false
@@ -444,7 +857,7 @@ func (s *PermissionService) getPermissionBySlug(slug string) (*models.Permission
 func (s *PermissionService) requiresOwnership(user *models.User, permission string) bool {
 	// Check if permission requires ownership
 	var perm models.Permission
-	err := facades.Orm().Query().Where("slug = ?", permission).First(&perm)
+	err := facades.Orm().Query().Where("slug IN ?", models.PermissionSlugVariants(permission)).First(&perm)
 	if err != nil {
 		return false
 	}
@@ -469,6 +882,36 @@ func (s *PermissionService) isResourceOwner(user *models.User, resourceType stri
 	}
 }
 
+// isModelOwner reports whether the first owner field present on resource holds user's ID.
+// Models without an owner field have no owner, so ownership checks on them fail.
+func isModelOwner(user *models.User, resource interface{}) bool {
+	value := reflect.ValueOf(resource)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return false
+	}
+
+	for _, name := range ownerFields {
+		field := value.FieldByName(name)
+		if !field.IsValid() {
+			continue
+		}
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				return false
+			}
+			field = field.Elem()
+		}
+		return field.CanUint() && field.Uint() == uint64(user.ID)
+	}
+	return false
+}
+
 func (s *PermissionService) clearUserCache(userID uint) {
 	s.cacheMutex.Lock()
 	defer s.cacheMutex.Unlock()
@@ -488,6 +931,9 @@ func (s *PermissionService) refreshCache() {
 }
 
 func (s *PermissionService) isCacheExpired() bool {
+	s.cacheMutex.RLock()
+	defer s.cacheMutex.RUnlock()
+
 	return time.Since(s.lastCacheUpdate) > s.cacheExpiry
 }
 
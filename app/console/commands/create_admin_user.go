@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/console/command"
@@ -11,6 +12,7 @@ import (
 
 	"players/app/auth"
 	"players/app/models"
+	"players/app/services"
 )
 
 type CreateAdminUser struct {
@@ -63,10 +65,10 @@ func (receiver *CreateAdminUser) Handle(ctx console.Context) error {
 		return err
 	}
 
-	password, err := ctx.Secret("Enter admin password (min 8 characters):", console.SecretOption{
+	password, err := ctx.Secret("Enter admin password:", console.SecretOption{
 		Validate: func(input string) error {
-			if len(input) < 8 {
-				return errors.New("password must be at least 8 characters long")
+			if failures := services.ValidatePassword(input); len(failures) > 0 {
+				return errors.New(strings.Join(failures, "; "))
 			}
 			return nil
 		},
@@ -96,6 +98,7 @@ func (receiver *CreateAdminUser) Handle(ctx console.Context) error {
 		return err
 	}
 
+	verifiedAt := time.Now()
 	adminUser := models.User{
 		Name:         name,
 		Email:        email,
@@ -104,6 +107,7 @@ func (receiver *CreateAdminUser) Handle(ctx console.Context) error {
 		IsActive:     true,
 		IsSuperAdmin: true, // Set super admin flag
 		EmailVerified: true, // Admin users are pre-verified
+		EmailVerifiedAt: &verifiedAt,
 	}
 
 	createErr := facades.Orm().Query().Create(&adminUser)
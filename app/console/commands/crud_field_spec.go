@@ -0,0 +1,875 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FieldSpec describes a single column passed to make:crud-e2e as name:type[:modifier...]
+type FieldSpec struct {
+	Column    string // in_stock
+	GoName    string // InStock
+	JSONName  string // in_stock
+	CamelName string // inStock
+	Label     string // In Stock
+	Type      string // normalized type: string, text, int, bigint, decimal, float, bool, date, datetime, json, belongsTo
+	Unique    bool
+	Nullable  bool
+	Index     bool
+
+	// belongsTo only
+	Relation     string // Category
+	RelatedModel string // Category
+	RelationJSON string // category
+	RelatedTable string // categories; defaults to the snake_case plural of RelatedModel
+}
+
+// supportedFieldTypes maps accepted type names (and aliases) to their normalized form
+var supportedFieldTypes = map[string]string{
+	"string":    "string",
+	"text":      "text",
+	"int":       "int",
+	"integer":   "int",
+	"bigint":    "bigint",
+	"decimal":   "decimal",
+	"float":     "float",
+	"bool":      "bool",
+	"boolean":   "bool",
+	"date":      "date",
+	"datetime":  "datetime",
+	"timestamp": "datetime",
+	"json":      "json",
+	"belongsto": "belongsTo",
+}
+
+// reservedFieldNames are columns every generated table already has
+var reservedFieldNames = map[string]bool{
+	"id": true, "created_at": true, "updated_at": true, "deleted_at": true,
+}
+
+// goInitialisms keeps common acronyms upper-cased in generated Go identifiers
+var goInitialisms = map[string]string{
+	"id": "ID", "url": "URL", "api": "API", "uuid": "UUID", "isbn": "ISBN", "ip": "IP",
+}
+
+// fieldModifiers are the modifiers accepted after a field type
+var fieldModifiers = map[string]bool{"unique": true, "nullable": true, "index": true}
+
+var fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var modelNamePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// defaultFieldSpecs is used when no field spec is given on the command line
+func defaultFieldSpecs() []FieldSpec {
+	return []FieldSpec{
+		newFieldSpec("name", "string"),
+		withNullable(newFieldSpec("description", "text")),
+		newFieldSpec("is_active", "bool"),
+	}
+}
+
+// parseFieldSpecs parses arguments like "price:decimal" or "sku:string:unique".
+// "category:belongsTo[:Model][:table]" adds a category_id foreign key plus an eager-loaded
+// Category relation; Model defaults to the field name in PascalCase and table, e.g.
+// order:belongsTo:orders, to the snake_case plural of Model.
+// is_active is always appended when missing because status toggles, quick filters
+// and statistics in the generated code depend on it.
+func parseFieldSpecs(args []string) ([]FieldSpec, error) {
+	if len(args) == 0 {
+		return defaultFieldSpecs(), nil
+	}
+
+	fields := make([]FieldSpec, 0, len(args)+1)
+	seen := make(map[string]bool)
+	hasIsActive := false
+	hasString := false
+
+	for _, arg := range args {
+		parts := strings.Split(strings.TrimSpace(arg), ":")
+		column := strings.ToLower(strings.TrimSpace(parts[0]))
+		if !fieldNamePattern.MatchString(column) {
+			return nil, fmt.Errorf("invalid field name %q: use lower snake_case", parts[0])
+		}
+		if reservedFieldNames[column] {
+			return nil, fmt.Errorf("field %q is added automatically and cannot be declared", column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("field %q is declared more than once", column)
+		}
+		seen[column] = true
+
+		fieldType := "string"
+		if len(parts) > 1 && parts[1] != "" {
+			normalized, ok := supportedFieldTypes[strings.ToLower(parts[1])]
+			if !ok {
+				return nil, fmt.Errorf("unsupported type %q for field %q", parts[1], column)
+			}
+			fieldType = normalized
+		}
+
+		field := newFieldSpec(column, fieldType)
+		if fieldType == "json" {
+			// A JSON document is free-form metadata, so it is always optional
+			field.Nullable = true
+		}
+		if fieldType == "belongsTo" {
+			field = newBelongsToFieldSpec(column)
+			if seen[field.Column] && field.Column != column {
+				return nil, fmt.Errorf("field %q is declared more than once", field.Column)
+			}
+			seen[field.Column] = true
+		}
+		for _, modifier := range parts[min(2, len(parts)):] {
+			if fieldType == "belongsTo" && modelNamePattern.MatchString(modifier) {
+				field.RelatedModel = modifier
+				continue
+			}
+			if fieldType == "belongsTo" && fieldNamePattern.MatchString(modifier) && !fieldModifiers[modifier] {
+				field.RelatedTable = modifier
+				continue
+			}
+			switch strings.ToLower(modifier) {
+			case "unique":
+				field.Unique = true
+			case "nullable":
+				field.Nullable = true
+			case "index":
+				field.Index = true
+			default:
+				return nil, fmt.Errorf("unsupported modifier %q for field %q", modifier, column)
+			}
+		}
+
+		if column == "is_active" {
+			if field.Type != "bool" {
+				return nil, fmt.Errorf("field %q must be a bool", column)
+			}
+			hasIsActive = true
+		}
+		if field.isString() {
+			hasString = true
+		}
+		fields = append(fields, field)
+	}
+
+	if !hasString {
+		return nil, fmt.Errorf("at least one string or text field is required to use as the display field")
+	}
+	if !hasIsActive {
+		fields = append(fields, newFieldSpec("is_active", "bool"))
+	}
+
+	return fields, nil
+}
+
+func newFieldSpec(column, fieldType string) FieldSpec {
+	parts := strings.Split(column, "_")
+	goParts := make([]string, len(parts))
+	labelParts := make([]string, len(parts))
+	camelParts := make([]string, len(parts))
+	for i, part := range parts {
+		titled := strings.ToUpper(part[:1]) + part[1:]
+		if initialism, ok := goInitialisms[part]; ok {
+			goParts[i] = initialism
+		} else {
+			goParts[i] = titled
+		}
+		labelParts[i] = titled
+		if i == 0 {
+			camelParts[i] = part
+		} else {
+			camelParts[i] = titled
+		}
+	}
+
+	return FieldSpec{
+		Column:    column,
+		GoName:    strings.Join(goParts, ""),
+		JSONName:  column,
+		CamelName: strings.Join(camelParts, ""),
+		Label:     strings.Join(labelParts, " "),
+		Type:      fieldType,
+	}
+}
+
+// newBelongsToFieldSpec builds the foreign key column for a belongsTo relation named after name
+func newBelongsToFieldSpec(name string) FieldSpec {
+	base := strings.TrimSuffix(name, "_id")
+	relation := newFieldSpec(base, "belongsTo")
+
+	field := newFieldSpec(base+"_id", "belongsTo")
+	field.Label = relation.Label
+	field.Index = true
+	field.Relation = relation.GoName
+	field.RelatedModel = relation.GoName
+	field.RelationJSON = base
+	return field
+}
+
+// authorshipFields are the created_by_id and updated_by_id columns --authorship adds, each
+// pointing at the user who made the change
+func authorshipFields() []FieldSpec {
+	var fields []FieldSpec
+	for _, name := range []string{"created_by", "updated_by"} {
+		field := withNullable(newBelongsToFieldSpec(name))
+		field.RelatedModel = "User"
+		field.RelatedTable = "users"
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func withNullable(field FieldSpec) FieldSpec {
+	field.Nullable = true
+	return field
+}
+
+func (f FieldSpec) isString() bool {
+	return f.Type == "string" || f.Type == "text"
+}
+
+func (f FieldSpec) isNumeric() bool {
+	return f.Type == "int" || f.Type == "bigint" || f.Type == "decimal" || f.Type == "float" || f.Type == "belongsTo"
+}
+
+// required reports whether the field must be present on create
+func (f FieldSpec) required() bool {
+	return !f.Nullable && f.Type != "bool"
+}
+
+// goType returns the Go type used on the model
+func (f FieldSpec) goType() string {
+	switch f.Type {
+	case "int":
+		return "int"
+	case "bigint":
+		return "int64"
+	case "belongsTo":
+		return "uint"
+	case "decimal", "float":
+		return "float64"
+	case "bool":
+		return "bool"
+	case "datetime":
+		if f.Nullable {
+			return "*time.Time"
+		}
+		return "time.Time"
+	case "json":
+		return "JSON"
+	default:
+		return "string"
+	}
+}
+
+// resourceGoType returns the Go type used on the API resource, which lives outside the models package
+func (f FieldSpec) resourceGoType() string {
+	if f.Type == "json" {
+		return "models.JSON"
+	}
+	return f.goType()
+}
+
+// requestGoType returns the Go type used on form requests (dates are bound as strings and JSON
+// documents as the decoded object)
+func (f FieldSpec) requestGoType() string {
+	switch f.Type {
+	case "datetime", "date":
+		return "string"
+	case "json":
+		return "map[string]interface{}"
+	}
+	return f.goType()
+}
+
+// updateRequestGoType returns the Go type used on update requests. Booleans and nullable fields are
+// pointers so a field the client didn't send can be told apart from false or an empty value; a JSON
+// document that wasn't sent is already a nil map.
+func (f FieldSpec) updateRequestGoType() string {
+	if f.Type == "json" {
+		return f.requestGoType()
+	}
+	if f.Type == "bool" || f.Nullable {
+		return "*" + f.requestGoType()
+	}
+	return f.requestGoType()
+}
+
+func (f FieldSpec) gormTag() string {
+	tags := []string{}
+	switch f.Type {
+	case "text":
+		tags = append(tags, "type:text")
+	case "decimal":
+		tags = append(tags, "type:decimal(10,2)")
+	case "date":
+		tags = append(tags, "type:date")
+	case "bool":
+		if f.Column == "is_active" {
+			tags = append(tags, "default:true")
+		} else {
+			tags = append(tags, "default:false")
+		}
+	}
+	if f.required() {
+		tags = append(tags, "not null")
+	}
+	if f.Unique {
+		tags = append(tags, "uniqueIndex")
+	} else if f.Index {
+		tags = append(tags, "index")
+	}
+	return strings.Join(tags, ";")
+}
+
+func (f FieldSpec) migrationColumn() string {
+	var column string
+	switch f.Type {
+	case "text":
+		column = fmt.Sprintf(`table.Text("%s")`, f.Column)
+	case "int":
+		column = fmt.Sprintf(`table.Integer("%s")`, f.Column)
+	case "bigint":
+		column = fmt.Sprintf(`table.BigInteger("%s")`, f.Column)
+	case "belongsTo":
+		column = fmt.Sprintf(`table.UnsignedBigInteger("%s")`, f.Column)
+	case "decimal":
+		column = fmt.Sprintf(`table.Decimal("%s").Total(10).Places(2)`, f.Column)
+	case "float":
+		column = fmt.Sprintf(`table.Float("%s")`, f.Column)
+	case "bool":
+		column = fmt.Sprintf(`table.Boolean("%s").Default(%t)`, f.Column, f.Column == "is_active")
+	case "date":
+		column = fmt.Sprintf(`table.Date("%s")`, f.Column)
+	case "datetime":
+		column = fmt.Sprintf(`table.DateTime("%s")`, f.Column)
+	case "json":
+		column = fmt.Sprintf(`table.Json("%s")`, f.Column)
+	default:
+		column = fmt.Sprintf(`table.String("%s")`, f.Column)
+	}
+	if f.Nullable {
+		column += ".Nullable()"
+	}
+	return column
+}
+
+// validationRule returns the rule string without the required prefix
+func (f FieldSpec) validationRule() string {
+	switch f.Type {
+	case "string":
+		return "string|max:255"
+	case "text":
+		return "string|max:1000"
+	case "int", "bigint", "belongsTo":
+		return "integer"
+	case "decimal", "float":
+		return "numeric"
+	case "bool":
+		return "boolean"
+	case "json":
+		return "map"
+	default:
+		return "date"
+	}
+}
+
+func (f FieldSpec) createRule() string {
+	if f.required() {
+		return "required|" + f.validationRule()
+	}
+	return f.validationRule()
+}
+
+func (f FieldSpec) tsType() string {
+	var tsType string
+	switch {
+	case f.isNumeric():
+		tsType = "number"
+	case f.Type == "bool":
+		tsType = "boolean"
+	case f.Type == "json":
+		tsType = "Record<string, any>"
+	default:
+		tsType = "string"
+	}
+	if f.Nullable && f.Type != "bool" {
+		tsType += " | null"
+	}
+	return tsType
+}
+
+func (f FieldSpec) tsDefault() string {
+	switch {
+	case f.isNumeric():
+		return "0"
+	case f.Type == "bool":
+		return fmt.Sprintf("%t", f.Column == "is_active")
+	case f.Type == "json":
+		return "{}"
+	default:
+		return "''"
+	}
+}
+
+// applyFieldSpecs renders the field-dependent template snippets onto the config
+func (receiver *MakeCrudE2E) applyFieldSpecs(config *ResourceConfig, fields []FieldSpec) {
+	config.Fields = fields
+
+	title := fields[0]
+	for _, field := range fields {
+		if field.isString() {
+			title = field
+			break
+		}
+	}
+	config.TitleColumn = title.Column
+	config.TitleGoName = title.GoName
+	config.TitleLabel = title.Label
+
+	// Lists default to alphabetical order when there is a name or title to sort on, else newest first
+	config.DefaultSortField, config.DefaultSortDirection = "id", "DESC"
+	for _, field := range fields {
+		if field.Column == "name" || field.Column == "title" {
+			config.DefaultSortField, config.DefaultSortDirection = field.Column, "ASC"
+			break
+		}
+	}
+
+	var (
+		modelFields, migrationColumns, migrationIndexes              []string
+		validationRules, columnMappings, sortable, searchable        []string
+		required, maxLengths                                         []string
+		requestFields, updateRequestFields, createRules, updateRules []string
+		attributes, fillable                                         []string
+		createMessages, updateMessages, createData, updateData       []string
+		tsFields, tsDefaults, tsEditValues, tsValidation             []string
+		tsInputs, tsColumns, tsDetails, relations                    []string
+		migrationForeignKeys, tsRelations, jsonColumns               []string
+		resourceFields, resourceValues                               []string
+		usesTime                                                     bool
+	)
+
+	sortable = append(sortable, `"id"`)
+	for _, f := range fields {
+		if f.Type == "datetime" {
+			usesTime = true
+		}
+
+		modelFields = append(modelFields, fmt.Sprintf("\t%s %s `gorm:\"%s\" json:\"%s\"`", f.GoName, f.goType(), f.gormTag(), f.JSONName))
+		resourceFields = append(resourceFields, fmt.Sprintf("\t%s %s `json:\"%s\"`", f.GoName, f.resourceGoType(), f.JSONName))
+		resourceValues = append(resourceValues, fmt.Sprintf("\t\t%s: %s.%s,", f.GoName, config.LowerName, f.GoName))
+		if f.Type == "belongsTo" {
+			modelFields = append(modelFields, fmt.Sprintf("\t%s *%s `gorm:\"foreignKey:%s\" json:\"%s,omitempty\"`", f.Relation, f.RelatedModel, f.GoName, f.RelationJSON))
+			relations = append(relations, fmt.Sprintf("%q", f.Relation))
+			resourceFields = append(resourceFields, fmt.Sprintf("\t%s *models.%s `json:\"%s,omitempty\"`", f.Relation, f.RelatedModel, f.RelationJSON))
+			resourceValues = append(resourceValues, fmt.Sprintf("\t\t%s: %s.%s,", f.Relation, config.LowerName, f.Relation))
+
+			relatedTable := f.RelatedTable
+			if relatedTable == "" {
+				relatedTable = receiver.pluralize(receiver.toSnakeCase(f.RelatedModel))
+			}
+			foreignKey := fmt.Sprintf("\t\ttable.Foreign(\"%s\").References(\"id\").On(\"%s\")", f.Column, relatedTable)
+			if f.Nullable {
+				foreignKey += ".NullOnDelete()"
+			}
+			migrationForeignKeys = append(migrationForeignKeys, foreignKey)
+			tsRelations = append(tsRelations, fmt.Sprintf("  %s?: { id: number; [key: string]: unknown } | null;", f.RelationJSON))
+		}
+		migrationColumns = append(migrationColumns, "\t\t"+f.migrationColumn())
+		if f.Unique {
+			migrationIndexes = append(migrationIndexes, fmt.Sprintf("\t\ttable.Unique(\"%s\")", f.Column))
+		} else if f.Index || f.Column == title.Column || f.Column == "is_active" {
+			migrationIndexes = append(migrationIndexes, fmt.Sprintf("\t\ttable.Index(\"%s\")", f.Column))
+		}
+
+		validationRules = append(validationRules, fmt.Sprintf("\t\t\"%s\": \"%s\",", f.Column, f.createRule()))
+		if f.CamelName != f.Column {
+			columnMappings = append(columnMappings, fmt.Sprintf("\t\t\"%s\": \"%s\",", f.CamelName, f.Column))
+		}
+		columnMappings = append(columnMappings, fmt.Sprintf("\t\t\"%s\": \"%s\",", f.Column, f.Column))
+		if f.Type == "json" {
+			jsonColumns = append(jsonColumns, fmt.Sprintf("%q", f.Column))
+		} else {
+			sortable = append(sortable, fmt.Sprintf("%q", f.Column))
+		}
+		if f.isString() {
+			searchable = append(searchable, fmt.Sprintf("%q", f.Column))
+			limit := 255
+			if f.Type == "text" {
+				limit = 1000
+			}
+			maxLengths = append(maxLengths, fmt.Sprintf("%q: %d", f.Column, limit))
+		}
+		if f.required() {
+			required = append(required, fmt.Sprintf("%q", f.Column))
+		}
+
+		// Form requests
+		fillable = append(fillable, fmt.Sprintf("%q", f.JSONName))
+		requestFields = append(requestFields, fmt.Sprintf("\t%s %s `form:\"%s\" json:\"%s\"`", f.GoName, f.requestGoType(), f.JSONName, f.JSONName))
+		updateRequestFields = append(updateRequestFields, fmt.Sprintf("\t%s %s `form:\"%s\" json:\"%s\"`", f.GoName, f.updateRequestGoType(), f.JSONName, f.JSONName))
+		createRules = append(createRules, fmt.Sprintf("\t\t\"%s\": \"%s\",", f.Column, f.createRule()))
+		updateRules = append(updateRules, fmt.Sprintf("\t\t\"%s\": \"%s\",", f.Column, f.validationRule()))
+		// Messages and attribute names are translation keys resolved from lang/<locale>/*.json
+		attributes = append(attributes, fmt.Sprintf("%q", f.Column))
+		if f.required() {
+			createMessages = append(createMessages, fmt.Sprintf("\t\t\"%s.required\",", f.Column))
+		}
+		if f.isString() {
+			limit := 255
+			if f.Type == "text" {
+				limit = 1000
+			}
+			message := fmt.Sprintf("\t\t\"%s.max:%d\",", f.Column, limit)
+			createMessages = append(createMessages, message)
+			updateMessages = append(updateMessages, message)
+		}
+		createData = append(createData, fmt.Sprintf("\t\t\"%s\": r.%s,", f.Column, f.GoName))
+		switch {
+		case f.Type == "json":
+			updateData = append(updateData, fmt.Sprintf("\tif r.%s != nil {\n\t\tdata[\"%s\"] = r.%s\n\t}", f.GoName, f.Column, f.GoName))
+		case f.Nullable && (f.Type == "date" || f.Type == "datetime"):
+			// An empty date clears the column
+			updateData = append(updateData, fmt.Sprintf("\tif r.%s != nil {\n\t\tif *r.%s == \"\" {\n\t\t\tdata[\"%s\"] = nil\n\t\t} else {\n\t\t\tdata[\"%s\"] = *r.%s\n\t\t}\n\t}", f.GoName, f.GoName, f.Column, f.Column, f.GoName))
+		case f.Type == "bool" || f.Nullable:
+			updateData = append(updateData, fmt.Sprintf("\tif r.%s != nil {\n\t\tdata[\"%s\"] = *r.%s\n\t}", f.GoName, f.Column, f.GoName))
+		case f.isNumeric():
+			updateData = append(updateData, fmt.Sprintf("\tif r.%s != 0 {\n\t\tdata[\"%s\"] = r.%s\n\t}", f.GoName, f.Column, f.GoName))
+		default:
+			updateData = append(updateData, fmt.Sprintf("\tif r.%s != \"\" {\n\t\tdata[\"%s\"] = r.%s\n\t}", f.GoName, f.Column, f.GoName))
+		}
+
+		// TypeScript
+		tsFields = append(tsFields, fmt.Sprintf("  %s: %s;", f.JSONName, f.tsType()))
+		tsDefaults = append(tsDefaults, fmt.Sprintf("    %s: %s,", f.JSONName, f.tsDefault()))
+		if f.Nullable && f.Type != "bool" {
+			tsEditValues = append(tsEditValues, fmt.Sprintf("    %s: %s.%s ?? %s,", f.JSONName, config.LowerName, f.JSONName, f.tsDefault()))
+		} else {
+			tsEditValues = append(tsEditValues, fmt.Sprintf("    %s: %s.%s,", f.JSONName, config.LowerName, f.JSONName))
+		}
+		if f.required() && f.isString() {
+			tsValidation = append(tsValidation, fmt.Sprintf("    if (!(formData.%s ?? '').trim()) {\n      newErrors.%s = '%s is required';\n    }", f.JSONName, f.JSONName, f.Label))
+		}
+		if f.Type == "json" {
+			// Keep a parse error from the editor so invalid JSON can't be submitted
+			tsValidation = append(tsValidation, fmt.Sprintf("    if (errors.%s) {\n      newErrors.%s = errors.%s;\n    }", f.JSONName, f.JSONName, f.JSONName))
+		}
+		tsInputs = append(tsInputs, receiver.renderFormInput(f, config.LowerName))
+
+		if f.Column != "is_active" && f.Type != "json" {
+			tsColumns = append(tsColumns, receiver.renderTableColumn(f, title, config.Name))
+		}
+		if f.Column != "is_active" {
+			if f.Column != title.Column {
+				tsDetails = append(tsDetails, receiver.renderDetailField(f, config.LowerName))
+			}
+		}
+	}
+	if config.Authorship {
+		// Filled by the service from the acting user, so they are neither fillable nor validated
+		for _, f := range authorshipFields() {
+			modelFields = append(modelFields,
+				fmt.Sprintf("\t%s *uint `gorm:\"%s\" json:\"%s\"`", f.GoName, f.gormTag(), f.JSONName),
+				fmt.Sprintf("\t%s *%s `gorm:\"foreignKey:%s\" json:\"%s,omitempty\"`", f.Relation, f.RelatedModel, f.GoName, f.RelationJSON))
+			relations = append(relations, fmt.Sprintf("%q", f.Relation))
+			resourceFields = append(resourceFields,
+				fmt.Sprintf("\t%s *uint `json:\"%s\"`", f.GoName, f.JSONName),
+				fmt.Sprintf("\t%s *AuthorResource `json:\"%s,omitempty\"`", f.Relation, f.RelationJSON))
+			resourceValues = append(resourceValues,
+				fmt.Sprintf("\t\t%s: %s.%s,", f.GoName, config.LowerName, f.GoName),
+				fmt.Sprintf("\t\t%s: NewAuthorResource(%s.%s),", f.Relation, config.LowerName, f.Relation))
+			migrationColumns = append(migrationColumns, "\t\t"+f.migrationColumn())
+			migrationIndexes = append(migrationIndexes, fmt.Sprintf("\t\ttable.Index(\"%s\")", f.Column))
+			migrationForeignKeys = append(migrationForeignKeys, fmt.Sprintf("\t\ttable.Foreign(\"%s\").References(\"id\").On(\"%s\").NullOnDelete()", f.Column, f.RelatedTable))
+			sortable = append(sortable, fmt.Sprintf("%q", f.Column))
+			tsRelations = append(tsRelations,
+				fmt.Sprintf("  %s?: number | null;", f.JSONName),
+				fmt.Sprintf("  %s?: { id: number; name: string; email: string } | null;", f.RelationJSON))
+		}
+	}
+	sortable = append(sortable, `"createdAt"`, `"updatedAt"`)
+
+	config.ModelImports = ""
+	if usesTime {
+		config.ModelImports = "\t\"time\"\n"
+	}
+	config.ModelFields = strings.Join(modelFields, "\n")
+	config.Relations = strings.Join(relations, ", ")
+	config.ResourceFields = strings.Join(resourceFields, "\n")
+	config.ResourceValues = strings.Join(resourceValues, "\n")
+	config.MigrationColumns = strings.Join(migrationColumns, "\n")
+	config.MigrationIndexes = strings.Join(append(migrationIndexes, migrationForeignKeys...), "\n")
+	config.ValidationRules = strings.Join(validationRules, "\n")
+	config.ColumnMappings = strings.Join(columnMappings, "\n")
+	config.SortableFields = strings.Join(sortable, ", ")
+	config.SearchableFields = strings.Join(searchable, ", ")
+	config.FillableFields = strings.Join(fillable, ", ")
+	config.RequiredFields = strings.Join(required, ", ")
+	config.MaxLengths = strings.Join(maxLengths, ", ")
+	config.RequestFields = strings.Join(requestFields, "\n")
+	config.RequestUpdateFields = strings.Join(updateRequestFields, "\n")
+	config.RequestCreateRules = strings.Join(createRules, "\n")
+	config.RequestUpdateRules = strings.Join(updateRules, "\n")
+	config.RequestCreateMessages = strings.Join(createMessages, "\n")
+	config.RequestUpdateMessages = strings.Join(updateMessages, "\n")
+	config.RequestAttributes = strings.Join(attributes, ", ")
+	config.ToCreateData = strings.Join(createData, "\n")
+	config.ToUpdateData = strings.Join(updateData, "\n")
+	config.EncodeJSONColumns = ""
+	if len(jsonColumns) > 0 {
+		// Updates go through a column map, so JSON documents are encoded before GORM sees them
+		config.EncodeJSONColumns = fmt.Sprintf("\t// JSON columns are saved as documents whatever shape the request sent\n\tif err := models.EncodeJSONColumns(data, %s); err != nil {\n\t\treturn nil, fmt.Errorf(\"invalid %s data: %%w\", err)\n\t}\n\n", strings.Join(jsonColumns, ", "), config.LowerName)
+	}
+	config.TSFields = strings.Join(tsFields, "\n")
+	config.TSModelFields = strings.Join(append(tsFields, tsRelations...), "\n")
+	config.TSFormDefaults = strings.Join(tsDefaults, "\n")
+	config.TSFormEditValues = strings.Join(tsEditValues, "\n")
+	config.TSFormValidation = strings.Join(tsValidation, "\n")
+	config.TSFormInputs = strings.Join(tsInputs, "\n\n")
+	config.TSColumns = strings.Join(tsColumns, "\n")
+	config.TSDetailFields = strings.Join(tsDetails, "\n\n")
+
+	// Mobile rows show the first long-form text field under the display field
+	config.TSMobileSubtitle = ""
+	for _, f := range fields {
+		if f.Type == "text" && f.Column != title.Column {
+			config.TSMobileSubtitle = fmt.Sprintf(`
+        <div className="text-sm text-muted-foreground">
+          {row.original.%[1]s ? row.original.%[1]s.substring(0, 50) + '...' : 'No %[2]s'}
+        </div>`, f.JSONName, strings.ToLower(f.Label))
+			break
+		}
+	}
+}
+
+func (receiver *MakeCrudE2E) renderFormInput(f FieldSpec, lowerName string) string {
+	label := f.Label
+	if f.required() {
+		label += " *"
+	}
+
+	if f.Type == "bool" {
+		return fmt.Sprintf(`      <div className="flex items-center space-x-2">
+        <Switch
+          id="%[1]s"
+          checked={formData.%[1]s}
+          onCheckedChange={(checked) => setFormData({ ...formData, %[1]s: checked })}
+        />
+        <Label htmlFor="%[1]s">%[2]s</Label>
+      </div>`, f.JSONName, f.Label)
+	}
+
+	if f.Type == "json" {
+		// The document is edited as text and only stored once it parses
+		return fmt.Sprintf(`      <div className="space-y-2">
+        <Label htmlFor="%[1]s">%[2]s (JSON)</Label>
+        <Textarea
+          id="%[1]s"
+          defaultValue={JSON.stringify(formData.%[1]s ?? {}, null, 2)}
+          onChange={(e) => {
+            try {
+              setFormData({ ...formData, %[1]s: e.target.value.trim() ? JSON.parse(e.target.value) : null });
+              setErrors({ ...errors, %[1]s: '' });
+            } catch {
+              setErrors({ ...errors, %[1]s: '%[2]s must be valid JSON' });
+            }
+          }}
+          rows={5}
+          className={errors.%[1]s ? 'font-mono border-destructive' : 'font-mono'}
+        />
+        {errors.%[1]s && (
+          <p className="text-sm text-destructive">{errors.%[1]s}</p>
+        )}
+      </div>`, f.JSONName, f.Label)
+	}
+
+	if f.Type == "text" {
+		return fmt.Sprintf(`      <div className="space-y-2">
+        <Label htmlFor="%[1]s">%[2]s</Label>
+        <Textarea
+          id="%[1]s"
+          value={formData.%[1]s ?? ''}
+          onChange={(e) => setFormData({ ...formData, %[1]s: e.target.value })}
+          placeholder="Enter %[3]s %[4]s"
+          rows={3}
+          className={errors.%[1]s ? 'border-destructive' : ''}
+        />
+        {errors.%[1]s && (
+          <p className="text-sm text-destructive">{errors.%[1]s}</p>
+        )}
+      </div>`, f.JSONName, label, lowerName, strings.ToLower(f.Label))
+	}
+
+	inputType := "text"
+	value := fmt.Sprintf("formData.%s ?? ''", f.JSONName)
+	onChange := "e.target.value"
+	extra := ""
+	switch f.Type {
+	case "int", "bigint", "belongsTo":
+		inputType = "number"
+		onChange = "Number(e.target.value)"
+	case "decimal", "float":
+		inputType = "number"
+		onChange = "Number(e.target.value)"
+		extra = "\n          step=\"0.01\""
+	case "date":
+		inputType = "date"
+	case "datetime":
+		inputType = "datetime-local"
+		value = fmt.Sprintf("formData.%s ? formData.%s.slice(0, 16) : ''", f.JSONName, f.JSONName)
+		onChange = "e.target.value ? new Date(e.target.value).toISOString() : ''"
+	}
+
+	return fmt.Sprintf(`      <div className="space-y-2">
+        <Label htmlFor="%[1]s">%[2]s</Label>
+        <Input
+          id="%[1]s"
+          type="%[3]s"%[4]s
+          value={%[5]s}
+          onChange={(e) => setFormData({ ...formData, %[1]s: %[6]s })}
+          placeholder="Enter %[7]s %[8]s"
+          className={errors.%[1]s ? 'border-destructive' : ''}
+        />
+        {errors.%[1]s && (
+          <p className="text-sm text-destructive">{errors.%[1]s}</p>
+        )}
+      </div>`, f.JSONName, label, inputType, extra, value, onChange, lowerName, strings.ToLower(f.Label))
+}
+
+func (receiver *MakeCrudE2E) renderTableColumn(f FieldSpec, title FieldSpec, name string) string {
+	var cell string
+	switch {
+	case f.Column == title.Column:
+		cell = fmt.Sprintf(`<div className="font-medium">{row.original.%s}</div>`, f.JSONName)
+	case f.Type == "text":
+		cell = fmt.Sprintf(`<div className="max-w-xs truncate text-muted-foreground">
+        {row.original.%s || 'No %s'}
+      </div>`, f.JSONName, strings.ToLower(f.Label))
+	case f.Type == "bool":
+		cell = fmt.Sprintf(`<Badge variant={row.original.%[1]s ? 'default' : 'secondary'}>
+        {row.original.%[1]s ? 'Yes' : 'No'}
+      </Badge>`, f.JSONName)
+	case f.Type == "datetime" || f.Type == "date":
+		cell = fmt.Sprintf(`<span className="text-sm">
+        {row.original.%[1]s ? new Date(row.original.%[1]s).toLocaleDateString() : '-'}
+      </span>`, f.JSONName)
+	default:
+		cell = fmt.Sprintf(`<span className="text-sm">{row.original.%s ?? '-'}</span>`, f.JSONName)
+	}
+
+	return fmt.Sprintf(`  {
+    accessorKey: '%s',
+    header: '%s',
+    cell: ({ row }: { row: { original: %s } }) => (
+      %s
+    ),
+  },`, f.JSONName, f.Label, name, cell)
+}
+
+func (receiver *MakeCrudE2E) renderDetailField(f FieldSpec, lowerName string) string {
+	value := fmt.Sprintf("{%s.%s ?? '-'}", lowerName, f.JSONName)
+	switch {
+	case f.Type == "bool":
+		value = fmt.Sprintf("{%s.%s ? 'Yes' : 'No'}", lowerName, f.JSONName)
+	case f.isString():
+		value = fmt.Sprintf("{%s.%s || 'No %s provided'}", lowerName, f.JSONName, strings.ToLower(f.Label))
+	case f.Type == "datetime" || f.Type == "date":
+		value = fmt.Sprintf("{%[1]s.%[2]s ? new Date(%[1]s.%[2]s).toLocaleString() : '-'}", lowerName, f.JSONName)
+	case f.Type == "json":
+		return fmt.Sprintf(`        <div>
+          <Label className="text-sm font-medium">%[1]s</Label>
+          <pre className="text-sm text-muted-foreground mt-1 whitespace-pre-wrap">
+            {%[2]s.%[3]s ? JSON.stringify(%[2]s.%[3]s, null, 2) : '-'}
+          </pre>
+        </div>`, f.Label, lowerName, f.JSONName)
+	}
+
+	return fmt.Sprintf(`        <div>
+          <Label className="text-sm font-medium">%s</Label>
+          <p className="text-sm text-muted-foreground mt-1">
+            %s
+          </p>
+        </div>`, f.Label, value)
+}
+
+// seedValue returns a Go literal for row n (1-based) of the generated data seeder,
+// or false when the field is better left to its default (nullable datetimes, relations and JSON)
+func (f FieldSpec) seedValue(n int, resourceName string, isTitle bool) (string, bool) {
+	switch f.Type {
+	case "string":
+		switch {
+		case strings.Contains(f.Column, "email"):
+			return fmt.Sprintf("%q", fmt.Sprintf("%s%d@example.com", strings.ToLower(resourceName), n)), true
+		case strings.Contains(f.Column, "url") || strings.Contains(f.Column, "website"):
+			return fmt.Sprintf("%q", fmt.Sprintf("https://example.com/%s/%d", strings.ToLower(resourceName), n)), true
+		case strings.Contains(f.Column, "phone"):
+			return fmt.Sprintf("%q", fmt.Sprintf("555-01%02d", n%100)), true
+		case f.Column == "sku" || strings.HasSuffix(f.Column, "_code") || f.Column == "code":
+			return fmt.Sprintf("%q", fmt.Sprintf("%s-%04d", strings.ToUpper(f.Column), n)), true
+		case isTitle:
+			return fmt.Sprintf("%q", fmt.Sprintf("%s %d", resourceName, n)), true
+		default:
+			return fmt.Sprintf("%q", fmt.Sprintf("%s %s %d", resourceName, f.Label, n)), true
+		}
+	case "text":
+		return fmt.Sprintf("%q", fmt.Sprintf("Sample %s for %s %d.", strings.ToLower(f.Label), resourceName, n)), true
+	case "int", "bigint":
+		return fmt.Sprintf("%d", n*10), true
+	case "decimal", "float":
+		return fmt.Sprintf("%.2f", 4.99+float64(n)*5), true
+	case "bool":
+		// Omitted values fall back to the column default (true for is_active, false otherwise)
+		if f.Column == "is_active" {
+			return "true", true
+		}
+		return "true", n%2 == 0
+	case "date":
+		return fmt.Sprintf("%q", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n-1).Format("2006-01-02")), true
+	case "datetime":
+		if f.Nullable {
+			return "", false
+		}
+		return fmt.Sprintf("time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC).AddDate(0, 0, %d)", n-1), true
+	case "belongsTo":
+		if f.Nullable {
+			return "", false
+		}
+		return "1", true
+	default:
+		return "", false
+	}
+}
+
+// renderSeederRows fills in the struct literals and imports for the generated data seeder
+func (receiver *MakeCrudE2E) renderSeederRows(config *ResourceConfig) {
+	rows := make([]string, 0, config.SeedCount)
+	usesTime := false
+	for n := 1; n <= config.SeedCount; n++ {
+		lines := []string{"\t\t{"}
+		for _, f := range config.Fields {
+			value, ok := f.seedValue(n, config.Name, f.Column == config.TitleColumn)
+			if !ok {
+				continue
+			}
+			if f.Type == "datetime" {
+				usesTime = true
+			}
+			line := fmt.Sprintf("\t\t\t%s: %s,", f.GoName, value)
+			if f.Type == "belongsTo" {
+				line += fmt.Sprintf(" // assumes a %s with ID 1 exists", f.RelatedModel)
+			}
+			lines = append(lines, line)
+		}
+		lines = append(lines, "\t\t},")
+		rows = append(rows, strings.Join(lines, "\n"))
+	}
+
+	config.SeederRows = strings.Join(rows, "\n")
+	config.SeederImports = ""
+	if usesTime {
+		config.SeederImports = "\t\"time\"\n\n"
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+
+	"players/app/services"
+)
+
+// MaintenanceDown puts the application into maintenance mode
+type MaintenanceDown struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *MaintenanceDown) Signature() string {
+	return "down"
+}
+
+// Description The console command description.
+func (receiver *MaintenanceDown) Description() string {
+	return "Put the application into maintenance mode; only super admins and allowed IPs get through"
+}
+
+// Extend The console command extend.
+func (receiver *MaintenanceDown) Extend() command.Extend {
+	return command.Extend{
+		Category: "maintenance",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "message",
+				Usage: "Message shown to users instead of the default maintenance notice",
+			},
+			&command.IntFlag{
+				Name:  "retry",
+				Value: -1,
+				Usage: "Seconds sent in the Retry-After header (default maintenance.retry_after, 0 sends none)",
+			},
+			&command.StringSliceFlag{
+				Name:  "allow",
+				Usage: "IP or CIDR range that can still use the application, on top of maintenance.allowed_ips; repeatable",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *MaintenanceDown) Handle(ctx console.Context) error {
+	allowed := ctx.OptionSlice("allow")
+	for _, entry := range allowed {
+		if net.ParseIP(entry) == nil {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				ctx.Error(fmt.Sprintf("--allow %q is not an IP address or CIDR range", entry))
+				return errors.New("invalid allowed IP")
+			}
+		}
+	}
+
+	retry := ctx.OptionInt("retry")
+	if retry < 0 {
+		retry = facades.Config().GetInt("maintenance.retry_after", 60)
+	}
+
+	if err := services.NewMaintenanceService().Down(services.MaintenanceState{
+		Message:    ctx.Option("message"),
+		RetryAfter: retry,
+		AllowedIPs: allowed,
+	}); err != nil {
+		ctx.Error(err.Error())
+		return err
+	}
+
+	ctx.Warning("Application is now in maintenance mode")
+	return nil
+}
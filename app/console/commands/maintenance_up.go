@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+
+	"players/app/services"
+)
+
+// MaintenanceUp brings the application out of maintenance mode
+type MaintenanceUp struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *MaintenanceUp) Signature() string {
+	return "up"
+}
+
+// Description The console command description.
+func (receiver *MaintenanceUp) Description() string {
+	return "Bring the application out of maintenance mode"
+}
+
+// Extend The console command extend.
+func (receiver *MaintenanceUp) Extend() command.Extend {
+	return command.Extend{
+		Category: "maintenance",
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *MaintenanceUp) Handle(ctx console.Context) error {
+	wasDown, err := services.NewMaintenanceService().Up()
+	if err != nil {
+		ctx.Error(err.Error())
+		return err
+	}
+
+	if facades.Config().GetBool("maintenance.enabled", false) {
+		ctx.Warning("MAINTENANCE_MODE=true still keeps the application down; unset it and restart")
+		return nil
+	}
+	if !wasDown {
+		ctx.Info("Application is not in maintenance mode")
+		return nil
+	}
+	ctx.Success("Application is now live")
+	return nil
+}
@@ -1,12 +1,15 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"go/format"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/console/command"
@@ -17,7 +20,7 @@ type MakeCrudE2E struct {
 
 // Signature The name and signature of the console command.
 func (receiver *MakeCrudE2E) Signature() string {
-	return "make:crud-e2e {name} {--force}"
+	return "make:crud-e2e"
 }
 
 // Description The console command description.
@@ -28,63 +31,146 @@ func (receiver *MakeCrudE2E) Description() string {
 // Extend The console command extend.
 func (receiver *MakeCrudE2E) Extend() command.Extend {
 	return command.Extend{
-		Category: "make",
+		Category:  "make",
+		ArgsUsage: crudArgsUsage,
+		Flags: append(crudGeneratorFlags(), &command.BoolFlag{
+			Name:  "api-only",
+			Usage: "Generate only the API: skip the page controller, admin route and React types, components and pages",
+		}),
 	}
 }
 
+// crudArgsUsage documents the positional arguments of make:crud and make:crud-e2e
+const crudArgsUsage = "<name> [field:type[:unique|:nullable|:index] ...] [relation:belongsTo[:Model][:table]]"
+
+// crudGeneratorFlags are the flags make:crud and make:crud-e2e share
+func crudGeneratorFlags() []command.Flag {
+	return []command.Flag{
+		&command.BoolFlag{
+			Name:  "force",
+			Usage: "Overwrite existing files",
+		},
+		&command.IntFlag{
+			Name:  "seed-count",
+			Value: 10,
+			Usage: "Number of sample rows the generated data seeder inserts",
+		},
+		&command.BoolFlag{
+			Name:  "no-migration",
+			Usage: "Skip the migration, e.g. when the table already exists",
+		},
+		&command.BoolFlag{
+			Name:  "authorship",
+			Usage: "Add created_by_id and updated_by_id, filled from the authenticated user on create and update",
+		},
+	}
+}
+
+// generateOptions selects which artifacts generate writes
+type generateOptions struct {
+	force         bool
+	seedCount     int
+	apiOnly       bool
+	skipMigration bool
+	authorship    bool
+}
+
+// generateStep is one artifact writer; it returns the paths it wrote
+type generateStep struct {
+	name        string
+	description string
+	fn          func(console.Context, ResourceConfig, bool) ([]string, error)
+}
+
 // Handle Execute the console command.
 func (receiver *MakeCrudE2E) Handle(ctx console.Context) error {
+	return receiver.generate(ctx, "make:crud-e2e", generateOptions{
+		force:         ctx.OptionBool("force"),
+		seedCount:     ctx.OptionInt("seed-count"),
+		apiOnly:       ctx.OptionBool("api-only"),
+		skipMigration: ctx.OptionBool("no-migration"),
+		authorship:    ctx.OptionBool("authorship"),
+	})
+}
+
+// generate writes every artifact for the resource named by the first argument, skipping those
+// excluded by opts. The remaining arguments are the field spec. make:crud and make:crud-e2e both
+// run through here so resources behave the same whichever command created them.
+func (receiver *MakeCrudE2E) generate(ctx console.Context, commandName string, opts generateOptions) error {
 	name := ctx.Argument(0)
 	if name == "" {
 		ctx.Error("Resource name is required")
-		ctx.Info("Usage: go run . artisan make:crud-e2e Product")
+		ctx.Info(fmt.Sprintf("Usage: go run . artisan %s Product name:string price:decimal sku:string:unique in_stock:bool", commandName))
 		return errors.New("missing resource name")
 	}
 
-	forceOption := ctx.Option("force")
-	force := forceOption != ""
+	// Parse the optional field spec (defaults to name/description/is_active)
+	fields, err := parseFieldSpecs(ctx.Arguments()[1:])
+	if err != nil {
+		ctx.Error(fmt.Sprintf("Invalid field spec: %v", err))
+		return err
+	}
+
+	if opts.seedCount < 1 || opts.seedCount > maxSeedCount {
+		err := fmt.Errorf("--seed-count must be between 1 and %d", maxSeedCount)
+		ctx.Error(err.Error())
+		return err
+	}
 
 	// Convert name to various formats
 	resourceConfig := receiver.parseResourceName(name)
-	
-	ctx.Info(fmt.Sprintf("Generating complete CRUD system for: %s", resourceConfig.DisplayName))
+	resourceConfig.Authorship = opts.authorship
+	receiver.applyFieldSpecs(&resourceConfig, fields)
+	resourceConfig.SeedCount = opts.seedCount
+	resourceConfig.APIOnly = opts.apiOnly
+
+	ctx.Info(fmt.Sprintf("Generating CRUD system for: %s", resourceConfig.DisplayName))
 	ctx.Info("=====================================")
 
 	// Generate all components
-	steps := []struct {
-		name        string
-		description string
-		fn          func(console.Context, ResourceConfig, bool) error
-	}{
+	steps := []generateStep{
 		{"model", "Creating model", receiver.generateModel},
+		{"resource", "Creating API resource", receiver.generateResource},
 		{"migration", "Creating migration", receiver.generateMigration},
 		{"service", "Creating service with contracts", receiver.generateService},
 		{"requests", "Creating validation requests", receiver.generateRequests},
+		{"translations", "Adding attribute names", receiver.generateTranslations},
 		{"controller", "Creating API controller", receiver.generateController},
-		{"page-controller", "Creating page controller", receiver.generatePageController},
 		{"routes", "Adding routes", receiver.generateRoutes},
 		{"permissions", "Creating permissions", receiver.generatePermissions},
-		{"ui-types", "Creating TypeScript types", receiver.generateUITypes},
-		{"ui-components", "Creating React components", receiver.generateUIComponents},
-		{"ui-pages", "Creating React pages", receiver.generateUIPages},
+		{"seeder", "Creating data seeder", receiver.generateSeeder},
+	}
+	if !opts.apiOnly {
+		steps = append(steps,
+			generateStep{"page-controller", "Creating page controller", receiver.generatePageController},
+			generateStep{"ui-types", "Creating TypeScript types", receiver.generateUITypes},
+			generateStep{"ui-components", "Creating React components", receiver.generateUIComponents},
+			generateStep{"ui-pages", "Creating React pages", receiver.generateUIPages},
+		)
 	}
 
 	generatedFiles := []string{}
-	
+
 	for _, step := range steps {
+		if opts.skipMigration && step.name == "migration" {
+			continue
+		}
+
 		ctx.Info(fmt.Sprintf("🔨 %s...", step.description))
 		
-		if err := step.fn(ctx, resourceConfig, force); err != nil {
+		files, err := step.fn(ctx, resourceConfig, opts.force)
+		if err != nil {
 			ctx.Error(fmt.Sprintf("Failed to generate %s: %v", step.name, err))
 			return err
 		}
+		generatedFiles = append(generatedFiles, files...)
 		
 		ctx.Success(fmt.Sprintf("✓ %s generated successfully", step.description))
 	}
 
 	// Display summary
 	ctx.Info("")
-	ctx.Success("🎉 Complete CRUD system generated successfully!")
+	ctx.Success("🎉 CRUD system generated successfully!")
 	ctx.Info("Generated files:")
 	for _, file := range generatedFiles {
 		ctx.Info(fmt.Sprintf("  • %s", file))
@@ -94,8 +180,13 @@ func (receiver *MakeCrudE2E) Handle(ctx console.Context) error {
 	ctx.Info("Next steps:")
 	ctx.Info("1. Run migration: go run . artisan migrate")
 	ctx.Info("2. Seed permissions: go run . artisan seed --seeder=rbac")
-	ctx.Info("3. Update your frontend routing")
-	ctx.Info("4. Test the CRUD operations")
+	ctx.Info(fmt.Sprintf("3. Register seeders.%sSeeder in database/kernel.go, then: go run . artisan seed --seeder=%sSeeder", resourceConfig.Name, resourceConfig.Name))
+	if opts.apiOnly {
+		ctx.Info("4. Test the CRUD operations through the API")
+	} else {
+		ctx.Info("4. Update your frontend routing")
+		ctx.Info("5. Test the CRUD operations")
+	}
 
 	return nil
 }
@@ -122,17 +213,72 @@ type ResourceConfig struct {
 	ControllerPath  string // app/http/controllers/product_controller.go
 	PageControllerPath string // app/http/controllers/product_page_controller.go
 	RequestPath     string // app/http/requests/product_request.go
+	ResourcePath    string // app/http/resources/product_resource.go
 	MigrationPath   string // database/migrations/
+	LangAttributesPath string // lang/en/attributes.json
 	
 	// Frontend paths
 	UITypesPath     string // resources/js/types/product.ts
 	UIComponentsPath string // resources/js/components/Products/
 	UIPagesPath     string // resources/js/pages/Products/
+
+	// Field spec and the template snippets rendered from it
+	Fields                []FieldSpec
+	TitleColumn           string // name
+	TitleGoName           string // Name
+	TitleLabel            string // Name
+	DefaultSortField      string // name
+	DefaultSortDirection  string // ASC
+	ModelImports          string
+	ModelFields           string
+	Relations             string
+	ResourceFields        string
+	ResourceValues        string
+	MigrationColumns      string
+	MigrationIndexes      string
+	ValidationRules       string
+	ColumnMappings        string
+	SortableFields        string
+	SearchableFields      string
+	FillableFields        string
+	RequiredFields        string
+	MaxLengths            string
+	RequestFields         string
+	RequestUpdateFields   string
+	RequestCreateRules    string
+	RequestUpdateRules    string
+	RequestCreateMessages string
+	RequestUpdateMessages string
+	RequestAttributes     string
+	ToCreateData          string
+	ToUpdateData          string
+	EncodeJSONColumns     string
+	TSFields              string
+	TSModelFields         string
+	TSFormDefaults        string
+	TSFormEditValues      string
+	TSFormValidation      string
+	TSFormInputs          string
+	TSColumns             string
+	TSMobileSubtitle      string
+	TSDetailFields        string
+
+	// APIOnly leaves out the page controller and admin route (make:crud, make:crud-e2e --api-only)
+	APIOnly bool
+
+	// Authorship adds created_by_id/updated_by_id, set from the acting user (--authorship)
+	Authorship bool
+
+	// Data seeder
+	SeedCount     int // rows inserted by database/seeders/product_seeder.go
+	SeederImports string
+	SeederRows    string
 }
 
 // parseResourceName converts the input name to all required variations
 func (receiver *MakeCrudE2E) parseResourceName(name string) ResourceConfig {
-	name = strings.Title(strings.ToLower(name))
+	// Keep the caller's casing so acronyms survive ("APIKey" stays APIKey, not Apikey)
+	name = receiver.singularize(strings.ToUpper(name[:1]) + name[1:])
 	lowerName := strings.ToLower(name)
 	pluralName := receiver.pluralize(name)
 	lowerPluralName := strings.ToLower(pluralName)
@@ -154,7 +300,9 @@ func (receiver *MakeCrudE2E) parseResourceName(name string) ResourceConfig {
 		ControllerPath:  fmt.Sprintf("app/http/controllers/%s_controller.go", receiver.toSnakeCase(name)),
 		PageControllerPath: fmt.Sprintf("app/http/controllers/%s_page_controller.go", receiver.toSnakeCase(name)),
 		RequestPath:     fmt.Sprintf("app/http/requests/%s_request.go", receiver.toSnakeCase(name)),
+		ResourcePath:    fmt.Sprintf("app/http/resources/%s_resource.go", receiver.toSnakeCase(name)),
 		MigrationPath:   "database/migrations/",
+		LangAttributesPath: "lang/en/attributes.json",
 		
 		UITypesPath:     fmt.Sprintf("resources/js/types/%s.ts", lowerName),
 		UIComponentsPath: fmt.Sprintf("resources/js/components/%s/", pluralName),
@@ -162,26 +310,183 @@ func (receiver *MakeCrudE2E) parseResourceName(name string) ResourceConfig {
 	}
 }
 
+// irregularPlurals maps singular nouns that don't follow the suffix rules to their plural form
+var irregularPlurals = map[string]string{
+	"person":    "people",
+	"child":     "children",
+	"man":       "men",
+	"woman":     "women",
+	"foot":      "feet",
+	"tooth":     "teeth",
+	"goose":     "geese",
+	"mouse":     "mice",
+	"ox":        "oxen",
+	"knife":     "knives",
+	"wife":      "wives",
+	"life":      "lives",
+	"status":    "statuses",
+	"analysis":  "analyses",
+	"criterion": "criteria",
+	"medium":    "media",
+}
+
+// uncountableNouns have the same singular and plural form
+var uncountableNouns = map[string]bool{
+	"series":      true,
+	"species":     true,
+	"sheep":       true,
+	"fish":        true,
+	"deer":        true,
+	"news":        true,
+	"equipment":   true,
+	"information": true,
+	"metadata":    true,
+}
+
+// Nouns ending in f/fe that just take an s
+var fPluralExceptions = map[string]bool{
+	"roof":   true,
+	"proof":  true,
+	"chief":  true,
+	"chef":   true,
+	"belief": true,
+	"safe":   true,
+}
+
 // Helper functions for string transformations
 func (receiver *MakeCrudE2E) pluralize(word string) string {
-	if strings.HasSuffix(word, "y") {
-		return word[:len(word)-1] + "ies"
+	lower := strings.ToLower(word)
+	if uncountableNouns[lower] || receiver.isPlural(lower) {
+		return word
+	}
+	if plural, ok := irregularPlurals[lower]; ok {
+		return receiver.matchCase(word, plural)
 	}
-	if strings.HasSuffix(word, "s") || strings.HasSuffix(word, "sh") || strings.HasSuffix(word, "ch") {
+
+	switch {
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(lower, "s") || strings.HasSuffix(lower, "x") || strings.HasSuffix(lower, "z") ||
+		strings.HasSuffix(lower, "sh") || strings.HasSuffix(lower, "ch"):
 		return word + "es"
+	case strings.HasSuffix(lower, "fe") && !fPluralExceptions[lower]:
+		return word[:len(word)-2] + "ves"
+	case strings.HasSuffix(lower, "f") && !strings.HasSuffix(lower, "ff") && !fPluralExceptions[lower]:
+		return word[:len(word)-1] + "ves"
 	}
 	return word + "s"
 }
 
-func (receiver *MakeCrudE2E) toSnakeCase(str string) string {
-	var result []rune
-	for i, r := range str {
-		if i > 0 && 'A' <= r && r <= 'Z' {
-			result = append(result, '_')
+// singularize reverses pluralize so resource names given in plural form ("Books") still map to a singular model
+func (receiver *MakeCrudE2E) singularize(word string) string {
+	lower := strings.ToLower(word)
+	if uncountableNouns[lower] {
+		return word
+	}
+	for singular, plural := range irregularPlurals {
+		if lower == plural {
+			return receiver.matchCase(word, singular)
 		}
-		result = append(result, r)
 	}
-	return strings.ToLower(string(result))
+	if !receiver.isPlural(lower) {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(lower) > 3:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(lower, "lves") || strings.HasSuffix(lower, "eaves") || strings.HasSuffix(lower, "arves"):
+		return word[:len(word)-3] + "f"
+	case strings.HasSuffix(lower, "sses") || strings.HasSuffix(lower, "xes") || strings.HasSuffix(lower, "zes") ||
+		strings.HasSuffix(lower, "shes") || strings.HasSuffix(lower, "ches"):
+		return word[:len(word)-2]
+	}
+	return word[:len(word)-1]
+}
+
+// isPlural reports whether a lowercase noun already looks plural
+func (receiver *MakeCrudE2E) isPlural(lower string) bool {
+	for singular, plural := range irregularPlurals {
+		if lower == plural && lower != singular {
+			return true
+		}
+	}
+	if _, ok := irregularPlurals[lower]; ok {
+		return false
+	}
+	return strings.HasSuffix(lower, "s") &&
+		!strings.HasSuffix(lower, "ss") &&
+		!strings.HasSuffix(lower, "us") &&
+		!strings.HasSuffix(lower, "is")
+}
+
+// matchCase copies the capitalization of the original word's first letter onto replacement
+func (receiver *MakeCrudE2E) matchCase(original, replacement string) string {
+	if original == "" || replacement == "" {
+		return replacement
+	}
+	if original[0] >= 'A' && original[0] <= 'Z' {
+		return strings.ToUpper(replacement[:1]) + replacement[1:]
+	}
+	return replacement
+}
+
+// mixedCaseWords are treated as a single word even though they contain an inner capital
+var mixedCaseWords = []string{"OAuth", "GraphQL", "MySQL", "NoSQL", "iOS"}
+
+// splitWords breaks a PascalCase name into words, keeping runs of capitals
+// together so "HTTPProxy" becomes ["HTTP", "Proxy"] rather than one word per letter
+func (receiver *MakeCrudE2E) splitWords(str string) []string {
+	var words []string
+	runes := []rune(str)
+	start := 0
+
+	isUpper := func(r rune) bool { return unicode.IsUpper(r) }
+	isLower := func(r rune) bool { return unicode.IsLower(r) || unicode.IsDigit(r) }
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '_' || runes[i] == '-' || runes[i] == ' ' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+
+		matched := false
+		for _, word := range mixedCaseWords {
+			if strings.HasPrefix(string(runes[i:]), word) {
+				if i > start {
+					words = append(words, string(runes[start:i]))
+				}
+				words = append(words, word)
+				i += len([]rune(word)) - 1
+				start = i + 1
+				matched = true
+				break
+			}
+		}
+		if matched || i == start {
+			continue
+		}
+
+		// Break before a capital that follows a lowercase letter ("bookShelf"),
+		// or before the last capital of an acronym run ("HTTPProxy")
+		if isUpper(runes[i]) && (isLower(runes[i-1]) ||
+			(isUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+func (receiver *MakeCrudE2E) toSnakeCase(str string) string {
+	return strings.ToLower(strings.Join(receiver.splitWords(str), "_"))
 }
 
 func (receiver *MakeCrudE2E) toKebabCase(str string) string {
@@ -189,26 +494,20 @@ func (receiver *MakeCrudE2E) toKebabCase(str string) string {
 }
 
 // Generation functions
-func (receiver *MakeCrudE2E) generateModel(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateModel(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	template := `package models
 
 import (
+	"fmt"
+{{.ModelImports}}
 	"github.com/goravel/framework/database/orm"
 )
 
 // {{.Name}} represents a {{.LowerName}} in the system
 type {{.Name}} struct {
 	orm.Model
-	Name        string ` + "`" + `gorm:"not null" json:"name"` + "`" + `
-	Description string ` + "`" + `gorm:"type:text" json:"description"` + "`" + `
-	IsActive    bool   ` + "`" + `gorm:"default:true" json:"is_active"` + "`" + `
-	
-	// Add your custom fields here
-	// Price       float64 ` + "`" + `gorm:"type:decimal(10,2)" json:"price"` + "`" + `
-	// Category    string  ` + "`" + `gorm:"index" json:"category"` + "`" + `
-	// CreatedByID *uint   ` + "`" + `gorm:"index" json:"created_by_id,omitempty"` + "`" + `
-	// CreatedBy   *User   ` + "`" + `gorm:"foreignKey:CreatedByID" json:"created_by,omitempty"` + "`" + `
-	
+{{.ModelFields}}
+
 	orm.SoftDeletes
 }
 
@@ -219,80 +518,119 @@ func ({{.Name}}) TableName() string {
 
 // Validate performs model validation
 func ({{.LowerName}} *{{.Name}}) Validate() error {
-	if {{.LowerName}}.Name == "" {
-		return fmt.Errorf("name is required")
+	if {{.LowerName}}.{{.TitleGoName}} == "" {
+		return fmt.Errorf("{{.TitleColumn}} is required")
 	}
 	return nil
 }
 `
 
-	return receiver.writeFileFromTemplate(config.ModelPath, template, config, force)
+	return receiver.writeGeneratedFile(config.ModelPath, template, config, force)
 }
 
-func (receiver *MakeCrudE2E) generateMigration(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateResource(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
+	template := `package resources
+
+import (
+	"time"
+
+	"github.com/goravel/framework/support/carbon"
+
+	"players/app/models"
+)
+
+// {{.Name}}Resource is the API representation of a {{.LowerName}}. Only the fields listed here are serialized.
+type {{.Name}}Resource struct {
+	ID        uint            ` + "`json:\"id\"`" + `
+{{.ResourceFields}}
+	CreatedAt carbon.DateTime ` + "`json:\"created_at\"`" + `
+	UpdatedAt carbon.DateTime ` + "`json:\"updated_at\"`" + `
+	DeletedAt *time.Time      ` + "`json:\"deleted_at,omitempty\"`" + `
+}
+
+// New{{.Name}}Resource builds the API representation of {{.LowerName}}
+func New{{.Name}}Resource({{.LowerName}} *models.{{.Name}}) *{{.Name}}Resource {
+	resource := &{{.Name}}Resource{
+		ID:        {{.LowerName}}.ID,
+{{.ResourceValues}}
+		CreatedAt: {{.LowerName}}.CreatedAt,
+		UpdatedAt: {{.LowerName}}.UpdatedAt,
+	}
+	if {{.LowerName}}.DeletedAt.Valid {
+		deletedAt := {{.LowerName}}.DeletedAt.Time
+		resource.DeletedAt = &deletedAt
+	}
+	return resource
+}
+
+// {{.Name}} is the Transformer for records returned by {{.Name}}Service; other values pass through unchanged
+func {{.Name}}(record interface{}) interface{} {
+	switch {{.LowerName}} := record.(type) {
+	case *models.{{.Name}}:
+		if {{.LowerName}} != nil {
+			return New{{.Name}}Resource({{.LowerName}})
+		}
+	case models.{{.Name}}:
+		return New{{.Name}}Resource(&{{.LowerName}})
+	}
+	return record
+}
+`
+
+	return receiver.writeGeneratedFile(config.ResourcePath, template, config, force)
+}
+
+func (receiver *MakeCrudE2E) generateMigration(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	timestamp := time.Now().Format("20060102150405")
 	migrationFile := fmt.Sprintf("%s%s_create_%s_table.go", config.MigrationPath, timestamp, config.TableName)
 	
 	template := `package migrations
 
 import (
-	"github.com/goravel/framework/contracts/database/migration"
 	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
 )
 
-func init() {
-	Migrator.Register(&Create{{.PluralName}}Table{})
-}
-
-type Create{{.PluralName}}Table struct {
+type M` + timestamp + `Create{{.PluralName}}Table struct {
 }
 
-// Signature The name and signature of the migration.
-func (m *Create{{.PluralName}}Table) Signature() string {
-	return "{{.TableName}}_table"
+// Signature The unique signature for the migration.
+func (r *M` + timestamp + `Create{{.PluralName}}Table) Signature() string {
+	return "` + timestamp + `_create_{{.TableName}}_table"
 }
 
 // Up Run the migrations.
-func (m *Create{{.PluralName}}Table) Up() error {
-	return schema.Create("{{.TableName}}", func(table schema.Blueprint) error {
+func (r *M` + timestamp + `Create{{.PluralName}}Table) Up() error {
+	return facades.Schema().Create("{{.TableName}}", func(table schema.Blueprint) {
 		table.ID()
-		table.String("name").NotNull()
-		table.Text("description").Nullable()
-		table.Boolean("is_active").Default(true)
-		
-		// Add your custom columns here
-		// table.Decimal("price", 10, 2).Nullable()
-		// table.String("category").Index().Nullable()
-		// table.UnsignedBigInteger("created_by_id").Index().Nullable()
-		// table.Foreign("created_by_id").References("id").On("users").OnDelete("SET NULL")
-		
+{{.MigrationColumns}}
 		table.Timestamps()
 		table.SoftDeletes()
-		
-		// Add indexes
-		table.Index("name")
-		table.Index("is_active")
-		
-		return nil
+
+		// Add indexes and foreign keys
+{{.MigrationIndexes}}
 	})
 }
 
 // Down Reverse the migrations.
-func (m *Create{{.PluralName}}Table) Down() error {
-	return schema.DropIfExists("{{.TableName}}")
+func (r *M` + timestamp + `Create{{.PluralName}}Table) Down() error {
+	return facades.Schema().DropIfExists("{{.TableName}}")
 }
 `
 
-	return receiver.writeFileFromTemplate(migrationFile, template, config, force)
+	return receiver.writeGeneratedFile(migrationFile, template, config, force)
 }
 
-func (receiver *MakeCrudE2E) generateService(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateService(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	template := `package services
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/goravel/framework/contracts/database/orm"
 	"github.com/goravel/framework/facades"
 	"players/app/contracts"
 	"players/app/models"
@@ -306,9 +644,12 @@ type {{.Name}}Service struct {
 // New{{.Name}}Service creates a new {{.LowerName}} service that implements all contracts
 func New{{.Name}}Service() *{{.Name}}Service {
 	service := &{{.Name}}Service{
-		BaseCrudService: contracts.NewBaseCrudService("{{.LowerName}}", "id"),
+		BaseCrudService: contracts.NewBaseCrudService("{{.TableName}}", "id"),
 	}
 
+	// The indexed display column can drive keyset pagination alongside id
+	service.SetCursorColumns("{{.TitleColumn}}")
+
 	// Register service with validation
 	contracts.MustRegisterCrudService("{{.LowerPluralName}}", service)
 
@@ -317,103 +658,72 @@ func New{{.Name}}Service() *{{.Name}}Service {
 
 // GetList with built-in pagination, sorting, filtering using GORM directly
 // Implements CrudServiceContract interface
-func (s *{{.Name}}Service) GetList(req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+func (s *{{.Name}}Service) GetList(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	db, release, err := s.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Use base service validation
 	if err := s.ValidateListRequest(&req); err != nil {
 		return nil, err
 	}
 	s.SanitizeListRequest(&req)
 
-	// Build query
-	query := facades.Orm().Query().Model(&models.{{.Name}}{})
-
-	// Apply search if provided using searchable fields
 	if req.Search != "" {
 		if err := s.ValidateSearchQuery(req.Search); err != nil {
 			return nil, err
 		}
-		searchFields := s.GetSearchableFields()
-		if len(searchFields) > 0 {
-			conditions := make([]string, len(searchFields))
-			values := make([]interface{}, len(searchFields))
-			for i, field := range searchFields {
-				conditions[i] = field + " LIKE ?"
-				values[i] = "%" + req.Search + "%"
-			}
-			query = query.Where(strings.Join(conditions, " OR "), values...)
-		}
 	}
 
-	// Apply sorting with field validation and mapping
-	if req.Sort != "" && req.Direction != "" {
-		if s.ValidateSortField(req.Sort) && s.ValidateSortDirection(req.Direction) {
-			dbColumn, valid := s.MapSortField(req.Sort)
-			if valid {
-				orderClause := dbColumn + " " + strings.ToUpper(req.Direction)
-				query = query.Order(orderClause)
-			} else {
-				// Use default sort
-				defaultField, defaultDir := s.GetDefaultSort()
-				query = query.Order(defaultField + " " + defaultDir)
+	// Build query with search conditions; called separately for count and data
+	newQuery := func() orm.Query {
+		query := db.Model(&models.{{.Name}}{})
+
+		// Apply search if provided using searchable fields and their match modes
+		if req.Search != "" {
+			searchFields := s.GetSearchableFields()
+			if len(searchFields) > 0 {
+				condition, values := s.SearchCondition(req.Search, searchFields, s.GetSearchFieldModes())
+				query = query.Where(condition, values...)
 			}
-		} else {
-			// Use default sort
-			defaultField, defaultDir := s.GetDefaultSort()
-			query = query.Order(defaultField + " " + defaultDir)
 		}
-	} else {
-		// Default sorting
-		defaultField, defaultDir := s.GetDefaultSort()
-		query = query.Order(defaultField + " " + defaultDir)
-	}
 
-	// Get all {{.LowerPluralName}} with applied filters and sorting
-	var all{{.PluralName}} []models.{{.Name}}
-	if err := query.Find(&all{{.PluralName}}); err != nil {
-		return nil, err
+		return query
 	}
 
-	// Manual pagination
-	total := int64(len(all{{.PluralName}}))
-	offset := (req.Page - 1) * req.PageSize
-	end := offset + req.PageSize
-
-	if offset > len(all{{.PluralName}}) {
-		offset = len(all{{.PluralName}})
-	}
-	if end > len(all{{.PluralName}}) {
-		end = len(all{{.PluralName}})
-	}
+	// Resolve sorting with field validation and mapping
+	orderClause := s.buildOrderClause(req)
 
+	// Count and fetch only the requested page at the database level
 	var page{{.PluralName}} []models.{{.Name}}
-	if offset < len(all{{.PluralName}}) {
-		page{{.PluralName}} = all{{.PluralName}}[offset:end]
+	total, err := s.PaginateQuery(newQuery, orderClause, req, &page{{.PluralName}}, s.GetRelations()...)
+	if err != nil {
+		return nil, err
 	}
 
-	lastPage := int((total + int64(req.PageSize) - 1) / int64(req.PageSize))
-
 	// Convert to interface slice
 	data := make([]interface{}, len(page{{.PluralName}}))
 	for i, {{.LowerName}} := range page{{.PluralName}} {
 		data[i] = {{.LowerName}}
 	}
 
-	return &contracts.PaginatedResult{
-		Data:        data,
-		Total:       total,
-		PerPage:     req.PageSize,
-		CurrentPage: req.Page,
-		LastPage:    lastPage,
-		From:        offset + 1,
-		To:          offset + len(page{{.PluralName}}),
-		HasNext:     req.Page < lastPage,
-		HasPrev:     req.Page > 1,
-	}, nil
+	// Pages sorted on a cursor column also carry a nextCursor for keyset pagination
+	result := s.BuildPaginatedResult(data, total, req)
+	s.ApplyNextCursor(result, req, orderClause, page{{.PluralName}})
+	return result, nil
 }
 
 // GetListAdvanced with additional filters using GORM directly
 // Implements CrudServiceContract interface
-func (s *{{.Name}}Service) GetListAdvanced(req contracts.ListRequest, filters map[string]interface{}) (*contracts.PaginatedResult, error) {
+func (s *{{.Name}}Service) GetListAdvanced(ctx context.Context, req contracts.ListRequest, filters map[string]interface{}) (*contracts.PaginatedResult, error) {
+	db, release, err := s.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Validate and sanitize request
 	if err := s.ValidateListRequest(&req); err != nil {
 		return nil, err
@@ -425,14 +735,27 @@ func (s *{{.Name}}Service) GetListAdvanced(req contracts.ListRequest, filters ma
 	if err != nil {
 		return nil, err
 	}
+	rangeFilters, err := s.BuildRangeFilters(filters, s.ValidateFilterField)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create separate queries for count and data
-	countQuery := facades.Orm().Query().Model(&models.{{.Name}}{})
-	dataQuery := facades.Orm().Query().Model(&models.{{.Name}}{})
+	countQuery := db.Model(&models.{{.Name}}{})
+	dataQuery := s.WithRelations(db.Model(&models.{{.Name}}{}), s.GetRelations())
+
+	// Soft-deleted rows stay hidden unless trashed=with|only is requested
+	trashed, _ := filters["trashed"].(string)
+	if countQuery, err = s.ApplyTrashedFilter(countQuery, trashed); err != nil {
+		return nil, err
+	}
+	if dataQuery, err = s.ApplyTrashedFilter(dataQuery, trashed); err != nil {
+		return nil, err
+	}
 
 	// Apply search to both queries if provided
 	if req.Search != "" {
-		searchCondition := "name LIKE ?"
+		searchCondition := "{{.TitleColumn}} LIKE ?"
 		searchValue := "%" + req.Search + "%"
 		countQuery = countQuery.Where(searchCondition, searchValue)
 		dataQuery = dataQuery.Where(searchCondition, searchValue)
@@ -443,9 +766,10 @@ func (s *{{.Name}}Service) GetListAdvanced(req contracts.ListRequest, filters ma
 		var condition string
 		switch field {
 		case "is_active":
-			condition = "is_active = ?"
-		case "name":
-			condition = "name LIKE ?"
+			// Slice values match with IN (...)
+			condition, value = s.FilterCondition("is_active", value)
+		case "{{.TitleColumn}}":
+			condition = "{{.TitleColumn}} LIKE ?"
 			value = "%" + fmt.Sprintf("%v", value) + "%"
 		default:
 			continue
@@ -454,6 +778,10 @@ func (s *{{.Name}}Service) GetListAdvanced(req contracts.ListRequest, filters ma
 		dataQuery = dataQuery.Where(condition, value)
 	}
 
+	// Apply {field}_from/_to/_gte/_lte bounds, e.g. created_at_from
+	countQuery = s.ApplyRangeFilters(countQuery, rangeFilters)
+	dataQuery = s.ApplyRangeFilters(dataQuery, rangeFilters)
+
 	// Count total records
 	var total int64
 	if err := countQuery.Count(&total); err != nil {
@@ -461,11 +789,7 @@ func (s *{{.Name}}Service) GetListAdvanced(req contracts.ListRequest, filters ma
 	}
 
 	// Add sorting to data query only
-	if req.Sort != "" {
-		dataQuery = dataQuery.Order("id DESC") // Should parse req.Sort properly
-	} else {
-		dataQuery = dataQuery.Order("id DESC")
-	}
+	dataQuery = dataQuery.Order(s.StableOrder(s.buildOrderClause(req)))
 
 	// Calculate pagination
 	offset := (req.Page - 1) * req.PageSize
@@ -496,6 +820,31 @@ func (s *{{.Name}}Service) GetListAdvanced(req contracts.ListRequest, filters ma
 	}, nil
 }
 
+// GetTrashed lists soft-deleted {{.LowerPluralName}} for the trash screen
+// Implements TrashedServiceContract interface
+func (s *{{.Name}}Service) GetTrashed(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	if err := s.ValidateListRequest(&req); err != nil {
+		return nil, err
+	}
+	s.SanitizeListRequest(&req)
+
+	orderClause := s.buildOrderClause(req)
+	var page{{.Name}}s []models.{{.Name}}
+	total, err := s.PaginateTrashed(ctx, &models.{{.Name}}{}, orderClause, req, &page{{.Name}}s, s.GetRelations()...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]interface{}, len(page{{.Name}}s))
+	for i, record := range page{{.Name}}s {
+		data[i] = record
+	}
+
+	result := s.BuildPaginatedResult(data, total, req)
+	s.ApplyNextCursor(result, req, orderClause, page{{.Name}}s)
+	return result, nil
+}
+
 // GetByID - Implements CrudServiceContract interface
 func (s *{{.Name}}Service) GetByID(id uint) (interface{}, error) {
 	if id == 0 {
@@ -508,7 +857,7 @@ func (s *{{.Name}}Service) GetByID(id uint) (interface{}, error) {
 // get{{.Name}}ByID is a helper method that returns the actual model type
 func (s *{{.Name}}Service) get{{.Name}}ByID(id uint) (*models.{{.Name}}, error) {
 	var {{.LowerName}} models.{{.Name}}
-	if err := facades.Orm().Query().Model(&models.{{.Name}}{}).Where("id = ?", id).First(&{{.LowerName}}); err != nil {
+	if err := s.WithRelations(facades.Orm().Query().Model(&models.{{.Name}}{}), s.GetRelations()).Where("id = ?", id).First(&{{.LowerName}}); err != nil {
 		return nil, fmt.Errorf("{{.LowerName}} not found: %w", err)
 	}
 
@@ -527,6 +876,10 @@ func (s *{{.Name}}Service) Create(data map[string]interface{}) (interface{}, err
 
 // create{{.Name}} is a helper method that returns the actual model type
 func (s *{{.Name}}Service) create{{.Name}}(data map[string]interface{}) (*models.{{.Name}}, error) {
+	// Only fillable fields are written, whatever else the caller passed; the acting user is read first
+	actorID := contracts.Actor(data)
+	data = contracts.OnlyFillable(data, s.GetFillableFields())
+
 	// Basic validation
 	if err := s.validate{{.Name}}Data(data, false); err != nil {
 		return nil, err
@@ -537,21 +890,30 @@ func (s *{{.Name}}Service) create{{.Name}}(data map[string]interface{}) (*models
 		data["is_active"] = true
 	}
 
-	// Create {{.LowerName}} struct from data
-	{{.LowerName}} := models.{{.Name}}{
-		Name:        data["name"].(string),
-		IsActive:    data["is_active"].(bool),
+	// Drop empty strings so optional dates and numbers keep their zero value
+	for field, value := range data {
+		if str, ok := value.(string); ok && str == "" {
+			delete(data, field)
+		}
 	}
 
-	if desc, ok := data["description"].(string); ok {
-		{{.LowerName}}.Description = desc
+	// Create {{.LowerName}} struct from data using the model's JSON field names
+	var {{.LowerName}} models.{{.Name}}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid {{.LowerName}} data: %w", err)
 	}
+	if err := json.Unmarshal(payload, &{{.LowerName}}); err != nil {
+		return nil, fmt.Errorf("invalid {{.LowerName}} data: %w", err)
+	}
+	s.StampCreatedBy(&{{.LowerName}}, actorID)
 
 	// Create using GORM
 	if err := facades.Orm().Query().Create(&{{.LowerName}}); err != nil {
 		return nil, fmt.Errorf("failed to create {{.LowerName}}: %w", err)
 	}
 
+	s.DispatchEvent(contracts.EventCreated, &{{.LowerName}})
 	return &{{.LowerName}}, nil
 }
 
@@ -577,14 +939,24 @@ func (s *{{.Name}}Service) update{{.Name}}(id uint, data map[string]interface{})
 		return nil, err
 	}
 
-	// Update using GORM
+	// Only fillable fields are written, whatever else the caller passed; the acting user is read first
+	actorID := contracts.Actor(data)
+	data = contracts.OnlyFillable(data, s.GetFillableFields())
+	s.StampUpdatedBy(&models.{{.Name}}{}, data, actorID)
+
+{{.EncodeJSONColumns}}	// Update using GORM
 	var {{.LowerName}} models.{{.Name}}
 	if _, err := facades.Orm().Query().Model(&{{.LowerName}}).Where("id = ?", id).Update(data); err != nil {
 		return nil, fmt.Errorf("failed to update {{.LowerName}}: %w", err)
 	}
 
 	// Return updated {{.LowerName}}
-	return s.get{{.Name}}ByID(id)
+	updated, err := s.get{{.Name}}ByID(id)
+	if err != nil {
+		return nil, err
+	}
+	s.DispatchEvent(contracts.EventUpdated, updated)
+	return updated, nil
 }
 
 // Delete - Implements CrudServiceContract interface
@@ -594,7 +966,7 @@ func (s *{{.Name}}Service) Delete(id uint) error {
 	}
 
 	// Check if {{.LowerName}} exists
-	_, err := s.get{{.Name}}ByID(id)
+	{{.LowerName}}, err := s.get{{.Name}}ByID(id)
 	if err != nil {
 		return err
 	}
@@ -604,19 +976,20 @@ func (s *{{.Name}}Service) Delete(id uint) error {
 		return fmt.Errorf("failed to delete {{.LowerName}}: %w", err)
 	}
 
+	s.DispatchEvent(contracts.EventDeleted, {{.LowerName}})
 	return nil
 }
 
 // CONTRACT IMPLEMENTATIONS - Required by CompleteCrudService interface
 
 // PaginationServiceContract implementation
-func (s *{{.Name}}Service) GetPaginatedList(req contracts.ListRequest) (*contracts.PaginatedResult, error) {
-	return s.GetList(req)
+func (s *{{.Name}}Service) GetPaginatedList(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	return s.GetList(ctx, req)
 }
 
 // SortableServiceContract implementation
 func (s *{{.Name}}Service) GetSortableFields() []string {
-	return []string{"id", "name", "description", "is_active", "createdAt", "updatedAt"}
+	return []string{{{.SortableFields}}}
 }
 
 func (s *{{.Name}}Service) ValidateSortField(field string) bool {
@@ -637,9 +1010,25 @@ func (s *{{.Name}}Service) MapSortField(frontendField string) (string, bool) {
 	return "", false
 }
 
+// GetDefaultSort is the order lists use when no valid sort is requested
+func (s *{{.Name}}Service) GetDefaultSort() (string, string) {
+	return "{{.DefaultSortField}}", "{{.DefaultSortDirection}}"
+}
+
+// buildOrderClause resolves the requested sort into an ORDER BY clause, falling back to the default sort
+func (s *{{.Name}}Service) buildOrderClause(req contracts.ListRequest) string {
+	// sort=status,title with direction=asc,desc sorts by several columns; invalid ones are dropped
+	if orderClause := s.BuildOrderClause(req, s.ValidateSortField, s.MapSortField); orderClause != "" {
+		return orderClause
+	}
+
+	defaultField, defaultDir := s.GetDefaultSort()
+	return defaultField + " " + defaultDir
+}
+
 // FilterableServiceContract implementation
 func (s *{{.Name}}Service) GetFilterableFields() []string {
-	return []string{"name", "is_active"}
+	return []string{"{{.TitleColumn}}", "is_active", "created_at"}
 }
 
 func (s *{{.Name}}Service) ValidateFilterField(field string) bool {
@@ -653,17 +1042,35 @@ func (s *{{.Name}}Service) ValidateFilterField(field string) bool {
 }
 
 func (s *{{.Name}}Service) GetSearchableFields() []string {
-	return []string{"name", "description"}
+	return []string{{{.SearchableFields}}}
 }
 
+// GetFilterFieldTypes coerces is_active from query strings such as is_active=true
+func (s *{{.Name}}Service) GetFilterFieldTypes() map[string]string {
+	return map[string]string{"is_active": contracts.FilterTypeBool}
+}
+
+// {{.LowerName}}MultiValueFilters are the filters that accept a list of values, matched with IN (...)
+var {{.LowerName}}MultiValueFilters = map[string]bool{"is_active": true}
+
 func (s *{{.Name}}Service) BuildFilterQuery(filters map[string]interface{}) (map[string]interface{}, error) {
 	validatedFilters := make(map[string]interface{})
+	fieldTypes := s.GetFilterFieldTypes()
 
 	for field, value := range filters {
 		if !s.ValidateFilterField(field) {
 			continue // Skip invalid fields
 		}
 
+		value, ok := contracts.CoerceFilterValue(value, fieldTypes[field])
+		if !ok {
+			continue // Skip values that don't convert to the field's type
+		}
+
+		if _, isList := contracts.FilterValueList(value); isList && !{{.LowerName}}MultiValueFilters[field] {
+			continue // Skip lists for single-value filters
+		}
+
 		if !s.ValidateFilterValue(field, value) {
 			continue // Skip invalid values
 		}
@@ -675,24 +1082,18 @@ func (s *{{.Name}}Service) BuildFilterQuery(filters map[string]interface{}) (map
 }
 
 // SearchableServiceContract implementation
-func (s *{{.Name}}Service) Search(query string, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+func (s *{{.Name}}Service) Search(ctx context.Context, query string, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
 	if err := s.ValidateSearchQuery(query); err != nil {
 		return nil, err
 	}
 
 	req.Search = query
-	return s.GetList(req)
+	return s.GetList(ctx, req)
 }
 
 func (s *{{.Name}}Service) ValidateSearchQuery(query string) error {
-	query = strings.TrimSpace(query)
-	if len(query) < 2 {
-		return fmt.Errorf("search query must be at least 2 characters")
-	}
-	if len(query) > 100 {
-		return fmt.Errorf("search query cannot exceed 100 characters")
-	}
-	return nil
+	minLength, maxLength := s.GetSearchConstraints()
+	return s.ValidateSearchLength(query, minLength, maxLength)
 }
 
 // BulkOperationsContract implementation
@@ -718,14 +1119,11 @@ func (s *{{.Name}}Service) BulkUpdate(ids []uint, data map[string]interface{}) e
 		return err
 	}
 
-	for _, id := range ids {
+	// Continue past individual failures so callers get a per-ID summary
+	return s.RunBulkOperation("update", ids, func(id uint) error {
 		_, err := s.Update(id, data)
-		if err != nil {
-			return fmt.Errorf("bulk update failed for ID %d: %w", id, err)
-		}
-	}
-
-	return nil
+		return err
+	})
 }
 
 func (s *{{.Name}}Service) BulkDelete(ids []uint) error {
@@ -733,13 +1131,8 @@ func (s *{{.Name}}Service) BulkDelete(ids []uint) error {
 		return err
 	}
 
-	for _, id := range ids {
-		if err := s.Delete(id); err != nil {
-			return fmt.Errorf("bulk delete failed for ID %d: %w", id, err)
-		}
-	}
-
-	return nil
+	// Continue past individual failures so callers get a per-ID summary
+	return s.RunBulkOperation("delete", ids, s.Delete)
 }
 
 // CrudServiceConfiguration implementation
@@ -749,26 +1142,31 @@ func (s *{{.Name}}Service) GetModel() interface{} {
 
 func (s *{{.Name}}Service) GetValidationRules() map[string]interface{} {
 	return map[string]interface{}{
-		"name":        "required|string|max:255",
-		"description": "string|max:1000",
-		"is_active":   "boolean",
+{{.ValidationRules}}
 	}
 }
 
 func (s *{{.Name}}Service) GetColumnMapping() map[string]string {
 	return map[string]string{
-		"id":          "id",
-		"name":        "name",
-		"description": "description",
-		"isActive":    "is_active",
-		"createdAt":   "created_at",
-		"updatedAt":   "updated_at",
-		"created_at":  "created_at",
-		"updated_at":  "updated_at",
-		"is_active":   "is_active",
+		"id":         "id",
+{{.ColumnMappings}}
+		"createdAt":  "created_at",
+		"updatedAt":  "updated_at",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
 	}
 }
 
+// GetFillableFields lists the fields create and update write; anything else is dropped
+func (s *{{.Name}}Service) GetFillableFields() []string {
+	return []string{ {{.FillableFields}} }
+}
+
+// GetRelations lists the belongsTo relations eager loaded with every {{.LowerName}}
+func (s *{{.Name}}Service) GetRelations() []string {
+	return []string{ {{.Relations}} }
+}
+
 // HELPER METHODS
 
 // validateWithRules uses the validation rules from the contract
@@ -791,54 +1189,48 @@ func (s *{{.Name}}Service) validateWithRules(data map[string]interface{}, isUpda
 	return s.validate{{.Name}}Data(data, isUpdate)
 }
 
-// validate{{.Name}}Data performs simple validation
+// validate{{.Name}}Data performs simple validation, collecting every failure by field
 func (s *{{.Name}}Service) validate{{.Name}}Data(data map[string]interface{}, isUpdate bool) error {
+	errs := contracts.NewFieldValidationError()
+
 	// Required fields for creation
 	if !isUpdate {
-		requiredFields := []string{"name"}
+		requiredFields := []string{{{.RequiredFields}}}
 		for _, field := range requiredFields {
 			if value, exists := data[field]; !exists || value == "" {
-				return fmt.Errorf("%s is required", field)
+				errs.Add(field, fmt.Sprintf("%s is required", field))
 			}
 		}
 	}
 
-	// Validate name if provided
-	if name, ok := data["name"].(string); ok && name != "" {
-		if len(name) < 2 || len(name) > 255 {
-			return fmt.Errorf("name must be between 2 and 255 characters")
+	// Validate string lengths if provided
+	maxLengths := map[string]int{{{.MaxLengths}}}
+	for field, maxLength := range maxLengths {
+		if value, ok := data[field].(string); ok && len(value) > maxLength {
+			errs.Add(field, fmt.Sprintf("%s cannot exceed %d characters", field, maxLength))
 		}
 	}
 
-	// Validate description if provided
-	if desc, exists := data["description"]; exists {
-		if descStr, ok := desc.(string); ok {
-			if len(descStr) > 1000 {
-				return fmt.Errorf("description cannot exceed 1000 characters")
-			}
-		}
-	}
-
-	return nil
+	return errs.Err()
 }
 `
 
-	return receiver.writeFileFromTemplate(config.ServicePath, template, config, force)
+	return receiver.writeGeneratedFile(config.ServicePath, template, config, force)
 }
 
-func (receiver *MakeCrudE2E) generateRequests(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateRequests(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	template := `package requests
 
 import (
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/contracts/validation"
+
+	"players/app/contracts"
 )
 
 // {{.Name}}CreateRequest handles validation for creating {{.LowerPluralName}}
 type {{.Name}}CreateRequest struct {
-	Name        string ` + "`" + `form:"name" json:"name"` + "`" + `
-	Description string ` + "`" + `form:"description" json:"description"` + "`" + `
-	IsActive    bool   ` + "`" + `form:"is_active" json:"is_active"` + "`" + `
+{{.RequestFields}}
 }
 
 // Authorize determines if the user can make this request
@@ -850,35 +1242,26 @@ func (r *{{.Name}}CreateRequest) Authorize(ctx http.Context) error {
 // Rules returns the validation rules for the request
 func (r *{{.Name}}CreateRequest) Rules(ctx http.Context) map[string]string {
 	return map[string]string{
-		"name":        "required|string|max:255|min:2",
-		"description": "string|max:1000",
-		"is_active":   "boolean",
+{{.RequestCreateRules}}
 	}
 }
 
-// Messages returns custom validation messages
+// Messages returns custom validation messages, translated from lang/<locale>/validation.json
 func (r *{{.Name}}CreateRequest) Messages(ctx http.Context) map[string]string {
-	return map[string]string{
-		"name.required": "{{.Name}} name is required",
-		"name.min":      "{{.Name}} name must be at least 2 characters",
-		"name.max":      "{{.Name}} name cannot exceed 255 characters",
-		"description.max": "Description cannot exceed 1000 characters",
-	}
+	return contracts.ValidationMessages(ctx, "{{.LowerPluralName}}",
+{{.RequestCreateMessages}}
+	)
 }
 
-// Attributes returns custom attribute names for validation
+// Attributes returns custom attribute names, translated from lang/<locale>/attributes.json
 func (r *{{.Name}}CreateRequest) Attributes(ctx http.Context) map[string]string {
-	return map[string]string{
-		"name":        "{{.Name}} Name",
-		"description": "Description",
-		"is_active":   "Active Status",
-	}
+	return contracts.ValidationAttributes(ctx, "{{.LowerPluralName}}", {{.RequestAttributes}})
 }
 
 // PrepareForValidation allows you to modify the data before validation
 func (r *{{.Name}}CreateRequest) PrepareForValidation(ctx http.Context, data validation.Data) error {
 	// Set default values or modify data before validation
-	if data.Get("is_active") == nil {
+	if _, exists := data.Get("is_active"); !exists {
 		data.Set("is_active", true)
 	}
 	return nil
@@ -887,18 +1270,14 @@ func (r *{{.Name}}CreateRequest) PrepareForValidation(ctx http.Context, data val
 // ToCreateData converts the request to data suitable for the service
 func (r *{{.Name}}CreateRequest) ToCreateData() map[string]interface{} {
 	return map[string]interface{}{
-		"name":        r.Name,
-		"description": r.Description,
-		"is_active":   r.IsActive,
+{{.ToCreateData}}
 	}
 }
 
 // {{.Name}}UpdateRequest handles validation for updating {{.LowerPluralName}}
 type {{.Name}}UpdateRequest struct {
-	ID          uint   ` + "`" + `form:"id" json:"id"` + "`" + `
-	Name        string ` + "`" + `form:"name" json:"name"` + "`" + `
-	Description string ` + "`" + `form:"description" json:"description"` + "`" + `
-	IsActive    bool   ` + "`" + `form:"is_active" json:"is_active"` + "`" + `
+	ID uint ` + "`" + `form:"id" json:"id"` + "`" + `
+{{.RequestUpdateFields}}
 }
 
 // Authorize determines if the user can make this request
@@ -910,28 +1289,20 @@ func (r *{{.Name}}UpdateRequest) Authorize(ctx http.Context) error {
 // Rules returns the validation rules for the request
 func (r *{{.Name}}UpdateRequest) Rules(ctx http.Context) map[string]string {
 	return map[string]string{
-		"name":        "string|max:255|min:2",
-		"description": "string|max:1000",
-		"is_active":   "boolean",
+{{.RequestUpdateRules}}
 	}
 }
 
 // Messages returns custom validation messages
 func (r *{{.Name}}UpdateRequest) Messages(ctx http.Context) map[string]string {
-	return map[string]string{
-		"name.min":      "{{.Name}} name must be at least 2 characters",
-		"name.max":      "{{.Name}} name cannot exceed 255 characters",
-		"description.max": "Description cannot exceed 1000 characters",
-	}
+	return contracts.ValidationMessages(ctx, "{{.LowerPluralName}}",
+{{.RequestUpdateMessages}}
+	)
 }
 
 // Attributes returns custom attribute names for validation
 func (r *{{.Name}}UpdateRequest) Attributes(ctx http.Context) map[string]string {
-	return map[string]string{
-		"name":        "{{.Name}} Name",
-		"description": "Description",
-		"is_active":   "Active Status",
-	}
+	return contracts.ValidationAttributes(ctx, "{{.LowerPluralName}}", {{.RequestAttributes}})
 }
 
 // PrepareForValidation allows you to modify the data before validation
@@ -943,34 +1314,66 @@ func (r *{{.Name}}UpdateRequest) PrepareForValidation(ctx http.Context, data val
 // ToUpdateData converts the request to data suitable for the service
 func (r *{{.Name}}UpdateRequest) ToUpdateData() map[string]interface{} {
 	data := make(map[string]interface{})
-	
-	if r.Name != "" {
-		data["name"] = r.Name
-	}
-	if r.Description != "" {
-		data["description"] = r.Description
-	}
-	// Always include is_active for updates
-	data["is_active"] = r.IsActive
-	
+
+{{.ToUpdateData}}
+
 	return data
 }
 `
 
-	return receiver.writeFileFromTemplate(config.RequestPath, template, config, force)
+	return receiver.writeGeneratedFile(config.RequestPath, template, config, force)
+}
+
+// generateTranslations adds the field labels to lang/en/attributes.json, where the generated
+// requests look up attribute names. Other locales are translated by hand from the English file.
+func (receiver *MakeCrudE2E) generateTranslations(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
+	attributes := map[string]interface{}{}
+	if content, err := os.ReadFile(config.LangAttributesPath); err == nil {
+		if err := json.Unmarshal(content, &attributes); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", config.LangAttributesPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", config.LangAttributesPath, err)
+	}
+
+	if _, exists := attributes[config.LowerPluralName]; exists && !force {
+		return nil, fmt.Errorf("%s already names the %s attributes (use --force to overwrite)", config.LangAttributesPath, config.LowerPluralName)
+	}
+	labels := make(map[string]string, len(config.Fields))
+	for _, field := range config.Fields {
+		labels[field.Column] = field.Label
+	}
+	attributes[config.LowerPluralName] = labels
+
+	encoded, err := json.MarshalIndent(attributes, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode attribute names: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(config.LangAttributesPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for %s: %w", config.LangAttributesPath, err)
+	}
+	if err := os.WriteFile(config.LangAttributesPath, append(encoded, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", config.LangAttributesPath, err)
+	}
+	return []string{config.LangAttributesPath}, nil
 }
 
-func (receiver *MakeCrudE2E) generateController(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateController(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	template := `package controllers
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+	"github.com/goravel/framework/validation"
 	"players/app/auth"
 	"players/app/contracts"
 	"players/app/helpers"
 	"players/app/http/requests"
+	"players/app/http/resources"
 	"players/app/services"
 )
 
@@ -990,6 +1393,8 @@ func New{{.Name}}Controller() *{{.Name}}Controller {
 		authHelper:         helpers.NewAuthHelper(),
 	}
 
+	controller.SetResourceTransformer(resources.{{.Name}})
+
 	// Register controller with validation
 	contracts.MustRegisterCrudController("{{.LowerPluralName}}", controller)
 
@@ -1008,18 +1413,35 @@ func (c *{{.Name}}Controller) Index(ctx http.Context) http.Response {
 
 	// Check permission
 	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.viewAny", nil); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: "+err.Error())
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	// Range bounds such as created_at_from; ?trashed=with|only includes soft-deleted rows
+	filters := c.RangeFilterParams(ctx, nil)
+	if trashed := ctx.Request().Query("trashed"); trashed != "" {
+		if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.restore", nil); err != nil {
+			return c.AccessDeniedResponse(ctx, err)
+		}
+		filters["trashed"] = trashed
 	}
 
 	// Get {{.LowerPluralName}} using service
-	result, err := c.{{.LowerName}}Service.GetList(*req)
+	var result *contracts.PaginatedResult
+	if len(filters) > 0 {
+		result, err = c.{{.LowerName}}Service.GetListAdvanced(ctx.Context(), *req, filters)
+	} else {
+		result, err = c.{{.LowerName}}Service.GetList(ctx.Context(), *req)
+	}
 	if err != nil {
-		return c.InternalErrorResponse(ctx, "Failed to retrieve {{.LowerPluralName}}: "+err.Error())
+		if _, ok := err.(*contracts.RangeFilterError); ok {
+			return c.BadRequestResponse(ctx, err.Error(), nil)
+		}
+		return c.QueryFailedResponse(ctx, "Failed to retrieve {{.LowerPluralName}}", err)
 	}
 
 	// Build standardized paginated response
 	response := c.BuildPaginatedResponse(result, req)
-	return c.SuccessResponse(ctx, response, "{{.PluralName}} retrieved successfully")
+	return c.ConditionalResponse(ctx, response, "{{.PluralName}} retrieved successfully")
 }
 
 // Show GET /{{.LowerPluralName}}/{id} - Implements CrudControllerContract (JSON for modals)
@@ -1032,9 +1454,9 @@ func (c *{{.Name}}Controller) Show(ctx http.Context) http.Response {
 		})
 	}
 
-	// Check authorization
+	// Check authorization before the lookup, so callers without access can't probe which IDs exist
 	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.view", nil); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: "+err.Error())
+		return c.AccessDeniedResponse(ctx, err)
 	}
 
 	// Get the {{.LowerName}}
@@ -1043,27 +1465,35 @@ func (c *{{.Name}}Controller) Show(ctx http.Context) http.Response {
 		return c.ResourceNotFoundResponse(ctx, "{{.LowerName}}", id)
 	}
 
-	return c.SuccessResponse(ctx, {{.LowerName}}, "{{.Name}} details retrieved successfully")
+	// Check again against the loaded record so ownership-scoped permissions apply
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.view", {{.LowerName}}); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	return c.ConditionalResponse(ctx, c.TransformResource({{.LowerName}}), "{{.Name}} details retrieved successfully")
 }
 
 // Store POST /{{.LowerPluralName}} - Implements CrudControllerContract
 func (c *{{.Name}}Controller) Store(ctx http.Context) http.Response {
 	// Check authorization
 	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.create", nil); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: "+err.Error())
+		return c.AccessDeniedResponse(ctx, err)
 	}
 
 	// Validate create request using contract
 	data, err := c.ValidateCreateRequest(ctx)
 	if err != nil {
-		return c.ValidationErrorResponse(ctx, map[string]interface{}{
-			"validation_error": err.Error(),
-		})
+		return c.ValidationFailedResponse(ctx, err)
 	}
 
-	// Create the {{.LowerName}} using validated data
+	// Create the {{.LowerName}} using validated data, as the current user
+	contracts.SetActor(data, services.AuditActor(c.GetCurrentUser(ctx)))
 	{{.LowerName}}, err := c.{{.LowerName}}Service.Create(data)
 	if err != nil {
+		// Service-level validation failures are returned by field like request validation
+		if _, ok := err.(*contracts.FieldValidationError); ok {
+			return c.ValidationFailedResponse(ctx, err)
+		}
 		return c.InternalErrorResponse(ctx, "Failed to create {{.LowerName}}: "+err.Error())
 	}
 
@@ -1080,28 +1510,35 @@ func (c *{{.Name}}Controller) Update(ctx http.Context) http.Response {
 		})
 	}
 
+	// Check authorization before the lookup, then against the loaded record for ownership
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.update", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
 	// Check if {{.LowerName}} exists
-	_, err = c.{{.LowerName}}Service.GetByID(id)
+	existing, err := c.{{.LowerName}}Service.GetByID(id)
 	if err != nil {
 		return c.ResourceNotFoundResponse(ctx, "{{.LowerName}}", id)
 	}
 
-	// Check authorization
-	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.update", nil); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: "+err.Error())
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.update", existing); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
 	}
 
 	// Validate update request using contract
 	data, err := c.ValidateUpdateRequest(ctx, id)
 	if err != nil {
-		return c.ValidationErrorResponse(ctx, map[string]interface{}{
-			"validation_error": err.Error(),
-		})
+		return c.ValidationFailedResponse(ctx, err)
 	}
 
-	// Update the {{.LowerName}} using validated data
+	// Update the {{.LowerName}} using validated data, as the current user
+	contracts.SetActor(data, services.AuditActor(c.GetCurrentUser(ctx)))
 	updated{{.Name}}, err := c.{{.LowerName}}Service.Update(id, data)
 	if err != nil {
+		// Service-level validation failures are returned by field like request validation
+		if _, ok := err.(*contracts.FieldValidationError); ok {
+			return c.ValidationFailedResponse(ctx, err)
+		}
 		return c.InternalErrorResponse(ctx, "Failed to update {{.LowerName}}: "+err.Error())
 	}
 
@@ -1118,15 +1555,33 @@ func (c *{{.Name}}Controller) Delete(ctx http.Context) http.Response {
 		})
 	}
 
+	// ?force=true permanently removes the {{.LowerName}}, including an already soft-deleted one
+	if ctx.Request().QueryBool("force") {
+		if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.forceDelete", nil); err != nil {
+			return c.AccessDeniedResponse(ctx, err)
+		}
+		if err := c.{{.LowerName}}Service.ForceDelete(id); err != nil {
+			if errors.Is(err, contracts.ErrRecordNotFound) {
+				return c.ResourceNotFoundResponse(ctx, "{{.LowerName}}", id)
+			}
+			return c.QueryFailedResponse(ctx, "Failed to delete {{.LowerName}}", err)
+		}
+		return c.ResourceDeletedResponse(ctx, "{{.LowerName}}", id)
+	}
+
+	// Check authorization before the lookup, then against the loaded record for ownership
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.delete", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
 	// Check if {{.LowerName}} exists
-	_, err = c.{{.LowerName}}Service.GetByID(id)
+	existing, err := c.{{.LowerName}}Service.GetByID(id)
 	if err != nil {
 		return c.ResourceNotFoundResponse(ctx, "{{.LowerName}}", id)
 	}
 
-	// Check authorization
-	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.delete", nil); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: "+err.Error())
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.delete", existing); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
 	}
 
 	// Delete the {{.LowerName}}
@@ -1138,23 +1593,237 @@ func (c *{{.Name}}Controller) Delete(ctx http.Context) http.Response {
 	return c.ResourceDeletedResponse(ctx, "{{.LowerName}}", id)
 }
 
+// Restore POST /{{.LowerPluralName}}/{id}/restore - brings back a soft-deleted {{.LowerName}}
+func (c *{{.Name}}Controller) Restore(ctx http.Context) http.Response {
+	// Validate ID parameter using contract
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid {{.LowerName}} ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	// Check authorization
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.restore", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	if err := c.{{.LowerName}}Service.Restore(id); err != nil {
+		return c.ResourceNotFoundResponse(ctx, "deleted {{.LowerName}}", id)
+	}
+
+	{{.LowerName}}, err := c.{{.LowerName}}Service.GetByID(id)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to load restored {{.LowerName}}: "+err.Error())
+	}
+
+	return c.SuccessResponse(ctx, c.TransformResource({{.LowerName}}), "{{.Name}} restored successfully")
+}
+
+// Trashed GET /{{.LowerPluralName}}/trashed - soft-deleted {{.LowerPluralName}} for the trash screen
+func (c *{{.Name}}Controller) Trashed(ctx http.Context) http.Response {
+	return c.TrashedResponse(ctx, "{{.LowerPluralName}}.viewAny", c, c.{{.LowerName}}Service)
+}
+
+// Exists GET /{{.LowerPluralName}}/exists?field=...&value=... - duplicate check for the {{.LowerName}} form
+func (c *{{.Name}}Controller) Exists(ctx http.Context) http.Response {
+	return c.ExistsResponse(ctx, "{{.LowerPluralName}}.viewAny", c, c.{{.LowerName}}Service)
+}
+
+// Schema GET /{{.LowerPluralName}}/schema - the fields {{.LowerName}} lists can sort, filter and search on
+func (c *{{.Name}}Controller) Schema(ctx http.Context) http.Response {
+	return c.SchemaResponse(ctx, "{{.LowerPluralName}}.viewAny", c, c.{{.LowerName}}Service)
+}
+
+// Related GET /{{.LowerPluralName}}/{id}/related/{relation} - one page of a {{.LowerName}}'s relation, limited to GetRelations
+func (c *{{.Name}}Controller) Related(ctx http.Context) http.Response {
+	return c.RelatedResponse(ctx, "{{.LowerPluralName}}.view", c, c.{{.LowerName}}Service)
+}
+
+// Activate POST /{{.LowerPluralName}}/{id}/activate
+func (c *{{.Name}}Controller) Activate(ctx http.Context) http.Response {
+	return c.SetActiveResponse(ctx, true, "{{.LowerPluralName}}.update", c, c.{{.LowerName}}Service)
+}
+
+// Deactivate POST /{{.LowerPluralName}}/{id}/deactivate
+func (c *{{.Name}}Controller) Deactivate(ctx http.Context) http.Response {
+	return c.SetActiveResponse(ctx, false, "{{.LowerPluralName}}.update", c, c.{{.LowerName}}Service)
+}
+
+// Export GET /{{.LowerPluralName}}/export - streams CSV or JSON honoring the current search and filters
+func (c *{{.Name}}Controller) Export(ctx http.Context) http.Response {
+	// Check authorization
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.export", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	req, err := c.ValidateExportRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid export parameters", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	return c.ExportResponse(ctx, "{{.LowerPluralName}}", req, func(listReq contracts.ListRequest) (*contracts.PaginatedResult, error) {
+		return c.{{.LowerName}}Service.GetListAdvanced(ctx.Context(), listReq, req.Filters)
+	})
+}
+
+// Import POST /{{.LowerPluralName}}/import - creates {{.LowerPluralName}} from a CSV or JSON upload, or updates
+// rows whose id matches an existing {{.LowerName}} when updateExisting is set
+func (c *{{.Name}}Controller) Import(ctx http.Context) http.Response {
+	// Check authorization
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.create", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	req, err := c.ValidateImportRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid import file", map[string]interface{}{
+			"import_error": err.Error(),
+		})
+	}
+
+	if req.UpdateExisting {
+		if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.update", nil); err != nil {
+			return c.AccessDeniedResponse(ctx, err)
+		}
+	}
+
+	return c.ImportResponse(ctx, req, func(row contracts.ImportRow) (string, error) {
+		return c.import{{.Name}}(ctx, row.Data, req.UpdateExisting)
+	})
+}
+
+// import{{.Name}} validates one imported row with the create request rules and saves it
+func (c *{{.Name}}Controller) import{{.Name}}(ctx http.Context, data map[string]interface{}, updateExisting bool) (string, error) {
+	var createRequest requests.{{.Name}}CreateRequest
+
+	validator, err := facades.Validation().Make(data, createRequest.Rules(ctx),
+		validation.Messages(createRequest.Messages(ctx)),
+		validation.Attributes(createRequest.Attributes(ctx)),
+		validation.PrepareForValidation(ctx, createRequest.PrepareForValidation),
+	)
+	if err != nil {
+		return "", fmt.Errorf("validation failed: %w", err)
+	}
+	if validator.Fails() {
+		return "", contracts.FieldValidationErrorFromValidator(validator.Errors().All())
+	}
+	if err := validator.Bind(&createRequest); err != nil {
+		return "", fmt.Errorf("invalid row: %w", err)
+	}
+
+	{{.LowerName}}Data := createRequest.ToCreateData()
+
+	if rawID, ok := data["id"]; ok && updateExisting {
+		if id, err := strconv.ParseUint(fmt.Sprint(rawID), 10, 64); err == nil {
+			if _, err := c.{{.LowerName}}Service.GetByID(uint(id)); err == nil {
+				if _, err := c.{{.LowerName}}Service.Update(uint(id), {{.LowerName}}Data); err != nil {
+					return "", err
+				}
+				return contracts.ImportStatusUpdated, nil
+			}
+		}
+	}
+
+	if _, err := c.{{.LowerName}}Service.Create({{.LowerName}}Data); err != nil {
+		return "", err
+	}
+	return contracts.ImportStatusCreated, nil
+}
+
+// BulkDelete DELETE /{{.LowerPluralName}}/bulk
+func (c *{{.Name}}Controller) BulkDelete(ctx http.Context) http.Response {
+	// Check authorization
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.delete", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	req, err := c.ValidateBulkRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid bulk request", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	err = c.{{.LowerName}}Service.BulkDelete(req.IDs)
+	return c.BulkOperationResponse(ctx, "delete", req.IDs, err)
+}
+
+// BulkUpdate PUT /{{.LowerPluralName}}/bulk
+func (c *{{.Name}}Controller) BulkUpdate(ctx http.Context) http.Response {
+	// Check authorization
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.update", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	req, err := c.ValidateBulkRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid bulk request", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+	if len(req.Data) == 0 {
+		return c.ValidationErrorResponse(ctx, map[string]interface{}{
+			"data": []string{"data must contain at least one field to update"},
+		})
+	}
+
+	err = c.{{.LowerName}}Service.BulkUpdate(req.IDs, req.Data)
+	return c.BulkOperationResponse(ctx, "update", req.IDs, err)
+}
+
+// BulkUpdateStatus PUT /{{.LowerPluralName}}/bulk/status
+func (c *{{.Name}}Controller) BulkUpdateStatus(ctx http.Context) http.Response {
+	// Check authorization
+	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.update", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	req, err := c.ValidateBulkRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid bulk request", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+	if req.IsActive == nil {
+		return c.ValidationErrorResponse(ctx, map[string]interface{}{
+			"is_active": []string{"is_active is required"},
+		})
+	}
+
+	err = c.{{.LowerName}}Service.BulkUpdate(req.IDs, map[string]interface{}{
+		"is_active": *req.IsActive,
+	})
+	return c.BulkOperationResponse(ctx, "status update", req.IDs, err)
+}
+
 // CONTRACT IMPLEMENTATIONS - Required by ResourceControllerContract interface
 
 // ValidationControllerContract implementation
 func (c *{{.Name}}Controller) ValidateCreateRequest(ctx http.Context) (map[string]interface{}, error) {
+	if err := c.RejectUnknownFields(ctx, c.{{.LowerName}}Service); err != nil {
+		return nil, err
+	}
+
 	var createRequest requests.{{.Name}}CreateRequest
 	errors, err := ctx.Request().ValidateRequest(&createRequest)
 	if err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 	if errors != nil {
-		return nil, fmt.Errorf("validation errors: %v", errors.All())
+		return nil, contracts.FieldValidationErrorFromValidator(errors.All())
 	}
 
 	return createRequest.ToCreateData(), nil
 }
 
 func (c *{{.Name}}Controller) ValidateUpdateRequest(ctx http.Context, id uint) (map[string]interface{}, error) {
+	if err := c.RejectUnknownFields(ctx, c.{{.LowerName}}Service); err != nil {
+		return nil, err
+	}
+
 	var updateRequest requests.{{.Name}}UpdateRequest
 	updateRequest.ID = id // Set the ID for validation context
 
@@ -1163,7 +1832,7 @@ func (c *{{.Name}}Controller) ValidateUpdateRequest(ctx http.Context, id uint) (
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 	if errors != nil {
-		return nil, fmt.Errorf("validation errors: %v", errors.All())
+		return nil, contracts.FieldValidationErrorFromValidator(errors.All())
 	}
 
 	return updateRequest.ToUpdateData(), nil
@@ -1175,8 +1844,9 @@ func (c *{{.Name}}Controller) GetValidationRules() map[string]interface{} {
 
 // AuthorizationControllerContract implementation
 func (c *{{.Name}}Controller) CheckPermission(ctx http.Context, permission string, resource interface{}) error {
+	// resource is the loaded model when there is one, so ownership-scoped permissions are enforced
 	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequirePermission(ctx, permission)
+	_, err := permHelper.RequirePermissionOn(ctx, permission, resource)
 	return err
 }
 
@@ -1199,10 +1869,10 @@ func (c *{{.Name}}Controller) BuildPermissionsMap(ctx http.Context, resourceType
 }
 `
 
-	return receiver.writeFileFromTemplate(config.ControllerPath, template, config, force)
+	return receiver.writeGeneratedFile(config.ControllerPath, template, config, force)
 }
 
-func (receiver *MakeCrudE2E) generatePageController(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generatePageController(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	template := `package controllers
 
 import (
@@ -1213,6 +1883,7 @@ import (
 	"players/app/contracts"
 	"players/app/helpers"
 	"players/app/http/inertia"
+	"players/app/http/resources"
 	"players/app/services"
 )
 
@@ -1251,15 +1922,15 @@ func (c *{{.Name}}PageController) Index(ctx http.Context) http.Response {
 	// Check permission
 	if err := c.CheckPermission(ctx, "{{.LowerPluralName}}.viewAny", nil); err != nil {
 		return inertia.Render(ctx, "Errors/403", map[string]interface{}{
-			"message": "Access denied: You don't have permission to view {{.LowerPluralName}}",
+			"message": contracts.Trans(ctx, "responses.access_denied", map[string]string{"reason": err.Error()}),
 		})
 	}
 
 	// Build permissions map using contract
-	permissions := c.BuildPermissionsMap(ctx, "{{.LowerName}}")
+	permissions := c.BuildPermissionsMap(ctx, "{{.LowerPluralName}}")
 
 	// Get {{.LowerPluralName}} data
-	{{.LowerPluralName}}Result, err := c.{{.LowerName}}Service.GetList(*req)
+	{{.LowerPluralName}}Result, err := c.{{.LowerName}}Service.GetList(ctx.Context(), *req)
 	if err != nil {
 		// Handle error gracefully, provide empty result
 		{{.LowerPluralName}}Result = &contracts.PaginatedResult{
@@ -1279,7 +1950,7 @@ func (c *{{.Name}}PageController) Index(ctx http.Context) http.Response {
 
 	// Build standardized page props using contract
 	data := map[string]interface{}{
-		"data":        {{.LowerPluralName}}Result.Data,
+		"data":        resources.Collection({{.LowerPluralName}}Result.Data, resources.{{.Name}}),
 		"total":       {{.LowerPluralName}}Result.Total,
 		"currentPage": {{.LowerPluralName}}Result.CurrentPage,
 		"lastPage":    {{.LowerPluralName}}Result.LastPage,
@@ -1324,31 +1995,23 @@ func (c *{{.Name}}PageController) get{{.Name}}Statistics() map[string]interface{
 
 // get{{.Name}}CountByStatus is a helper function to get {{.LowerName}} count by status
 func (c *{{.Name}}PageController) get{{.Name}}CountByStatus(isActive bool) int {
-	// This is a simplified version. In a real implementation,
-	// you'd want to add a method to the service to get counts efficiently
-	req := contracts.ListRequest{
-		PageSize: 1,
-		Filters: map[string]interface{}{
-			"is_active": isActive,
-		},
-	}
-
-	result, err := c.{{.LowerName}}Service.GetListAdvanced(req, map[string]interface{}{
+	count, err := c.{{.LowerName}}Service.CountByFilter(map[string]interface{}{
 		"is_active": isActive,
 	})
 	if err != nil {
 		return 0
 	}
 
-	return int(result.Total)
+	return int(count)
 }
 
 // CONTRACT IMPLEMENTATIONS - Required by PageControllerContract interface
 
 // AuthorizationControllerContract implementation
 func (c *{{.Name}}PageController) CheckPermission(ctx http.Context, permission string, resource interface{}) error {
+	// resource is the loaded model when there is one, so ownership-scoped permissions are enforced
 	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequirePermission(ctx, permission)
+	_, err := permHelper.RequirePermissionOn(ctx, permission, resource)
 	return err
 }
 
@@ -1371,47 +2034,66 @@ func (c *{{.Name}}PageController) BuildPermissionsMap(ctx http.Context, resource
 }
 `
 
-	return receiver.writeFileFromTemplate(config.PageControllerPath, template, config, force)
+	return receiver.writeGeneratedFile(config.PageControllerPath, template, config, force)
 }
 
-func (receiver *MakeCrudE2E) generateRoutes(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateRoutes(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	routeFile := fmt.Sprintf("routes/%s.go", config.LowerPluralName)
+
+	pageController := `
+	{{.LowerName}}PageController := controllers.New{{.Name}}PageController()`
+	pageRoutes := `
+
+	// Admin Web Routes (Inertia.js)
+	adminGroup := router.Prefix("/admin").Middleware("web", "auth")
+	{
+		adminGroup.Get("/{{.LowerPluralName}}", {{.LowerName}}PageController.Index)
+	}`
+	if config.APIOnly {
+		pageController, pageRoutes = "", ""
+	}
 	
 	template := `package routes
 
 import (
 	"github.com/goravel/framework/contracts/route"
 	"players/app/http/controllers"
+	"players/app/http/middleware"
 )
 
 // {{.Name}}Routes registers all {{.LowerName}} related routes
 func {{.Name}}Routes(router route.Route) {
-	{{.LowerName}}Controller := controllers.New{{.Name}}Controller()
-	{{.LowerName}}PageController := controllers.New{{.Name}}PageController()
+	{{.LowerName}}Controller := controllers.New{{.Name}}Controller()` + pageController + `
 
 	// API Routes
-	apiGroup := router.Prefix("/api").Middleware("cors")
+	apiGroup := router.Prefix("/api").Middleware(middleware.Cors())
 	{{.LowerName}}ApiGroup := apiGroup.Prefix("/{{.LowerPluralName}}")
 	{
 		{{.LowerName}}ApiGroup.Get("/", {{.LowerName}}Controller.Index)
+		{{.LowerName}}ApiGroup.Get("/export", {{.LowerName}}Controller.Export)
+		{{.LowerName}}ApiGroup.Post("/import", {{.LowerName}}Controller.Import)
+		{{.LowerName}}ApiGroup.Delete("/bulk", {{.LowerName}}Controller.BulkDelete)
+		{{.LowerName}}ApiGroup.Put("/bulk", {{.LowerName}}Controller.BulkUpdate)
+		{{.LowerName}}ApiGroup.Put("/bulk/status", {{.LowerName}}Controller.BulkUpdateStatus)
+		{{.LowerName}}ApiGroup.Get("/trashed", {{.LowerName}}Controller.Trashed)
+		{{.LowerName}}ApiGroup.Get("/exists", {{.LowerName}}Controller.Exists)
+		{{.LowerName}}ApiGroup.Get("/schema", {{.LowerName}}Controller.Schema)
 		{{.LowerName}}ApiGroup.Get("/{id}", {{.LowerName}}Controller.Show)
 		{{.LowerName}}ApiGroup.Post("/", {{.LowerName}}Controller.Store)
 		{{.LowerName}}ApiGroup.Put("/{id}", {{.LowerName}}Controller.Update)
 		{{.LowerName}}ApiGroup.Delete("/{id}", {{.LowerName}}Controller.Delete)
-	}
-
-	// Admin Web Routes (Inertia.js)
-	adminGroup := router.Prefix("/admin").Middleware("web", "auth")
-	{
-		adminGroup.Get("/{{.LowerPluralName}}", {{.LowerName}}PageController.Index)
-	}
+		{{.LowerName}}ApiGroup.Post("/{id}/restore", {{.LowerName}}Controller.Restore)
+		{{.LowerName}}ApiGroup.Post("/{id}/activate", {{.LowerName}}Controller.Activate)
+		{{.LowerName}}ApiGroup.Post("/{id}/deactivate", {{.LowerName}}Controller.Deactivate)
+		{{.LowerName}}ApiGroup.Get("/{id}/related/{relation}", {{.LowerName}}Controller.Related)
+	}` + pageRoutes + `
 }
 `
 
-	return receiver.writeFileFromTemplate(routeFile, template, config, force)
+	return receiver.writeGeneratedFile(routeFile, template, config, force)
 }
 
-func (receiver *MakeCrudE2E) generatePermissions(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generatePermissions(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	permissionFile := fmt.Sprintf("database/seeders/%s_permissions_seeder.go", config.LowerName)
 	
 	template := `package seeders
@@ -1441,6 +2123,8 @@ func (s *{{.Name}}PermissionsSeeder) Run() error {
 		{Name: "Delete {{.PluralName}}", Slug: "{{.LowerPluralName}}.delete", Category: "{{.LowerPluralName}}", Action: "delete", Description: "Delete {{.LowerPluralName}}"},
 		{Name: "Manage {{.PluralName}}", Slug: "{{.LowerPluralName}}.manage", Category: "{{.LowerPluralName}}", Action: "manage", Description: "Full {{.LowerName}} management"},
 		{Name: "Export {{.PluralName}}", Slug: "{{.LowerPluralName}}.export", Category: "{{.LowerPluralName}}", Action: "export", Description: "Export {{.LowerPluralName}} data"},
+		{Name: "Restore {{.PluralName}}", Slug: "{{.LowerPluralName}}.restore", Category: "{{.LowerPluralName}}", Action: "restore", Description: "Restore deleted {{.LowerPluralName}}"},
+		{Name: "Force Delete {{.PluralName}}", Slug: "{{.LowerPluralName}}.forceDelete", Category: "{{.LowerPluralName}}", Action: "forceDelete", Description: "Permanently delete {{.LowerPluralName}}"},
 	}
 
 	for _, permission := range permissions {
@@ -1464,6 +2148,7 @@ func (s *{{.Name}}PermissionsSeeder) Run() error {
 	adminPerms := []string{
 		"{{.LowerPluralName}}.viewAny", "{{.LowerPluralName}}.view", "{{.LowerPluralName}}.create", 
 		"{{.LowerPluralName}}.update", "{{.LowerPluralName}}.delete", "{{.LowerPluralName}}.manage", "{{.LowerPluralName}}.export",
+		"{{.LowerPluralName}}.restore",
 	}
 	s.assignPermissionsToRole("admin", adminPerms, permissionService)
 
@@ -1507,16 +2192,57 @@ func (s *{{.Name}}PermissionsSeeder) assignPermissionsToRole(roleSlug string, pe
 }
 `
 
-	return receiver.writeFileFromTemplate(permissionFile, template, config, force)
+	return receiver.writeGeneratedFile(permissionFile, template, config, force)
+}
+
+// maxSeedCount keeps --seed-count to a size that is still readable as a literal slice
+const maxSeedCount = 500
+
+func (receiver *MakeCrudE2E) generateSeeder(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
+	seederFile := fmt.Sprintf("database/seeders/%s_seeder.go", config.SnakeName)
+	receiver.renderSeederRows(&config)
+
+	template := `package seeders
+
+import (
+{{.SeederImports}}	"github.com/goravel/framework/facades"
+	"players/app/models"
+)
+
+// {{.Name}}Seeder inserts sample {{.LowerPluralName}} for local development and demos
+type {{.Name}}Seeder struct {
+}
+
+// Signature The name and signature of the seeder.
+func (s *{{.Name}}Seeder) Signature() string {
+	return "{{.Name}}Seeder"
+}
+
+// Run executes the seeder logic.
+func (s *{{.Name}}Seeder) Run() error {
+	{{.LowerPluralName}} := []models.{{.Name}}{
+{{.SeederRows}}
+	}
+
+	// Insert all {{.LowerPluralName}} into database
+	for _, {{.LowerName}} := range {{.LowerPluralName}} {
+		if err := facades.Orm().Query().Create(&{{.LowerName}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+`
+
+	return receiver.writeGeneratedFile(seederFile, template, config, force)
 }
 
-func (receiver *MakeCrudE2E) generateUITypes(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateUITypes(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	template := `// TypeScript type definitions for {{.Name}}
 export interface {{.Name}} {
   id: number;
-  name: string;
-  description: string;
-  is_active: boolean;
+{{.TSModelFields}}
   created_at: string;
   updated_at: string;
 }
@@ -1549,9 +2275,7 @@ export interface {{.Name}}Stats {
 }
 
 export interface {{.Name}}FormData {
-  name: string;
-  description: string;
-  is_active: boolean;
+{{.TSFields}}
 }
 
 export interface {{.Name}}BulkOperation {
@@ -1560,7 +2284,7 @@ export interface {{.Name}}BulkOperation {
 }
 
 export interface {{.Name}}ExportOptions {
-  format: 'csv' | 'json' | 'excel';
+  format: 'csv' | 'json';
   fields?: string[];
   includeStats?: boolean;
   filters?: {{.Name}}ListRequest;
@@ -1568,7 +2292,7 @@ export interface {{.Name}}ExportOptions {
 
 export interface {{.Name}}ImportData {
   file: File;
-  format: 'csv' | 'json' | 'excel';
+  format: 'csv' | 'json';
   skipErrors: boolean;
   updateExisting: boolean;
 }
@@ -1604,10 +2328,10 @@ export interface {{.Name}}DetailProps {
 }
 `
 
-	return receiver.writeFileFromTemplate(config.UITypesPath, template, config, force)
+	return receiver.writeGeneratedFile(config.UITypesPath, template, config, force)
 }
 
-func (receiver *MakeCrudE2E) generateUIComponents(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateUIComponents(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	// Create components directory
 	os.MkdirAll(config.UIComponentsPath, 0755)
 
@@ -1628,22 +2352,7 @@ export const {{.LowerName}}Columns = [
       <span className="font-mono text-sm">#{row.original.id}</span>
     ),
   },
-  {
-    accessorKey: 'name',
-    header: 'Name',
-    cell: ({ row }: { row: { original: {{.Name}} } }) => (
-      <div className="font-medium">{row.original.name}</div>
-    ),
-  },
-  {
-    accessorKey: 'description',
-    header: 'Description',
-    cell: ({ row }: { row: { original: {{.Name}} } }) => (
-      <div className="max-w-xs truncate text-muted-foreground">
-        {row.original.description || 'No description'}
-      </div>
-    ),
-  },
+{{.TSColumns}}
   {
     accessorKey: 'is_active',
     header: 'Status',
@@ -1715,14 +2424,11 @@ export const {{.LowerName}}Columns = [
 // Mobile-friendly columns
 export const {{.LowerName}}ColumnsMobile = [
   {
-    accessorKey: 'name',
-    header: 'Name',
+    accessorKey: '{{.TitleColumn}}',
+    header: '{{.TitleLabel}}',
     cell: ({ row }: { row: { original: {{.Name}} } }) => (
       <div>
-        <div className="font-medium">{row.original.name}</div>
-        <div className="text-sm text-muted-foreground">
-          {row.original.description ? row.original.description.substring(0, 50) + '...' : 'No description'}
-        </div>
+        <div className="font-medium">{row.original.{{.TitleColumn}}}</div>{{.TSMobileSubtitle}}
         <div className="flex items-center space-x-2 mt-1">
           <Badge variant={row.original.is_active ? 'default' : 'secondary'} className="text-xs">
             {row.original.is_active ? 'Active' : 'Inactive'}
@@ -1824,7 +2530,7 @@ export const {{.LowerName}}QuickFilters = [
 `
 
 	if err := receiver.writeFileFromTemplate(columnsFile, columnsTemplate, config, force); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Generate form components
@@ -1849,9 +2555,7 @@ export function {{.Name}}CreateForm({
   isLoading?: boolean;
 }) {
   const [formData, setFormData] = useState<{{.Name}}FormData>({
-    name: '',
-    description: '',
-    is_active: true,
+{{.TSFormDefaults}}
   });
 
   const [errors, setErrors] = useState<Record<string, string>>({});
@@ -1861,9 +2565,7 @@ export function {{.Name}}CreateForm({
     
     // Basic validation
     const newErrors: Record<string, string> = {};
-    if (!formData.name.trim()) {
-      newErrors.name = 'Name is required';
-    }
+{{.TSFormValidation}}
     
     if (Object.keys(newErrors).length > 0) {
       setErrors(newErrors);
@@ -1876,39 +2578,7 @@ export function {{.Name}}CreateForm({
 
   return (
     <form onSubmit={handleSubmit} className="space-y-4">
-      <div className="space-y-2">
-        <Label htmlFor="name">Name *</Label>
-        <Input
-          id="name"
-          value={formData.name}
-          onChange={(e) => setFormData({ ...formData, name: e.target.value })}
-          placeholder="Enter {{.LowerName}} name"
-          className={errors.name ? 'border-destructive' : ''}
-        />
-        {errors.name && (
-          <p className="text-sm text-destructive">{errors.name}</p>
-        )}
-      </div>
-
-      <div className="space-y-2">
-        <Label htmlFor="description">Description</Label>
-        <Textarea
-          id="description"
-          value={formData.description}
-          onChange={(e) => setFormData({ ...formData, description: e.target.value })}
-          placeholder="Enter {{.LowerName}} description"
-          rows={3}
-        />
-      </div>
-
-      <div className="flex items-center space-x-2">
-        <Switch
-          id="is_active"
-          checked={formData.is_active}
-          onCheckedChange={(checked) => setFormData({ ...formData, is_active: checked })}
-        />
-        <Label htmlFor="is_active">Active</Label>
-      </div>
+{{.TSFormInputs}}
 
       <div className="flex justify-end space-x-2">
         <Button type="button" variant="outline" onClick={onCancel}>
@@ -1935,9 +2605,7 @@ export function {{.Name}}EditForm({
   isLoading?: boolean;
 }) {
   const [formData, setFormData] = useState<{{.Name}}FormData>({
-    name: {{.LowerName}}.name,
-    description: {{.LowerName}}.description,
-    is_active: {{.LowerName}}.is_active,
+{{.TSFormEditValues}}
   });
 
   const [errors, setErrors] = useState<Record<string, string>>({});
@@ -1947,9 +2615,7 @@ export function {{.Name}}EditForm({
     
     // Basic validation
     const newErrors: Record<string, string> = {};
-    if (!formData.name.trim()) {
-      newErrors.name = 'Name is required';
-    }
+{{.TSFormValidation}}
     
     if (Object.keys(newErrors).length > 0) {
       setErrors(newErrors);
@@ -1962,39 +2628,7 @@ export function {{.Name}}EditForm({
 
   return (
     <form onSubmit={handleSubmit} className="space-y-4">
-      <div className="space-y-2">
-        <Label htmlFor="name">Name *</Label>
-        <Input
-          id="name"
-          value={formData.name}
-          onChange={(e) => setFormData({ ...formData, name: e.target.value })}
-          placeholder="Enter {{.LowerName}} name"
-          className={errors.name ? 'border-destructive' : ''}
-        />
-        {errors.name && (
-          <p className="text-sm text-destructive">{errors.name}</p>
-        )}
-      </div>
-
-      <div className="space-y-2">
-        <Label htmlFor="description">Description</Label>
-        <Textarea
-          id="description"
-          value={formData.description}
-          onChange={(e) => setFormData({ ...formData, description: e.target.value })}
-          placeholder="Enter {{.LowerName}} description"
-          rows={3}
-        />
-      </div>
-
-      <div className="flex items-center space-x-2">
-        <Switch
-          id="is_active"
-          checked={formData.is_active}
-          onCheckedChange={(checked) => setFormData({ ...formData, is_active: checked })}
-        />
-        <Label htmlFor="is_active">Active</Label>
-      </div>
+{{.TSFormInputs}}
 
       <div className="flex justify-end space-x-2">
         <Button type="button" variant="outline" onClick={onCancel}>
@@ -2019,18 +2653,13 @@ export function {{.Name}}DetailView({
   return (
     <Card>
       <CardHeader>
-        <CardTitle>{{{.LowerName}}.name}</CardTitle>
+        <CardTitle>{{{.LowerName}}.{{.TitleColumn}}}</CardTitle>
         <CardDescription>
           {{.Name}} ID: #{{{.LowerName}}.id}
         </CardDescription>
       </CardHeader>
       <CardContent className="space-y-4">
-        <div>
-          <Label className="text-sm font-medium">Description</Label>
-          <p className="text-sm text-muted-foreground mt-1">
-            {{{.LowerName}}.description || 'No description provided'}
-          </p>
-        </div>
+{{.TSDetailFields}}
 
         <div>
           <Label className="text-sm font-medium">Status</Label>
@@ -2072,10 +2701,13 @@ export function {{.Name}}DetailView({
 }
 `
 
-	return receiver.writeFileFromTemplate(formsFile, formsTemplate, config, force)
+	if err := receiver.writeFileFromTemplate(formsFile, formsTemplate, config, force); err != nil {
+		return nil, err
+	}
+	return []string{columnsFile, formsFile}, nil
 }
 
-func (receiver *MakeCrudE2E) generateUIPages(ctx console.Context, config ResourceConfig, force bool) error {
+func (receiver *MakeCrudE2E) generateUIPages(ctx console.Context, config ResourceConfig, force bool) ([]string, error) {
 	// Create pages directory
 	os.MkdirAll(config.UIPagesPath, 0755)
 
@@ -2128,7 +2760,7 @@ export default function {{.PluralName}}Index({
       delete: () => handleBulkDelete(selectedIds),
       activate: () => handleBulkStatusUpdate(selectedIds, true),
       deactivate: () => handleBulkStatusUpdate(selectedIds, false),
-      export: () => handleBulkExport(selectedIds),
+      export: () => handleBulkExport(),
     };
 
     const operation = operations[action];
@@ -2141,7 +2773,7 @@ export default function {{.PluralName}}Index({
     const confirmMessage = ` + "`" + `Are you sure you want to delete ${{{.LowerName}}Ids.length} {{.LowerName}}(s)? This action cannot be undone.` + "`" + `;
     if (confirm(confirmMessage)) {
       router.delete('/api/{{.LowerPluralName}}/bulk', {
-        data: { {{.LowerName}}Ids },
+        data: { ids: {{.LowerName}}Ids },
         onSuccess: () => {
           // Refresh will be handled by the parent
         },
@@ -2151,20 +2783,24 @@ export default function {{.PluralName}}Index({
 
   const handleBulkStatusUpdate = ({{.LowerName}}Ids: number[], isActive: boolean) => {
     router.put('/api/{{.LowerPluralName}}/bulk/status', {
-      {{.LowerName}}Ids,
+      ids: {{.LowerName}}Ids,
       is_active: isActive,
     });
   };
 
-  const handleBulkExport = ({{.LowerName}}Ids: number[]) => {
-    const format = prompt('Export format (csv, json, excel):') || 'csv';
-    const options = {
-      format: format as any,
-      filters: { ...filters, {{.LowerName}}Ids },
-    };
+  const handleBulkExport = () => {
+    const format = prompt('Export format (csv, json):') || 'csv';
+    const params = new URLSearchParams({ format });
+    if (filters.search) params.set('search', filters.search);
+    if (filters.sort) params.set('sort', filters.sort);
+    if (filters.direction) params.set('direction', filters.direction);
+    Object.entries(filters.filters || {}).forEach(([key, value]) => {
+      if (value === undefined || value === null || value === '') return;
+      params.set(key, Array.isArray(value) ? value.join(',') : String(value));
+    });
     
     // Trigger download
-    window.open(` + "`" + `/api/{{.LowerPluralName}}/export?${new URLSearchParams(options as any).toString()}` + "`" + `);
+    window.open(` + "`" + `/api/{{.LowerPluralName}}/export?${params.toString()}` + "`" + `);
   };
 
   const handleRefresh = () => {
@@ -2293,7 +2929,15 @@ export default function {{.PluralName}}Index({
 }
 `
 
-	return receiver.writeFileFromTemplate(indexFile, indexTemplate, config, force)
+	return receiver.writeGeneratedFile(indexFile, indexTemplate, config, force)
+}
+
+// writeGeneratedFile writes a single template and returns its path for the summary
+func (receiver *MakeCrudE2E) writeGeneratedFile(filePath, template string, config ResourceConfig, force bool) ([]string, error) {
+	if err := receiver.writeFileFromTemplate(filePath, template, config, force); err != nil {
+		return nil, err
+	}
+	return []string{filePath}, nil
 }
 
 // Helper method to write file from template
@@ -2312,10 +2956,17 @@ func (receiver *MakeCrudE2E) writeFileFromTemplate(filePath, template string, co
 	}
 
 	// Parse template
-	parsedTemplate := receiver.parseTemplate(template, config)
+	parsedTemplate := []byte(receiver.parseTemplate(template, config))
+
+	// Field spec snippets vary in width, so let gofmt realign Go output
+	if filepath.Ext(filePath) == ".go" {
+		if formatted, err := format.Source(parsedTemplate); err == nil {
+			parsedTemplate = formatted
+		}
+	}
 
 	// Write file
-	return os.WriteFile(filePath, []byte(parsedTemplate), 0644)
+	return os.WriteFile(filePath, parsedTemplate, 0644)
 }
 
 // Simple template parser (replace {{.Field}} with config values)
@@ -2331,6 +2982,48 @@ func (receiver *MakeCrudE2E) parseTemplate(template string, config ResourceConfi
 		"{{.KebabPluralName}}": config.KebabPluralName,
 		"{{.DisplayName}}":     config.DisplayName,
 		"{{.TableName}}":       config.TableName,
+
+		// Field spec snippets
+		"{{.TitleColumn}}":           config.TitleColumn,
+		"{{.TitleGoName}}":           config.TitleGoName,
+		"{{.TitleLabel}}":            config.TitleLabel,
+		"{{.DefaultSortField}}":      config.DefaultSortField,
+		"{{.DefaultSortDirection}}":  config.DefaultSortDirection,
+		"{{.ModelImports}}":          config.ModelImports,
+		"{{.ModelFields}}":           config.ModelFields,
+		"{{.Relations}}":             config.Relations,
+		"{{.ResourceFields}}":        config.ResourceFields,
+		"{{.ResourceValues}}":        config.ResourceValues,
+		"{{.MigrationColumns}}":      config.MigrationColumns,
+		"{{.MigrationIndexes}}":      config.MigrationIndexes,
+		"{{.ValidationRules}}":       config.ValidationRules,
+		"{{.ColumnMappings}}":        config.ColumnMappings,
+		"{{.SortableFields}}":        config.SortableFields,
+		"{{.SearchableFields}}":      config.SearchableFields,
+		"{{.FillableFields}}":        config.FillableFields,
+		"{{.RequiredFields}}":        config.RequiredFields,
+		"{{.MaxLengths}}":            config.MaxLengths,
+		"{{.RequestFields}}":         config.RequestFields,
+		"{{.RequestUpdateFields}}":   config.RequestUpdateFields,
+		"{{.RequestCreateRules}}":    config.RequestCreateRules,
+		"{{.RequestUpdateRules}}":    config.RequestUpdateRules,
+		"{{.RequestCreateMessages}}": config.RequestCreateMessages,
+		"{{.RequestUpdateMessages}}": config.RequestUpdateMessages,
+		"{{.RequestAttributes}}":     config.RequestAttributes,
+		"{{.ToCreateData}}":          config.ToCreateData,
+		"{{.ToUpdateData}}":          config.ToUpdateData,
+		"{{.EncodeJSONColumns}}":     config.EncodeJSONColumns,
+		"{{.TSFields}}":              config.TSFields,
+		"{{.TSModelFields}}":         config.TSModelFields,
+		"{{.TSFormDefaults}}":        config.TSFormDefaults,
+		"{{.TSFormEditValues}}":      config.TSFormEditValues,
+		"{{.TSFormValidation}}":      config.TSFormValidation,
+		"{{.TSFormInputs}}":          config.TSFormInputs,
+		"{{.TSColumns}}":             config.TSColumns,
+		"{{.TSMobileSubtitle}}":      config.TSMobileSubtitle,
+		"{{.TSDetailFields}}":        config.TSDetailFields,
+		"{{.SeederImports}}":         config.SeederImports,
+		"{{.SeederRows}}":            config.SeederRows,
 	}
 
 	result := template
@@ -0,0 +1,385 @@
+package commands
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestGenerateModelImportsEveryReferencedPackage renders the model template and
+// checks that every package selector it uses is imported, so the scaffolded
+// model compiles without manual fixes.
+func TestGenerateModelImportsEveryReferencedPackage(t *testing.T) {
+	specs := map[string][]string{
+		"default":   nil,
+		"with time": {"title:string", "published_at:datetime:nullable", "price:decimal"},
+		"with json": {"title:string", "metadata:json"},
+	}
+
+	for name, args := range specs {
+		t.Run(name, func(t *testing.T) {
+			receiver := &MakeCrudE2E{}
+			fields, err := parseFieldSpecs(args)
+			if err != nil {
+				t.Fatalf("parseFieldSpecs: %v", err)
+			}
+
+			config := receiver.parseResourceName("Product")
+			receiver.applyFieldSpecs(&config, fields)
+			config.ModelPath = filepath.Join(t.TempDir(), "product.go")
+
+			if _, err := receiver.generateModel(nil, config, true); err != nil {
+				t.Fatalf("generateModel: %v", err)
+			}
+
+			file, err := parser.ParseFile(token.NewFileSet(), config.ModelPath, nil, 0)
+			if err != nil {
+				t.Fatalf("generated model does not parse: %v", err)
+			}
+
+			imported := map[string]bool{}
+			for _, spec := range file.Imports {
+				path, _ := strconv.Unquote(spec.Path.Value)
+				imported[filepath.Base(path)] = true
+			}
+
+			ast.Inspect(file, func(node ast.Node) bool {
+				selector, ok := node.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Obj == nil && !imported[pkg.Name] {
+					t.Errorf("generated model uses %s.%s without importing %q", pkg.Name, selector.Sel.Name, pkg.Name)
+				}
+				return true
+			})
+		})
+	}
+}
+
+func TestPluralizeAndSingularize(t *testing.T) {
+	receiver := &MakeCrudE2E{}
+	cases := map[string]string{
+		"Book":     "Books",
+		"Category": "Categories",
+		"Day":      "Days",
+		"Person":   "People",
+		"Child":    "Children",
+		"Series":   "Series",
+		"Shelf":    "Shelves",
+		"Knife":    "Knives",
+		"Roof":     "Roofs",
+		"Box":      "Boxes",
+		"Branch":   "Branches",
+		"Address":  "Addresses",
+		"Status":   "Statuses",
+	}
+
+	for singular, plural := range cases {
+		if got := receiver.pluralize(singular); got != plural {
+			t.Errorf("pluralize(%q) = %q, want %q", singular, got, plural)
+		}
+		if got := receiver.pluralize(plural); got != plural {
+			t.Errorf("pluralize(%q) = %q, want it left unchanged", plural, got)
+		}
+		if got := receiver.singularize(plural); got != singular {
+			t.Errorf("singularize(%q) = %q, want %q", plural, got, singular)
+		}
+	}
+}
+
+func TestToSnakeCaseKeepsAcronymsTogether(t *testing.T) {
+	receiver := &MakeCrudE2E{}
+	cases := map[string]string{
+		"Book":       "book",
+		"BookShelf":  "book_shelf",
+		"APIKey":     "api_key",
+		"HTTPProxy":  "http_proxy",
+		"OAuthToken": "oauth_token",
+		"UserOAuth":  "user_oauth",
+		"Mp3Track":   "mp3_track",
+		"URL":        "url",
+	}
+
+	for input, want := range cases {
+		if got := receiver.toSnakeCase(input); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	if got := receiver.toKebabCase("APIKeys"); got != "api-keys" {
+		t.Errorf("toKebabCase(%q) = %q, want %q", "APIKeys", got, "api-keys")
+	}
+}
+
+func TestBelongsToFieldSpecAddsForeignKeyAndRelation(t *testing.T) {
+	fields, err := parseFieldSpecs([]string{"name:string", "category:belongsTo", "owner:belongsTo:User:nullable"})
+	if err != nil {
+		t.Fatalf("parseFieldSpecs: %v", err)
+	}
+
+	category, owner := fields[1], fields[2]
+	if category.Column != "category_id" || category.GoName != "CategoryID" || category.RelatedModel != "Category" {
+		t.Errorf("category field = %+v, want category_id/CategoryID related to Category", category)
+	}
+	if owner.Column != "owner_id" || owner.Relation != "Owner" || owner.RelatedModel != "User" || !owner.Nullable {
+		t.Errorf("owner field = %+v, want nullable owner_id with an Owner relation to User", owner)
+	}
+
+	receiver := &MakeCrudE2E{}
+	config := receiver.parseResourceName("Product")
+	receiver.applyFieldSpecs(&config, fields)
+	if config.Relations != `"Category", "Owner"` {
+		t.Errorf("Relations = %s, want \"Category\", \"Owner\"", config.Relations)
+	}
+
+	if _, err := parseFieldSpecs([]string{"name:string", "category:belongsTo", "category_id:int"}); err == nil {
+		t.Error("expected a duplicate category_id column to be rejected")
+	}
+}
+
+func TestBelongsToFieldSpecEmitsForeignKeyAndTSRelation(t *testing.T) {
+	fields, err := parseFieldSpecs([]string{"quantity:int", "label:string", "order:belongsTo:orders", "product:belongsTo:nullable"})
+	if err != nil {
+		t.Fatalf("parseFieldSpecs: %v", err)
+	}
+	if order := fields[2]; order.RelatedModel != "Order" || order.RelatedTable != "orders" {
+		t.Errorf("order field = %+v, want an Order relation on the orders table", order)
+	}
+
+	receiver := &MakeCrudE2E{}
+	config := receiver.parseResourceName("OrderItem")
+	receiver.applyFieldSpecs(&config, fields)
+
+	for _, want := range []string{
+		`table.Foreign("order_id").References("id").On("orders")`,
+		`table.Foreign("product_id").References("id").On("products").NullOnDelete()`,
+	} {
+		if !strings.Contains(config.MigrationIndexes, want) {
+			t.Errorf("MigrationIndexes missing %s:\n%s", want, config.MigrationIndexes)
+		}
+	}
+	if !strings.Contains(config.TSModelFields, "order?: { id: number; [key: string]: unknown } | null;") {
+		t.Errorf("TSModelFields missing the order relation:\n%s", config.TSModelFields)
+	}
+	if strings.Contains(config.TSFields, "order?:") {
+		t.Error("TSFields is shared with the form data type and must not include relations")
+	}
+}
+
+func TestAuthorshipAddsCreatedByAndUpdatedBy(t *testing.T) {
+	fields, err := parseFieldSpecs([]string{"name:string"})
+	if err != nil {
+		t.Fatalf("parseFieldSpecs: %v", err)
+	}
+
+	receiver := &MakeCrudE2E{}
+	config := receiver.parseResourceName("Product")
+	config.Authorship = true
+	receiver.applyFieldSpecs(&config, fields)
+
+	if config.Relations != `"CreatedBy", "UpdatedBy"` {
+		t.Errorf("Relations = %s, want \"CreatedBy\", \"UpdatedBy\"", config.Relations)
+	}
+	for _, want := range []string{"CreatedByID *uint", "CreatedBy *User `gorm:\"foreignKey:CreatedByID\"", "UpdatedByID *uint"} {
+		if !strings.Contains(config.ModelFields, want) {
+			t.Errorf("ModelFields missing %s:\n%s", want, config.ModelFields)
+		}
+	}
+	if !strings.Contains(config.MigrationIndexes, `table.Foreign("updated_by_id").References("id").On("users").NullOnDelete()`) {
+		t.Errorf("MigrationIndexes missing the updated_by_id foreign key:\n%s", config.MigrationIndexes)
+	}
+	// The service sets them, so a client can't claim someone else wrote the record
+	if strings.Contains(config.FillableFields, "created_by_id") || strings.Contains(config.RequestCreateRules, "created_by_id") {
+		t.Error("created_by_id must not be fillable or part of the request")
+	}
+}
+
+func TestGenerateSeederWritesOneRowPerSeedCount(t *testing.T) {
+	fields, err := parseFieldSpecs([]string{"name:string", "shipped_at:datetime", "price:decimal"})
+	if err != nil {
+		t.Fatalf("parseFieldSpecs: %v", err)
+	}
+
+	receiver := &MakeCrudE2E{}
+	config := receiver.parseResourceName("Product")
+	receiver.applyFieldSpecs(&config, fields)
+	config.SeedCount = 4
+	receiver.renderSeederRows(&config)
+
+	if got := strings.Count(config.SeederRows, "\t\t{"); got != 4 {
+		t.Errorf("SeederRows has %d rows, want 4", got)
+	}
+	if !strings.Contains(config.SeederRows, `Name: "Product 1",`) {
+		t.Errorf("SeederRows = %s, want the title column seeded as \"Product 1\"", config.SeederRows)
+	}
+	if !strings.Contains(config.SeederImports, `"time"`) {
+		t.Error("expected a datetime field to import time in the seeder")
+	}
+}
+
+func TestJSONFieldSpecIsAnOptionalDocument(t *testing.T) {
+	fields, err := parseFieldSpecs([]string{"name:string", "metadata:json"})
+	if err != nil {
+		t.Fatalf("parseFieldSpecs: %v", err)
+	}
+	metadata := fields[1]
+	if !metadata.Nullable || metadata.required() {
+		t.Errorf("metadata field = %+v, want an optional column", metadata)
+	}
+
+	receiver := &MakeCrudE2E{}
+	config := receiver.parseResourceName("Product")
+	receiver.applyFieldSpecs(&config, fields)
+
+	checks := map[string]struct{ got, want string }{
+		"ModelFields":         {config.ModelFields, "Metadata JSON `"},
+		"ResourceFields":      {config.ResourceFields, "Metadata models.JSON `"},
+		"MigrationColumns":    {config.MigrationColumns, `table.Json("metadata").Nullable()`},
+		"RequestFields":       {config.RequestFields, "Metadata map[string]interface{} `"},
+		"RequestUpdateFields": {config.RequestUpdateFields, "Metadata map[string]interface{} `"},
+		"ToUpdateData":        {config.ToUpdateData, "if r.Metadata != nil {"},
+		"EncodeJSONColumns":   {config.EncodeJSONColumns, `models.EncodeJSONColumns(data, "metadata")`},
+		"TSFields":            {config.TSFields, "metadata: Record<string, any> | null;"},
+		"FillableFields":      {config.FillableFields, `"name", "metadata"`},
+	}
+	for name, check := range checks {
+		if !strings.Contains(check.got, check.want) {
+			t.Errorf("%s missing %s:\n%s", name, check.want, check.got)
+		}
+	}
+	if strings.Contains(config.SortableFields, `"metadata"`) {
+		t.Errorf("SortableFields = %s, JSON columns cannot be sorted on", config.SortableFields)
+	}
+}
+
+func TestDefaultSortPrefersNameOrTitle(t *testing.T) {
+	specs := map[string]struct {
+		args             []string
+		field, direction string
+	}{
+		"title":          {[]string{"sku:string", "title:string"}, "title", "ASC"},
+		"no title":       {[]string{"sku:string", "price:decimal"}, "id", "DESC"},
+		"default fields": {nil, "name", "ASC"},
+	}
+
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			fields, err := parseFieldSpecs(spec.args)
+			if err != nil {
+				t.Fatalf("parseFieldSpecs: %v", err)
+			}
+
+			receiver := &MakeCrudE2E{}
+			config := receiver.parseResourceName("Product")
+			receiver.applyFieldSpecs(&config, fields)
+
+			if config.DefaultSortField != spec.field || config.DefaultSortDirection != spec.direction {
+				t.Errorf("default sort = %s %s, want %s %s", config.DefaultSortField, config.DefaultSortDirection, spec.field, spec.direction)
+			}
+		})
+	}
+}
+
+func TestGenerateTranslationsKeepsOtherResources(t *testing.T) {
+	fields, err := parseFieldSpecs([]string{"name:string", "in_stock:bool"})
+	if err != nil {
+		t.Fatalf("parseFieldSpecs: %v", err)
+	}
+
+	receiver := &MakeCrudE2E{}
+	config := receiver.parseResourceName("Product")
+	receiver.applyFieldSpecs(&config, fields)
+	config.LangAttributesPath = filepath.Join(t.TempDir(), "attributes.json")
+	if err := os.WriteFile(config.LangAttributesPath, []byte(`{"books": {"isbn": "ISBN number"}}`), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := receiver.generateTranslations(nil, config, false); err != nil {
+		t.Fatalf("generateTranslations: %v", err)
+	}
+
+	content, err := os.ReadFile(config.LangAttributesPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var attributes map[string]map[string]string
+	if err := json.Unmarshal(content, &attributes); err != nil {
+		t.Fatalf("generated attributes do not parse: %v", err)
+	}
+	if attributes["books"]["isbn"] != "ISBN number" {
+		t.Errorf("books attributes = %v, want them left alone", attributes["books"])
+	}
+	if attributes["products"]["in_stock"] != "In Stock" {
+		t.Errorf("products attributes = %v, want the field labels", attributes["products"])
+	}
+
+	if _, err := receiver.generateTranslations(nil, config, false); err == nil {
+		t.Error("generateTranslations overwrote existing attribute names without --force")
+	}
+}
+
+// TestGeneratedHandlersCheckPermissionBeforeLookup renders the controller and checks that the
+// single-record handlers authorize before loading the record, so a 404 never answers a caller
+// who may not see the resource at all.
+func TestGeneratedHandlersCheckPermissionBeforeLookup(t *testing.T) {
+	receiver := &MakeCrudE2E{}
+	config := receiver.parseResourceName("Product")
+	config.ControllerPath = filepath.Join(t.TempDir(), "product_controller.go")
+
+	if _, err := receiver.generateController(nil, config, true); err != nil {
+		t.Fatalf("generateController: %v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), config.ControllerPath, nil, 0)
+	if err != nil {
+		t.Fatalf("generated controller does not parse: %v", err)
+	}
+
+	handlers := map[string]bool{"Show": false, "Update": false, "Delete": false}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil {
+			continue
+		}
+		if _, ok := handlers[fn.Name.Name]; !ok {
+			continue
+		}
+		handlers[fn.Name.Name] = true
+
+		var checked, looked bool
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			switch selector.Sel.Name {
+			case "CheckPermission":
+				checked = true
+			case "GetByID":
+				if !checked && !looked {
+					t.Errorf("%s loads the record before checking permission", fn.Name.Name)
+				}
+				looked = true
+			}
+			return true
+		})
+		if !looked {
+			t.Errorf("%s never loads the record", fn.Name.Name)
+		}
+	}
+	for name, found := range handlers {
+		if !found {
+			t.Errorf("generated controller has no %s handler", name)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+
+	"players/app/auth"
+	"players/app/models"
+	"players/app/services"
+)
+
+// PermissionsAudit compares the permissions declared in code with the permissions table
+type PermissionsAudit struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *PermissionsAudit) Signature() string {
+	return "permissions:audit"
+}
+
+// Description The console command description.
+func (receiver *PermissionsAudit) Description() string {
+	return "Report permissions declared in code but missing from the database, and database permissions no code declares"
+}
+
+// Extend The console command extend.
+func (receiver *PermissionsAudit) Extend() command.Extend {
+	return command.Extend{
+		Category: "permissions",
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:  "fix",
+				Usage: "Create the missing permissions; orphaned ones are only reported",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *PermissionsAudit) Handle(ctx console.Context) error {
+	declared := declaredPermissions()
+
+	var existing []models.Permission
+	if err := facades.Orm().Query().Find(&existing); err != nil {
+		ctx.Error(fmt.Sprintf("Failed to load permissions: %v", err))
+		return err
+	}
+
+	// Slugs are compared in either style, so books.create and books_create are the same permission
+	inDatabase := make(map[string]bool, len(existing))
+	for _, permission := range existing {
+		inDatabase[models.PermissionKey(permission.Slug)] = true
+	}
+	declaredKeys := make(map[string]bool, len(declared))
+	for _, permission := range declared {
+		declaredKeys[models.PermissionKey(permission.Slug)] = true
+	}
+
+	var missing []models.Permission
+	for _, permission := range declared {
+		if !inDatabase[models.PermissionKey(permission.Slug)] {
+			missing = append(missing, permission)
+		}
+	}
+
+	var orphaned []string
+	for _, permission := range existing {
+		if !declaredKeys[models.PermissionKey(permission.Slug)] {
+			orphaned = append(orphaned, permission.Slug)
+		}
+	}
+	sort.Strings(orphaned)
+
+	if len(missing) == 0 {
+		ctx.Success("Every declared permission exists in the database")
+	} else {
+		ctx.Warning(fmt.Sprintf("%d declared permissions are missing from the database:", len(missing)))
+		for _, permission := range missing {
+			ctx.Line("  - " + permission.Slug)
+		}
+	}
+
+	if len(orphaned) > 0 {
+		ctx.Warning(fmt.Sprintf("%d database permissions are not declared in code (generated resources show up here):", len(orphaned)))
+		for _, slug := range orphaned {
+			ctx.Line("  - " + slug)
+		}
+	}
+
+	if len(missing) == 0 || !ctx.OptionBool("fix") {
+		return nil
+	}
+
+	created := 0
+	for _, permission := range missing {
+		permission := permission
+		if err := facades.Orm().Query().Create(&permission); err != nil {
+			ctx.Error(fmt.Sprintf("Failed to create permission %s: %v", permission.Slug, err))
+			continue
+		}
+		created++
+	}
+	auth.GetPermissionService().ClearCache()
+
+	ctx.Success(fmt.Sprintf("Created %d missing permissions", created))
+	return nil
+}
+
+// declaredPermissions lists every permission the code checks: the service_action permissions of
+// the service registry and the permissions behind the registered gates, sorted by slug
+func declaredPermissions() []models.Permission {
+	var declared []models.Permission
+	seen := make(map[string]bool)
+	add := func(permission models.Permission) {
+		key := models.PermissionKey(permission.Slug)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		permission.IsActive = true
+		declared = append(declared, permission)
+	}
+
+	for _, service := range auth.GetAllServiceRegistries() {
+		for _, action := range auth.GetServiceActions(service) {
+			add(models.Permission{
+				Name:        fmt.Sprintf("%s %s", auth.GetActionDisplayName(action), auth.GetServiceDisplayName(service)),
+				Slug:        auth.BuildPermissionSlug(service, action),
+				Description: fmt.Sprintf("Allows %s on %s", auth.GetActionDisplayName(action), auth.GetServiceDisplayName(service)),
+				Category:    string(service),
+				Resource:    string(service),
+				Action:      string(action),
+			})
+		}
+	}
+
+	for _, gate := range services.GatePermissions() {
+		add(models.Permission{
+			Name:        gate.Name,
+			Slug:        gate.Slug,
+			Description: gate.Description,
+			Category:    gate.Category,
+			Resource:    gate.Resource,
+			Action:      gate.Action,
+		})
+	}
+
+	sort.Slice(declared, func(i, j int) bool { return declared[i].Slug < declared[j].Slug })
+	return declared
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+
+	"players/app/contracts"
+)
+
+// TrashPurge permanently deletes soft-deleted records once they have been in the trash long enough
+type TrashPurge struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *TrashPurge) Signature() string {
+	return "trash:purge"
+}
+
+// Description The console command description.
+func (receiver *TrashPurge) Description() string {
+	return "Permanently delete records soft-deleted longer ago than their retention, for one resource or all of them"
+}
+
+// Extend The console command extend.
+func (receiver *TrashPurge) Extend() command.Extend {
+	return command.Extend{
+		Category: "trash",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "days",
+				Usage: "Purge records deleted more than this many days ago, overriding trash.retention_days (default: each resource's retention, or 30)",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (receiver *TrashPurge) Handle(ctx console.Context) error {
+	// -1 leaves each resource at its configured retention
+	days := -1
+	if option := ctx.Option("days"); option != "" {
+		parsed, err := strconv.Atoi(option)
+		if err != nil || parsed < 0 {
+			ctx.Error("--days must be a whole number of days, 0 or more")
+			return errors.New("invalid days")
+		}
+		days = parsed
+	}
+
+	// Resources are the registered CRUD services, so each purge goes through the service's own
+	// ForceDelete and cleans up whatever it cleans up for a single record
+	names := []string{ctx.Argument(0)}
+	if names[0] == "" {
+		names = contracts.GlobalServiceRegistry.ListServices()
+		sort.Strings(names)
+	}
+
+	total := 0
+	for _, name := range names {
+		service, err := contracts.GetCrudService(name)
+		if err != nil {
+			ctx.Error(fmt.Sprintf("Unknown resource '%s'", name))
+			return err
+		}
+
+		softDeletes, ok := service.(contracts.SoftDeleteServiceContract)
+		if !ok || !facades.Schema().HasColumn(service.GetTableName(), "deleted_at") {
+			if len(names) == 1 {
+				ctx.Error(fmt.Sprintf("Resource '%s' does not soft delete", name))
+				return errors.New("resource does not soft delete")
+			}
+			continue
+		}
+
+		purged, err := receiver.purge(service, softDeletes, retentionDays(name, days))
+		total += purged
+		if err != nil {
+			ctx.Error(fmt.Sprintf("Failed to purge %s: %v", name, err))
+			return err
+		}
+		ctx.Info(fmt.Sprintf("Purged %d %s", purged, name))
+	}
+
+	ctx.Success(fmt.Sprintf("Purged %d records in total", total))
+	return nil
+}
+
+// purge force deletes the records of service that were soft-deleted more than days ago and
+// returns how many it removed
+func (receiver *TrashPurge) purge(service contracts.CompleteCrudService, softDeletes contracts.SoftDeleteServiceContract, days int) (int, error) {
+	cutoff := time.Now().AddDate(0, 0, -days)
+
+	var ids []uint
+	if err := facades.Orm().Query().
+		Table(service.GetTableName()).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck(service.GetPrimaryKey(), &ids); err != nil {
+		return 0, fmt.Errorf("failed to find trashed records: %w", err)
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := softDeletes.ForceDelete(id); err != nil {
+			facades.Log().Error(fmt.Sprintf("trash:purge failed to delete %s %d: %v", service.GetTableName(), id, err))
+			continue
+		}
+		purged++
+	}
+
+	facades.Log().Info(fmt.Sprintf("trash:purge removed %d of %d %s deleted before %s", purged, len(ids), service.GetTableName(), cutoff.Format(time.RFC3339)))
+	return purged, nil
+}
+
+// retentionDays is the purge threshold for resource: days when --days was given, otherwise
+// trash.retention_days.<resource>, or 30 for resources without one
+func retentionDays(resource string, days int) int {
+	if days >= 0 {
+		return days
+	}
+	return facades.Config().GetInt("trash.retention_days."+resource, 30)
+}
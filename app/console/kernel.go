@@ -37,5 +37,9 @@ func (kernel *Kernel) Commands() []console.Command {
 		&commands.MakeCrudCommand{},
 		&commands.MakeCrudE2E{},
 		&commands.MakeSuperAdmin{},
+		&commands.PermissionsAudit{},
+		&commands.TrashPurge{},
+		&commands.MaintenanceDown{},
+		&commands.MaintenanceUp{},
 	}
 }
@@ -1,27 +1,33 @@
 package contracts
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
 )
 
+// PageSizeWarningHeader is set when a list request asked for more rows than the maximum page size
+const PageSizeWarningHeader = "X-Pagination-Warning"
+
 // BaseCrudController provides common implementations for CRUD controllers
 // Controllers MUST embed this and implement the abstract methods
 type BaseCrudController struct {
 	resourceType     string
-	maxPageSize      int
+	maxPageSize      int // 0 follows MaxPageSize
 	defaultPageSize  int
 	allowedPageSizes []int
+	transformer      func(record interface{}) interface{}
 }
 
 // NewBaseCrudController creates a new base CRUD controller
 func NewBaseCrudController(resourceType string) *BaseCrudController {
 	return &BaseCrudController{
 		resourceType:     resourceType,
-		maxPageSize:      100,
 		defaultPageSize:  20,
 		allowedPageSizes: []int{5, 10, 20, 30, 50, 100}, // More flexible options
 	}
@@ -38,6 +44,7 @@ func (c *BaseCrudController) ValidatePaginationRequest(ctx http.Context) (*ListR
 	req.Search = ctx.Request().Query("search", "")
 	req.Sort = ctx.Request().Query("sort", "")
 	req.Direction = ctx.Request().Query("direction", "")
+	req.Cursor = ctx.Request().Query("cursor", "")
 	
 	// Parse filters
 	req.Filters = make(map[string]interface{})
@@ -51,12 +58,15 @@ func (c *BaseCrudController) ValidatePaginationRequest(ctx http.Context) (*ListR
 		req.PageSize = c.defaultPageSize
 	}
 	
-	if req.PageSize > c.maxPageSize {
-		return nil, fmt.Errorf("pageSize cannot exceed %d", c.maxPageSize)
+	// Oversized pages are clamped, with a header so API clients can tell they got fewer rows
+	maxPageSize := c.MaxPageSize()
+	if req.PageSize > maxPageSize {
+		ctx.Response().Header(PageSizeWarningHeader, fmt.Sprintf("pageSize %d exceeds the maximum of %d; using %d", req.PageSize, maxPageSize, maxPageSize))
+		req.PageSize = maxPageSize
 	}
 	
-	// Validate page size is in allowed sizes
-	validPageSize := false
+	// Validate page size is in allowed sizes; the maximum itself is always allowed
+	validPageSize := req.PageSize == maxPageSize
 	for _, size := range c.allowedPageSizes {
 		if req.PageSize == size {
 			validPageSize = true
@@ -68,14 +78,9 @@ func (c *BaseCrudController) ValidatePaginationRequest(ctx http.Context) (*ListR
 		req.PageSize = c.defaultPageSize
 	}
 	
-	// Validate sort direction
+	// Normalize sort direction(s); sort=status,title pairs with direction=asc,desc
 	if req.Direction != "" {
-		upper := strings.ToUpper(req.Direction)
-		if upper != "ASC" && upper != "DESC" {
-			req.Direction = "DESC"
-		} else {
-			req.Direction = upper
-		}
+		req.Direction = NormalizeSortDirections(req.Direction)
 	}
 	
 	// Set defaults
@@ -85,12 +90,20 @@ func (c *BaseCrudController) ValidatePaginationRequest(ctx http.Context) (*ListR
 }
 
 func (c *BaseCrudController) GetPaginationDefaults() (page int, pageSize int, maxPageSize int) {
-	return 1, c.defaultPageSize, c.maxPageSize
+	return 1, c.defaultPageSize, c.MaxPageSize()
+}
+
+// MaxPageSize is the controller's page size limit, never above the configured hard cap
+func (c *BaseCrudController) MaxPageSize() int {
+	if c.maxPageSize > 0 {
+		return min(c.maxPageSize, MaxPageSize())
+	}
+	return MaxPageSize()
 }
 
 func (c *BaseCrudController) BuildPaginatedResponse(result *PaginatedResult, request *ListRequest) map[string]interface{} {
 	return map[string]interface{}{
-		"data": result.Data,
+		"data": c.TransformCollection(result.Data),
 		"pagination": map[string]interface{}{
 			"current_page": result.CurrentPage,
 			"last_page":    result.LastPage,
@@ -100,6 +113,7 @@ func (c *BaseCrudController) BuildPaginatedResponse(result *PaginatedResult, req
 			"to":           result.To,
 			"has_next":     result.HasNext,
 			"has_prev":     result.HasPrev,
+			"next_cursor":  result.NextCursor,
 		},
 		"filters": map[string]interface{}{
 			"page":      request.Page,
@@ -132,6 +146,19 @@ func (c *BaseCrudController) ValidateID(ctx http.Context, paramName string) (uin
 	return uint(id), nil
 }
 
+// RangeFilterParams copies {field}_from/_to/_gte/_lte query parameters into filters for GetListAdvanced
+func (c *BaseCrudController) RangeFilterParams(ctx http.Context, filters map[string]interface{}) map[string]interface{} {
+	if filters == nil {
+		filters = make(map[string]interface{})
+	}
+	for key, value := range ctx.Request().Queries() {
+		if value != "" && IsRangeFilterKey(key) {
+			filters[key] = value
+		}
+	}
+	return filters
+}
+
 // RESPONSE CONTRACT IMPLEMENTATION (enforced)
 
 func (c *BaseCrudController) SuccessResponse(ctx http.Context, data interface{}, message string) http.Response {
@@ -160,67 +187,401 @@ func (c *BaseCrudController) NoContentResponse(ctx http.Context, message string)
 	return ctx.Response().Json(http.StatusNoContent, response)
 }
 
+// Error responses share the envelope written by ErrorResponse, each with its own code
+
 func (c *BaseCrudController) BadRequestResponse(ctx http.Context, message string, errors map[string]interface{}) http.Response {
-	response := ResponseFormat{
-		Success: false,
-		Message: message,
-		Errors:  errors,
-	}
-	return ctx.Response().Json(http.StatusBadRequest, response)
+	return FieldErrorResponse(ctx, http.StatusBadRequest, ErrorCodeBadRequest, message, errors)
+}
+
+func (c *BaseCrudController) UnauthorizedResponse(ctx http.Context, message string) http.Response {
+	return ErrorResponse(ctx, http.StatusUnauthorized, ErrorCodeUnauthenticated, message, nil)
 }
 
 func (c *BaseCrudController) NotFoundResponse(ctx http.Context, message string) http.Response {
-	response := ResponseFormat{
-		Success: false,
-		Message: message,
-	}
-	return ctx.Response().Json(http.StatusNotFound, response)
+	return ErrorResponse(ctx, http.StatusNotFound, ErrorCodeResourceNotFound, message, nil)
 }
 
 func (c *BaseCrudController) ForbiddenResponse(ctx http.Context, message string) http.Response {
-	response := ResponseFormat{
-		Success: false,
-		Message: message,
-	}
-	return ctx.Response().Json(http.StatusForbidden, response)
+	return ErrorResponse(ctx, http.StatusForbidden, ErrorCodePermissionDenied, message, nil)
+}
+
+// AccessDeniedResponse answers a failed permission check with the reason it gave
+func (c *BaseCrudController) AccessDeniedResponse(ctx http.Context, err error) http.Response {
+	return c.ForbiddenResponse(ctx, Trans(ctx, "responses.access_denied", map[string]string{"reason": err.Error()}))
+}
+
+// ConflictResponse reports a request that clashes with existing state, e.g. a duplicate name
+func (c *BaseCrudController) ConflictResponse(ctx http.Context, message string, details interface{}) http.Response {
+	return ErrorResponse(ctx, http.StatusConflict, ErrorCodeConflict, message, details)
 }
 
 func (c *BaseCrudController) ValidationErrorResponse(ctx http.Context, errors map[string]interface{}) http.Response {
-	response := ResponseFormat{
-		Success: false,
-		Message: "Validation failed",
-		Errors:  errors,
+	return FieldErrorResponse(ctx, http.StatusUnprocessableEntity, ErrorCodeValidationFailed, Trans(ctx, "validation.failed"), errors)
+}
+
+// ValidationFailedResponse returns field-level messages when err is a *FieldValidationError from the
+// request layer or a service, keeping the summary under validation_error for callers that only
+// show a toast
+func (c *BaseCrudController) ValidationFailedResponse(ctx http.Context, err error) http.Response {
+	fieldErr, ok := err.(*FieldValidationError)
+	if !ok {
+		return c.ValidationErrorResponse(ctx, map[string]interface{}{
+			"validation_error": err.Error(),
+		})
 	}
-	return ctx.Response().Json(http.StatusUnprocessableEntity, response)
+
+	errors := fieldErr.FieldErrors()
+	errors["validation_error"] = err.Error()
+	return c.ValidationErrorResponse(ctx, errors)
 }
 
 func (c *BaseCrudController) InternalErrorResponse(ctx http.Context, message string) http.Response {
+	return ErrorResponse(ctx, http.StatusInternalServerError, ErrorCodeInternal, message, nil)
+}
+
+// QueryFailedResponse answers a failed list query: 504 when the query was cancelled because it ran
+// past database.query_timeout or the client disconnected, 500 with the error otherwise
+func (c *BaseCrudController) QueryFailedResponse(ctx http.Context, message string, err error) http.Response {
+	if IsQueryTimeout(err) {
+		return ErrorResponse(ctx, http.StatusGatewayTimeout, ErrorCodeQueryTimeout, message+": the query timed out", nil)
+	}
+	return c.InternalErrorResponse(ctx, message+": "+err.Error())
+}
+
+// PARTIAL UPDATE HELPERS
+
+// PartialUpdateData reads a PATCH body into a map holding only the keys the client sent that are
+// listed in fields, so omitted fields are left alone instead of saved as zero values. fields maps
+// each key to a FilterType* it is coerced to ("is_active": "false" becomes false); an empty type
+// keeps the value as decoded. A value that doesn't convert is a validation error for its key.
+func (c *BaseCrudController) PartialUpdateData(ctx http.Context, fields map[string]string) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	if err := ctx.Request().Bind(&body); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	data := make(map[string]interface{})
+	errs := NewFieldValidationError()
+	for key, value := range body {
+		fieldType, allowed := fields[key]
+		if !allowed {
+			continue
+		}
+		if fieldType == "" {
+			data[key] = value
+			continue
+		}
+		_, isList := FilterValueList(value)
+		coerced, ok := CoerceFilterValue(value, fieldType)
+		if isList || !ok {
+			errs.Add(key, fmt.Sprintf("%s must be of type %s", key, fieldType))
+			continue
+		}
+		data[key] = coerced
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("request body contains no fields that can be updated")
+	}
+	return data, nil
+}
+
+// StrictFields reports whether create and update bodies may only hold the resource's fillable
+// fields, http.strict_fields (default off). When off, unknown keys are dropped as before.
+func StrictFields() bool {
+	return facades.Config().GetBool("http.strict_fields", false)
+}
+
+// RejectUnknownFields returns a *FieldValidationError naming every body key that isn't one of
+// service's fillable fields or the handled fields the controller applies itself (a user's
+// role_id), when strict fields are on, so a client sending a misspelt field gets a 422 instead
+// of having it silently ignored. Route parameters and query strings are not checked.
+func (c *BaseCrudController) RejectUnknownFields(ctx http.Context, service FillableServiceContract, handled ...string) error {
+	if !StrictFields() {
+		return nil
+	}
+
+	fillable := make(map[string]bool)
+	for _, field := range append(service.GetFillableFields(), handled...) {
+		fillable[field] = true
+	}
+	queries := ctx.Request().Queries()
+
+	errs := NewFieldValidationError()
+	for key := range ctx.Request().All() {
+		if fillable[key] {
+			continue
+		}
+		if _, inQuery := queries[key]; inQuery || ctx.Request().Route(key) != "" {
+			continue
+		}
+		errs.Add(key, Trans(ctx, "validation.unknown_field", map[string]string{"field": key}))
+	}
+	return errs.Err()
+}
+
+// BULK OPERATION HELPERS
+
+// ValidateBulkRequest binds the bulk payload and ensures at least one ID was provided
+func (c *BaseCrudController) ValidateBulkRequest(ctx http.Context) (*BulkActionRequest, error) {
+	req := &BulkActionRequest{}
+	if err := ctx.Request().Bind(req); err != nil {
+		return nil, fmt.Errorf("invalid bulk request: %w", err)
+	}
+	if len(req.IDs) == 0 {
+		return nil, fmt.Errorf("ids must contain at least one ID")
+	}
+	return req, nil
+}
+
+// BulkOperationResponse summarizes a bulk operation, reporting per-ID failures from a *BulkOperationError
+func (c *BaseCrudController) BulkOperationResponse(ctx http.Context, operation string, ids []uint, err error) http.Response {
+	result := BulkOperationResult{
+		Requested: len(ids),
+		Succeeded: len(ids),
+	}
+
+	if err != nil {
+		bulkErr, ok := err.(*BulkOperationError)
+		if !ok {
+			return c.BadRequestResponse(ctx, fmt.Sprintf("Bulk %s failed", operation), map[string]interface{}{
+				"bulk_error": err.Error(),
+			})
+		}
+		result.Failed = len(bulkErr.Failed)
+		result.Succeeded = len(ids) - result.Failed
+		result.Errors = bulkErr.Failed
+	}
+
+	result.Results = make([]BulkItemResult, len(ids))
+	for i, id := range ids {
+		message, failed := result.Errors[id]
+		result.Results[i] = BulkItemResult{ID: id, Success: !failed, Error: message}
+	}
+
+	message := fmt.Sprintf("Bulk %s completed: %d succeeded, %d failed", operation, result.Succeeded, result.Failed)
 	response := ResponseFormat{
-		Success: false,
+		Success: result.Failed == 0,
+		Data:    result,
 		Message: message,
 	}
-	return ctx.Response().Json(http.StatusInternalServerError, response)
+	return ctx.Response().Json(http.StatusOK, response)
+}
+
+// SetActiveResponse backs POST /{resource}/{id}/activate and /deactivate for resources with an is_active column.
+// auth enforces updatePermission and the updated record is returned.
+func (c *BaseCrudController) SetActiveResponse(ctx http.Context, active bool, updatePermission string, auth AuthorizationControllerContract, service ActivatableServiceContract) http.Response {
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid "+c.resourceType+" ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	if err := auth.CheckPermission(ctx, updatePermission, nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	if _, err := service.GetByID(id); err != nil {
+		return c.ResourceNotFoundResponse(ctx, c.resourceType, id)
+	}
+
+	if err := service.SetActive(id, active); err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to update "+c.resourceType+": "+err.Error())
+	}
+
+	resource, err := service.GetByID(id)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to load updated "+c.resourceType+": "+err.Error())
+	}
+
+	state := "deactivated"
+	if active {
+		state = "activated"
+	}
+	return c.SuccessResponse(ctx, c.TransformResource(resource), fmt.Sprintf("%s %s successfully", strings.Title(c.resourceType), state))
+}
+
+// TrashedResponse handles GET /{resource}/trashed: checks viewPermission, then lists the
+// soft-deleted records with the usual pagination and sorting parameters
+func (c *BaseCrudController) TrashedResponse(ctx http.Context, viewPermission string, auth AuthorizationControllerContract, service TrashedServiceContract) http.Response {
+	if err := auth.CheckPermission(ctx, viewPermission, nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	req, err := c.ValidatePaginationRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid pagination parameters", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	result, err := service.GetTrashed(ctx.Context(), *req)
+	if err != nil {
+		return c.QueryFailedResponse(ctx, "Failed to retrieve deleted "+c.resourceType+" records", err)
+	}
+
+	response := c.BuildPaginatedResponse(result, req)
+	return c.SuccessResponse(ctx, response, fmt.Sprintf("Deleted %s records retrieved successfully", c.resourceType))
+}
+
+// ExistsResponse handles GET /{resource}/exists?field=isbn&value=...&except=12 for form duplicate
+// checks. field must be one of the service's filterable fields; the response is only
+// {"exists": bool}, so the check never exposes the matching record. except skips the record
+// being edited.
+func (c *BaseCrudController) ExistsResponse(ctx http.Context, viewPermission string, auth AuthorizationControllerContract, service ExistsServiceContract) http.Response {
+	if err := auth.CheckPermission(ctx, viewPermission, nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	field := ctx.Request().Query("field", "")
+	value := strings.TrimSpace(ctx.Request().Query("value", ""))
+	if field == "" || value == "" {
+		return c.BadRequestResponse(ctx, "field and value are required", nil)
+	}
+	if !service.ValidateFilterField(field) {
+		return c.BadRequestResponse(ctx, fmt.Sprintf("%s cannot be checked for duplicates", field), map[string]interface{}{
+			"allowed_fields": service.GetFilterableFields(),
+		})
+	}
+	coerced, ok := CoerceFilterValue(value, service.GetFilterFieldTypes()[field])
+	if !ok {
+		return c.BadRequestResponse(ctx, fmt.Sprintf("Invalid value for %s", field), nil)
+	}
+
+	exceptID := uint(ctx.Request().QueryInt("except", 0))
+	exists, err := service.RecordExists(field, coerced, exceptID)
+	if errors.Is(err, ErrNotAColumn) {
+		return c.BadRequestResponse(ctx, fmt.Sprintf("%s cannot be checked for duplicates", field), nil)
+	}
+	if err != nil {
+		return c.InternalErrorResponse(ctx, err.Error())
+	}
+	return c.SuccessResponse(ctx, map[string]interface{}{"exists": exists}, "Duplicate check completed")
+}
+
+// RelatedResponse handles GET /{resource}/{id}/related/{relation}: checks viewPermission before and
+// after loading the record, then pages through one of its relations. Only the service's
+// GetRelations and, if it has them, GetRelatedRelations can be requested, so no other association
+// is reachable; the latter can require a further permission. The relation is named in snake_case,
+// e.g. /users/5/related/roles for Roles.
+func (c *BaseCrudController) RelatedResponse(ctx http.Context, viewPermission string, auth AuthorizationControllerContract, service RelatedServiceContract) http.Response {
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid "+c.resourceType+" ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	allowed := service.GetRelations()
+	permissions := map[string]string{}
+	if extra, ok := service.(RelatedRelationsContract); ok {
+		permissions = extra.GetRelatedRelations()
+		allowed = append([]string{}, allowed...)
+		for relation := range permissions {
+			allowed = append(allowed, relation)
+		}
+		sort.Strings(allowed[len(service.GetRelations()):])
+	}
+	name := ctx.Request().Route("relation")
+	relation := ""
+	names := make([]string, 0, len(allowed))
+	for _, candidate := range allowed {
+		if strings.Contains(candidate, ".") {
+			continue
+		}
+		names = append(names, snakeColumnName(candidate))
+		if snakeColumnName(candidate) == name {
+			relation = candidate
+		}
+	}
+	if relation == "" {
+		return c.BadRequestResponse(ctx, fmt.Sprintf("%s has no relation %q", strings.Title(c.resourceType), name), map[string]interface{}{
+			"allowed_relations": names,
+		})
+	}
+
+	// Check the permissions before the lookup, so callers without access can't probe which IDs
+	// exist, then again against the loaded record for ownership-scoped permissions
+	required := []string{viewPermission}
+	if permission := permissions[relation]; permission != "" {
+		required = append(required, permission)
+	}
+	for _, permission := range required {
+		if err := auth.CheckPermission(ctx, permission, nil); err != nil {
+			return c.AccessDeniedResponse(ctx, err)
+		}
+	}
+
+	record, err := service.GetByID(id)
+	if err != nil {
+		return c.ResourceNotFoundResponse(ctx, c.resourceType, id)
+	}
+	for _, permission := range required {
+		if err := auth.CheckPermission(ctx, permission, record); err != nil {
+			return c.AccessDeniedResponse(ctx, err)
+		}
+	}
+
+	req, err := c.ValidatePaginationRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid pagination parameters", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	result, err := service.PaginateRelated(ctx.Context(), record, relation, *req)
+	if errors.Is(err, ErrUnknownRelation) {
+		return c.BadRequestResponse(ctx, fmt.Sprintf("%s has no relation %q", strings.Title(c.resourceType), name), nil)
+	}
+	if err != nil {
+		return c.QueryFailedResponse(ctx, "Failed to retrieve "+name, err)
+	}
+
+	// The parent's transformer passes related records through unchanged
+	response := c.BuildPaginatedResponse(result, req)
+	return c.SuccessResponse(ctx, response, fmt.Sprintf("%s %s retrieved successfully", strings.Title(c.resourceType), strings.ReplaceAll(name, "_", " ")))
+}
+
+// SchemaResponse handles GET /{resource}/schema, letting list UIs discover the fields the service
+// actually sorts, filters and searches on instead of hardcoding them
+func (c *BaseCrudController) SchemaResponse(ctx http.Context, viewPermission string, auth AuthorizationControllerContract, service SchemaServiceContract) http.Response {
+	if err := auth.CheckPermission(ctx, viewPermission, nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	sortField, sortDirection := service.GetDefaultSort()
+	return c.SuccessResponse(ctx, map[string]interface{}{
+		"sortable":    service.GetSortableFields(),
+		"filterable":  service.GetFilterableFields(),
+		"searchable":  service.GetSearchableFields(),
+		"filterTypes": service.GetFilterFieldTypes(),
+		"defaultSort": map[string]string{"field": sortField, "direction": sortDirection},
+		"columns":     service.GetColumnMapping(),
+	}, fmt.Sprintf("%s schema retrieved successfully", strings.Title(c.resourceType)))
 }
 
 // SPECIALIZED CRUD RESPONSES
 
 func (c *BaseCrudController) ResourceNotFoundResponse(ctx http.Context, resourceType string, id uint) http.Response {
-	message := fmt.Sprintf("%s with ID %d not found", strings.Title(resourceType), id)
+	message := Trans(ctx, "responses.not_found", map[string]string{"resource": strings.Title(resourceType), "id": strconv.FormatUint(uint64(id), 10)})
 	return c.NotFoundResponse(ctx, message)
 }
 
 func (c *BaseCrudController) ResourceCreatedResponse(ctx http.Context, resource interface{}, resourceType string) http.Response {
-	message := fmt.Sprintf("%s created successfully", strings.Title(resourceType))
-	return c.CreatedResponse(ctx, resource, message)
+	message := Trans(ctx, "responses.created", map[string]string{"resource": strings.Title(resourceType)})
+	return c.CreatedResponse(ctx, c.TransformResource(resource), message)
 }
 
 func (c *BaseCrudController) ResourceUpdatedResponse(ctx http.Context, resource interface{}, resourceType string) http.Response {
-	message := fmt.Sprintf("%s updated successfully", strings.Title(resourceType))
-	return c.SuccessResponse(ctx, resource, message)
+	message := Trans(ctx, "responses.updated", map[string]string{"resource": strings.Title(resourceType)})
+	return c.SuccessResponse(ctx, c.TransformResource(resource), message)
 }
 
 func (c *BaseCrudController) ResourceDeletedResponse(ctx http.Context, resourceType string, id uint) http.Response {
-	message := fmt.Sprintf("%s with ID %d deleted successfully", strings.Title(resourceType), id)
+	message := Trans(ctx, "responses.deleted", map[string]string{"resource": strings.Title(resourceType), "id": strconv.FormatUint(uint64(id), 10)})
 	return c.NoContentResponse(ctx, message)
 }
 
@@ -238,6 +599,32 @@ func (c *BaseCrudController) SetPaginationConfig(defaultPageSize, maxPageSize in
 	}
 }
 
+// SetResourceTransformer sets the function that shapes records for API output (see app/http/resources).
+// Paginated, created, updated and activated responses apply it automatically.
+func (c *BaseCrudController) SetResourceTransformer(transformer func(record interface{}) interface{}) {
+	c.transformer = transformer
+}
+
+// TransformResource returns the API representation of record, or record itself when no transformer is set
+func (c *BaseCrudController) TransformResource(record interface{}) interface{} {
+	if c.transformer == nil {
+		return record
+	}
+	return c.transformer(record)
+}
+
+// TransformCollection applies TransformResource to every record of a list
+func (c *BaseCrudController) TransformCollection(records []interface{}) []interface{} {
+	if c.transformer == nil {
+		return records
+	}
+	transformed := make([]interface{}, len(records))
+	for i, record := range records {
+		transformed[i] = c.transformer(record)
+	}
+	return transformed
+}
+
 func (c *BaseCrudController) GetResourceType() string {
 	return c.resourceType
 }
@@ -252,7 +639,7 @@ func (c *BaseCrudController) GenerateMetadata(supportedActions []string, require
 		ValidationRules:  validationRules,
 		PaginationConfig: PaginationConfig{
 			DefaultPageSize: c.defaultPageSize,
-			MaxPageSize:     c.maxPageSize,
+			MaxPageSize:     c.MaxPageSize(),
 			AllowedSizes:    c.allowedPageSizes,
 		},
 		ResponseFormats: []string{"json"},
@@ -307,7 +694,7 @@ func (c *BasePageController) GetPageMetadata() map[string]interface{} {
 func (c *BasePageController) GetPaginationConfig() map[string]interface{} {
 	return map[string]interface{}{
 		"defaultPageSize": c.defaultPageSize,
-		"maxPageSize":     c.maxPageSize,
+		"maxPageSize":     c.MaxPageSize(),
 		"allowedSizes":    c.allowedPageSizes,
 	}
 }
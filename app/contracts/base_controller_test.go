@@ -0,0 +1,53 @@
+package contracts
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goravel/framework/contracts/http"
+	goravelgin "github.com/goravel/gin"
+
+	"players/tests/testdb"
+)
+
+// allowAll authorizes every request
+type allowAll struct {
+	AuthorizationControllerContract
+}
+
+func (allowAll) CheckPermission(http.Context, string, interface{}) error { return nil }
+
+// relatedRecord is a model without the relation its service offers
+type relatedRecord struct {
+	ID uint
+}
+
+type staleRelationService struct {
+	*BaseCrudService
+}
+
+func (staleRelationService) GetByID(id uint) (interface{}, error) { return &relatedRecord{ID: id}, nil }
+func (staleRelationService) GetRelations() []string               { return []string{"Owner"} }
+
+// TestRelatedResponseRefusesRelationsTheModelLacks offers a relation the model doesn't define:
+// the request is a bad one, not a server error
+func TestRelatedResponseRefusesRelationsTheModelLacks(t *testing.T) {
+	testdb.Open(t)
+
+	recorder := httptest.NewRecorder()
+	ginCtx, _ := gin.CreateTestContext(recorder)
+	ginCtx.Request = httptest.NewRequest(nethttp.MethodGet, "/api/records/1/related/owner", nil)
+	ginCtx.Params = gin.Params{{Key: "id", Value: "1"}, {Key: "relation", Value: "owner"}}
+	ctx := goravelgin.NewContext(ginCtx)
+
+	controller := NewBaseCrudController("record")
+	service := staleRelationService{NewBaseCrudService("records", "id")}
+	if err := controller.RelatedResponse(ctx, "records.view", allowAll{}, service).Render(); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if recorder.Code != nethttp.StatusBadRequest {
+		t.Errorf("GET related/owner = %d, want %d: %s", recorder.Code, nethttp.StatusBadRequest, recorder.Body)
+	}
+}
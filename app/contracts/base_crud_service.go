@@ -1,9 +1,24 @@
 package contracts
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goravel/framework/contracts/database"
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
 )
 
 // BaseCrudService provides common implementations for CRUD services
@@ -11,8 +26,12 @@ import (
 type BaseCrudService struct {
 	tableName       string
 	primaryKey      string
-	maxPageSize     int
+	maxPageSize     int // 0 follows MaxPageSize
 	defaultPageSize int
+	cursorColumns   []string
+	searchMode      string
+	ftsTable        string
+	cacheRecords    bool // see SetRecordCache
 }
 
 // NewBaseCrudService creates a new base CRUD service
@@ -20,13 +39,23 @@ func NewBaseCrudService(tableName, primaryKey string) *BaseCrudService {
 	return &BaseCrudService{
 		tableName:       tableName,
 		primaryKey:      primaryKey,
-		maxPageSize:     100,
 		defaultPageSize: 20,
+		cursorColumns:   []string{primaryKey},
+		searchMode:      SearchModeLike,
 	}
 }
 
 // PAGINATION CONTRACT IMPLEMENTATION (enforced)
 
+// MaxPageSize is the hard cap on list page sizes, http.pagination.max_page_size (default 100).
+// Requests above it are clamped rather than refused, so no caller can load an unbounded page.
+func MaxPageSize() int {
+	if size := facades.Config().GetInt("http.pagination.max_page_size", 100); size > 0 {
+		return size
+	}
+	return 100
+}
+
 func (b *BaseCrudService) ValidatePaginationParams(page, pageSize int) error {
 	if page <= 0 {
 		return errors.New("page must be greater than 0")
@@ -34,20 +63,25 @@ func (b *BaseCrudService) ValidatePaginationParams(page, pageSize int) error {
 	if pageSize <= 0 {
 		return errors.New("pageSize must be greater than 0")
 	}
-	if pageSize > b.maxPageSize {
-		return fmt.Errorf("pageSize cannot exceed %d", b.maxPageSize)
+	if pageSize > b.GetMaxPageSize() {
+		return fmt.Errorf("pageSize cannot exceed %d", b.GetMaxPageSize())
 	}
 	return nil
 }
 
+// GetMaxPageSize is the service's page size limit, never above the configured hard cap
 func (b *BaseCrudService) GetMaxPageSize() int {
-	return b.maxPageSize
+	if b.maxPageSize > 0 {
+		return min(b.maxPageSize, MaxPageSize())
+	}
+	return MaxPageSize()
 }
 
 func (b *BaseCrudService) GetDefaultPageSize() int {
 	return b.defaultPageSize
 }
 
+// SetMaxPageSize sets the service's page size limit; GetMaxPageSize still caps it at MaxPageSize
 func (b *BaseCrudService) SetMaxPageSize(size int) {
 	if size > 0 {
 		b.maxPageSize = size
@@ -55,11 +89,16 @@ func (b *BaseCrudService) SetMaxPageSize(size int) {
 }
 
 func (b *BaseCrudService) SetDefaultPageSize(size int) {
-	if size > 0 && size <= b.maxPageSize {
+	if size > 0 && size <= b.GetMaxPageSize() {
 		b.defaultPageSize = size
 	}
 }
 
+// SetCursorColumns lists the indexed, non-null columns keyset pagination may sort on, besides the primary key
+func (b *BaseCrudService) SetCursorColumns(columns ...string) {
+	b.cursorColumns = append([]string{b.primaryKey}, columns...)
+}
+
 // SORTING CONTRACT IMPLEMENTATION (enforced)
 
 func (b *BaseCrudService) ValidateSortDirection(direction string) bool {
@@ -67,10 +106,72 @@ func (b *BaseCrudService) ValidateSortDirection(direction string) bool {
 	return upper == "ASC" || upper == "DESC"
 }
 
+// GetDefaultSort is the order lists fall back to when no valid sort is requested: newest first.
+// Services override it to pick their own, e.g. books by title.
 func (b *BaseCrudService) GetDefaultSort() (field string, direction string) {
 	return b.primaryKey, "DESC"
 }
 
+// BuildOrderClause turns sort=status,title with direction=asc,desc into "status ASC, title DESC".
+// Each field is checked with validate and mapped with mapField; invalid fields are dropped.
+// A field without its own direction uses the last one given. Returns "" when no field is usable.
+func (b *BaseCrudService) BuildOrderClause(req ListRequest, validate func(field string) bool, mapField func(field string) (string, bool)) string {
+	if req.Sort == "" {
+		return ""
+	}
+
+	directions := strings.Split(NormalizeSortDirections(req.Direction), ",")
+	seen := make(map[string]bool)
+	var columns []string
+	for i, field := range strings.Split(req.Sort, ",") {
+		direction := directions[len(directions)-1]
+		if i < len(directions) {
+			direction = directions[i]
+		}
+
+		field = strings.TrimSpace(field)
+		if field == "" || !validate(field) {
+			continue
+		}
+		dbColumn, valid := mapField(field)
+		if !valid || seen[dbColumn] {
+			continue
+		}
+		seen[dbColumn] = true
+		columns = append(columns, dbColumn+" "+direction)
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// StableOrder appends the primary key to an ORDER BY clause as a tiebreaker, unless the clause
+// already sorts on it. Without it, rows sharing a sort value come back in whatever order the
+// database picks, so they can repeat or go missing between pages.
+func (b *BaseCrudService) StableOrder(orderBy string) string {
+	for _, column := range strings.Split(orderBy, ",") {
+		if parts := strings.Fields(column); len(parts) > 0 && strings.TrimPrefix(parts[0], b.tableName+".") == b.primaryKey {
+			return orderBy
+		}
+	}
+	if strings.TrimSpace(orderBy) == "" {
+		return b.primaryKey + " ASC"
+	}
+	return orderBy + ", " + b.primaryKey + " ASC"
+}
+
+// NormalizeSortDirections upper-cases each comma-separated direction, replacing unknown ones with DESC
+func NormalizeSortDirections(direction string) string {
+	parts := strings.Split(direction, ",")
+	for i, part := range parts {
+		part = strings.ToUpper(strings.TrimSpace(part))
+		if part != "ASC" {
+			part = "DESC"
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ",")
+}
+
 // FILTERING CONTRACT IMPLEMENTATION (enforced)
 
 func (b *BaseCrudService) ValidateFilterValue(field string, value interface{}) bool {
@@ -79,6 +180,12 @@ func (b *BaseCrudService) ValidateFilterValue(field string, value interface{}) b
 		return false
 	}
 	
+	if values, ok := FilterValueList(value); ok {
+		return b.ValidateFilterValueList(values, func(v interface{}) bool {
+			return b.ValidateFilterValue(field, v)
+		})
+	}
+
 	switch v := value.(type) {
 	case string:
 		return strings.TrimSpace(v) != ""
@@ -93,6 +200,395 @@ func (b *BaseCrudService) ValidateFilterValue(field string, value interface{}) b
 	}
 }
 
+// MaxFilterValues caps how many values a single IN (...) filter may carry
+const MaxFilterValues = 100
+
+// FilterValueList returns the elements of a slice filter value such as status: ["AVAILABLE", "MAINTENANCE"]
+func FilterValueList(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case []string:
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			values[i] = item
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+// ValidateFilterValueList checks a slice filter value: it must be non-empty, within
+// MaxFilterValues, hold no nested slices, and every element must pass validate
+func (b *BaseCrudService) ValidateFilterValueList(values []interface{}, validate func(value interface{}) bool) bool {
+	if len(values) == 0 || len(values) > MaxFilterValues {
+		return false
+	}
+	for _, value := range values {
+		if _, nested := FilterValueList(value); nested || !validate(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter value types a service can declare per field with GetFilterFieldTypes
+const (
+	FilterTypeString = "string"
+	FilterTypeBool   = "bool"
+	FilterTypeInt    = "int"
+	FilterTypeFloat  = "float"
+)
+
+// GetFilterFieldTypes declares no types, so filter values are validated as given
+func (b *BaseCrudService) GetFilterFieldTypes() map[string]string {
+	return map[string]string{}
+}
+
+// CoerceFilterValue converts a filter value, or every element of a list value, to fieldType so
+// query string input such as is_active=true or price=9.99 compares against the right column type.
+// Fields without a declared type are returned unchanged; ok is false when a value can't be converted.
+func CoerceFilterValue(value interface{}, fieldType string) (interface{}, bool) {
+	if values, isList := FilterValueList(value); isList {
+		coerced := make([]interface{}, len(values))
+		for i, item := range values {
+			converted, ok := CoerceFilterValue(item, fieldType)
+			if !ok {
+				return nil, false
+			}
+			coerced[i] = converted
+		}
+		return coerced, true
+	}
+
+	switch fieldType {
+	case FilterTypeBool:
+		return coerceFilterBool(value)
+	case FilterTypeInt:
+		return coerceFilterInt(value)
+	case FilterTypeFloat:
+		return coerceFilterFloat(value)
+	case FilterTypeString:
+		str, ok := value.(string)
+		return strings.TrimSpace(str), ok
+	default:
+		return value, true
+	}
+}
+
+func coerceFilterBool(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "1", "yes", "on":
+			return true, true
+		case "false", "0", "no", "off":
+			return false, true
+		}
+	case int:
+		if v == 0 || v == 1 {
+			return v == 1, true
+		}
+	case float64:
+		if v == 0 || v == 1 {
+			return v == 1, true
+		}
+	}
+	return nil, false
+}
+
+func coerceFilterInt(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	case float64:
+		// JSON bodies decode every number as float64
+		if v == math.Trunc(v) {
+			return int64(v), true
+		}
+	case string:
+		if n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+func coerceFilterFloat(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case string:
+		if n, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// FilterCondition builds the WHERE clause for a filter value: "column IN ?" for slices, "column = ?" otherwise
+func (b *BaseCrudService) FilterCondition(column string, value interface{}) (string, interface{}) {
+	if values, ok := FilterValueList(value); ok {
+		return column + " IN ?", values
+	}
+	return column + " = ?", value
+}
+
+// SEARCH VALIDATION
+
+// Default search query length limits; services override GetSearchConstraints to change them
+const (
+	DefaultSearchMinLength = 2
+	DefaultSearchMaxLength = 100
+)
+
+// GetSearchConstraints returns the default 2-100 character search query length
+func (b *BaseCrudService) GetSearchConstraints() (minLength, maxLength int) {
+	return DefaultSearchMinLength, DefaultSearchMaxLength
+}
+
+// ValidateSearchLength checks a trimmed search query against the given length limits.
+// Services call it from ValidateSearchQuery with their own GetSearchConstraints.
+func (b *BaseCrudService) ValidateSearchLength(query string, minLength, maxLength int) error {
+	length := utf8.RuneCountInString(strings.TrimSpace(query))
+	if length < minLength {
+		return fmt.Errorf("search query must be at least %d characters", minLength)
+	}
+	if length > maxLength {
+		return fmt.Errorf("search query cannot exceed %d characters", maxLength)
+	}
+	return nil
+}
+
+// SEARCH FIELD MODES
+
+// Search match strategies a service can assign to each searchable field
+const (
+	SearchMatchContains = "contains" // field LIKE %term%
+	SearchMatchPrefix   = "prefix"   // field LIKE term%
+	SearchMatchExact    = "exact"    // field = term, for identifiers such as ISBNs
+)
+
+// GetSearchFieldModes returns no overrides, so every searchable field matches with contains
+func (b *BaseCrudService) GetSearchFieldModes() map[string]string {
+	return map[string]string{}
+}
+
+// SearchCondition ORs a match on each field for term, using the field's mode from modes
+// (contains when unlisted). It returns an empty condition when there are no fields.
+func (b *BaseCrudService) SearchCondition(term string, fields []string, modes map[string]string) (string, []interface{}) {
+	conditions := make([]string, len(fields))
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		switch modes[field] {
+		case SearchMatchExact:
+			conditions[i] = field + " = ?"
+			values[i] = term
+		case SearchMatchPrefix:
+			conditions[i] = field + " LIKE ?"
+			values[i] = term + "%"
+		default:
+			conditions[i] = field + " LIKE ?"
+			values[i] = "%" + term + "%"
+		}
+	}
+	return strings.Join(conditions, " OR "), values
+}
+
+// SEARCH MODES
+
+// Search modes; full-text search falls back to LIKE on drivers without a full-text index to query
+const (
+	SearchModeLike     = "like"
+	SearchModeFullText = "fulltext"
+)
+
+// SetSearchMode switches searches between LIKE matching and relevance-ranked full-text search.
+// ftsTable names the SQLite FTS5 table indexing this table's rows by rowid; on Postgres the
+// searchable fields are matched with to_tsvector and no extra table is needed.
+func (b *BaseCrudService) SetSearchMode(mode, ftsTable string) {
+	if mode == SearchModeLike || mode == SearchModeFullText {
+		b.searchMode = mode
+		b.ftsTable = ftsTable
+	}
+}
+
+// GetSearchMode returns the configured search mode
+func (b *BaseCrudService) GetSearchMode() string {
+	return b.searchMode
+}
+
+// ApplySearch restricts query to rows matching term in fields. In full-text mode on SQLite
+// (with an FTS table) or Postgres it joins the ranked matches; otherwise it applies
+// SearchCondition with the per-field modes. Fields with an exact or prefix mode are never
+// part of the full-text match: they are matched on their own and ORed with it.
+func (b *BaseCrudService) ApplySearch(query orm.Query, term string, fields []string, modes map[string]string) orm.Query {
+	term = strings.TrimSpace(term)
+	if term == "" || len(fields) == 0 {
+		return query
+	}
+
+	if search, ok := b.fullTextSearch(b.fullTextDriver(), term, fields, modes); ok {
+		query = query.Join(search.join, search.joinValues...)
+		if search.condition != "" {
+			query = query.Where(search.condition, search.values...)
+		}
+		return query
+	}
+
+	condition, values := b.SearchCondition(term, fields, modes)
+	return query.Where(condition, values...)
+}
+
+// SearchRelevanceOrder returns the ORDER BY expression ranking ApplySearch matches best first,
+// or "" when the search falls back to LIKE. Rows found only by an exact or prefix field come
+// after the full-text matches. Services put it ahead of the requested sort.
+func (b *BaseCrudService) SearchRelevanceOrder(term string, fields []string, modes map[string]string) string {
+	term = strings.TrimSpace(term)
+	if term == "" || len(fields) == 0 {
+		return ""
+	}
+
+	driver := b.fullTextDriver()
+	if _, ok := b.fullTextSearch(driver, term, fields, modes); !ok {
+		return ""
+	}
+	switch driver {
+	case database.DriverSqlite:
+		// bm25 scores are negative, lower is more relevant; rows without one didn't match the text
+		return "search_results.search_rank IS NULL, search_results.search_rank ASC"
+	case database.DriverPostgres:
+		textFields, _ := splitSearchFields(fields, modes)
+		return "ts_rank(" + b.searchDocument(textFields) + ", search_results.search_query) DESC"
+	}
+	return ""
+}
+
+// fullTextClauses is the join and extra WHERE clause ApplySearch adds for a full-text search
+type fullTextClauses struct {
+	join       string
+	joinValues []interface{}
+	condition  string // ORs the exact and prefix fields with the full-text match; "" without them
+	values     []interface{}
+}
+
+// fullTextSearch builds the full-text search on driver, or returns false when the search has to
+// fall back to LIKE: no full-text driver, no contains-mode field, or nothing to match. On SQLite
+// the FTS table's columns are named after the fields, so the match is limited to the text fields.
+func (b *BaseCrudService) fullTextSearch(driver database.Driver, term string, fields []string, modes map[string]string) (fullTextClauses, bool) {
+	textFields, modeFields := splitSearchFields(fields, modes)
+	if len(textFields) == 0 {
+		return fullTextClauses{}, false
+	}
+
+	var search fullTextClauses
+	var textMatch string
+	switch driver {
+	case database.DriverSqlite:
+		match := ftsMatchExpression(term)
+		if match == "" {
+			return fullTextClauses{}, false
+		}
+		join := "JOIN"
+		if len(modeFields) > 0 {
+			// Rows found only by the exact or prefix fields have no full-text match to join
+			join = "LEFT JOIN"
+		}
+		search.join = join + " (SELECT rowid AS search_id, bm25(" + b.ftsTable + ") AS search_rank FROM " + b.ftsTable +
+			" WHERE " + b.ftsTable + " MATCH ?) search_results ON search_results.search_id = " + b.tableName + "." + b.primaryKey
+		search.joinValues = []interface{}{"{" + strings.Join(textFields, " ") + "} : (" + match + ")"}
+		textMatch = "search_results.search_id IS NOT NULL"
+	case database.DriverPostgres:
+		textMatch = b.searchDocument(textFields) + " @@ search_results.search_query"
+		on := textMatch
+		if len(modeFields) > 0 {
+			on = "TRUE"
+		}
+		search.join = "JOIN (SELECT plainto_tsquery('simple', ?) AS search_query) search_results ON " + on
+		search.joinValues = []interface{}{term}
+	default:
+		return fullTextClauses{}, false
+	}
+
+	if len(modeFields) > 0 {
+		condition, values := b.SearchCondition(term, modeFields, modes)
+		search.condition = "(" + textMatch + " OR " + condition + ")"
+		search.values = values
+	}
+	return search, true
+}
+
+// splitSearchFields separates the fields full-text search covers, those matched with contains,
+// from the fields with an exact or prefix mode
+func splitSearchFields(fields []string, modes map[string]string) (textFields, modeFields []string) {
+	for _, field := range fields {
+		switch modes[field] {
+		case SearchMatchExact, SearchMatchPrefix:
+			modeFields = append(modeFields, field)
+		default:
+			textFields = append(textFields, field)
+		}
+	}
+	return textFields, modeFields
+}
+
+// fullTextDriver returns the database driver when full-text search can run on it, or ""
+func (b *BaseCrudService) fullTextDriver() database.Driver {
+	if b.searchMode != SearchModeFullText {
+		return ""
+	}
+
+	driver := facades.Orm().Query().Driver()
+	switch {
+	case driver == database.DriverSqlite && b.ftsTable != "":
+		return driver
+	case driver == database.DriverPostgres:
+		return driver
+	}
+	return ""
+}
+
+// searchDocument builds the Postgres tsvector the searchable fields are matched against.
+// Create a GIN index on the same expression to keep searches off a sequential scan.
+func (b *BaseCrudService) searchDocument(fields []string) string {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = "coalesce(" + b.tableName + "." + field + "::text, '')"
+	}
+	return "to_tsvector('simple', " + strings.Join(columns, " || ' ' || ") + ")"
+}
+
+// ftsMatchExpression turns free text into an FTS5 query of quoted prefix terms, so user input
+// such as AND, NEAR or unbalanced quotes is matched literally instead of failing to parse
+func ftsMatchExpression(term string) string {
+	words := strings.Fields(term)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+	}
+	return strings.Join(words, " ")
+}
+
 // CONFIGURATION IMPLEMENTATION
 
 func (b *BaseCrudService) GetTableName() string {
@@ -103,6 +599,76 @@ func (b *BaseCrudService) GetPrimaryKey() string {
 	return b.primaryKey
 }
 
+// GetRelations defaults to no eager loading; services with relations override it
+func (b *BaseCrudService) GetRelations() []string {
+	return []string{}
+}
+
+// WithRelations eager loads each relation on the query
+func (b *BaseCrudService) WithRelations(query orm.Query, relations []string) orm.Query {
+	for _, relation := range relations {
+		query = query.With(relation)
+	}
+	return query
+}
+
+// ErrUnknownRelation is returned by PaginateRelated for a relation the model doesn't define
+var ErrUnknownRelation = errors.New("unknown relation")
+
+// PaginateRelated returns one page of parent's relation in primary key order, where parent is a
+// model loaded by GetByID. To-many relations are counted and paged in the database; a to-one
+// relation is a page holding at most its one record.
+func (b *BaseCrudService) PaginateRelated(ctx context.Context, parent interface{}, relation string, req ListRequest) (*PaginatedResult, error) {
+	parentValue := reflect.Indirect(reflect.ValueOf(parent))
+	if parentValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRelation, relation)
+	}
+	field, ok := parentValue.Type().FieldByName(relation)
+	if !ok || !field.IsExported() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRelation, relation)
+	}
+	id, ok := modelColumnValue(parentValue, b.primaryKey)
+	if !ok {
+		return nil, fmt.Errorf("%s has no %s column", parentValue.Type().Name(), b.primaryKey)
+	}
+
+	query, release, err := b.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	toMany := field.Type.Kind() == reflect.Slice
+	var total int64
+	if toMany {
+		total = query.Model(parent).Association(relation).Count()
+		offset := (req.Page - 1) * req.PageSize
+		query = query.With(relation, func(related orm.Query) orm.Query {
+			return related.Order("id ASC").Offset(offset).Limit(req.PageSize)
+		})
+	} else {
+		query = query.With(relation)
+	}
+
+	loadedParent := reflect.New(parentValue.Type())
+	if err := query.Where(b.primaryKey+" = ?", id).First(loadedParent.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", relation, err)
+	}
+
+	loaded := loadedParent.Elem().FieldByIndex(field.Index)
+	data := make([]interface{}, 0)
+	switch {
+	case toMany:
+		for i := 0; i < loaded.Len(); i++ {
+			data = append(data, loaded.Index(i).Addr().Interface())
+		}
+	case loaded.Kind() != reflect.Ptr || !loaded.IsNil():
+		data = append(data, loaded.Interface())
+		total = 1
+	}
+	return b.BuildPaginatedResult(data, total, req), nil
+}
+
 // VALIDATION HELPERS
 
 func (b *BaseCrudService) ValidateListRequest(req *ListRequest) error {
@@ -114,9 +680,13 @@ func (b *BaseCrudService) ValidateListRequest(req *ListRequest) error {
 		return fmt.Errorf("pagination validation failed: %w", err)
 	}
 	
-	// Validate sort direction if provided
-	if req.Direction != "" && !b.ValidateSortDirection(req.Direction) {
-		return fmt.Errorf("invalid sort direction: %s", req.Direction)
+	// Validate sort direction(s) if provided; direction may be a comma-separated list
+	if req.Direction != "" {
+		for _, direction := range strings.Split(req.Direction, ",") {
+			if !b.ValidateSortDirection(strings.TrimSpace(direction)) {
+				return fmt.Errorf("invalid sort direction: %s", req.Direction)
+			}
+		}
 	}
 	
 	return nil
@@ -132,22 +702,366 @@ func (b *BaseCrudService) SanitizeListRequest(req *ListRequest) {
 	if req.PageSize <= 0 {
 		req.PageSize = b.defaultPageSize
 	}
-	if req.PageSize > b.maxPageSize {
-		req.PageSize = b.maxPageSize
+	if req.PageSize > b.GetMaxPageSize() {
+		req.PageSize = b.GetMaxPageSize()
 	}
 	
-	// Normalize sort direction
+	// Normalize sort direction(s)
 	if req.Direction != "" {
-		req.Direction = strings.ToUpper(req.Direction)
-		if req.Direction != "ASC" && req.Direction != "DESC" {
-			req.Direction = "DESC"
-		}
+		req.Direction = NormalizeSortDirections(req.Direction)
 	}
 	
 	// Trim search query
 	req.Search = strings.TrimSpace(req.Search)
 }
 
+// QUERY CONTEXT HELPERS
+
+// ListQuery returns a query for list reads that is cancelled when ctx is, or when
+// database.query_timeout (default 30 seconds) passes, and a release func the caller must defer.
+// The query is a read transaction of its own: Orm().WithContext would rebind the shared query,
+// leaving every later caller with this request's context.
+func (b *BaseCrudService) ListQuery(ctx context.Context) (orm.Query, func(), error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var cancel context.CancelFunc
+	if timeout := facades.Config().GetInt("database.query_timeout", 30); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to start list query: %w", err)
+	}
+	if withContext, ok := tx.(orm.QueryWithSetContext); ok {
+		withContext.SetContext(ctx)
+	}
+
+	release := func() {
+		_ = tx.Rollback()
+		cancel()
+	}
+	return tx, release, nil
+}
+
+// MASS ASSIGNMENT
+
+// OnlyFillable returns the entries of data whose key is one of fillable, a service's
+// GetFillableFields. Create and update helpers write through it so a column a client names but
+// the resource doesn't expose, such as is_super_admin, is dropped instead of saved.
+func OnlyFillable(data map[string]interface{}, fillable []string) map[string]interface{} {
+	filtered := make(map[string]interface{}, len(data))
+	for _, field := range fillable {
+		if value, ok := data[field]; ok {
+			filtered[field] = value
+		}
+	}
+	return filtered
+}
+
+// AUTHORSHIP
+
+// ActorKey is the data entry carrying the ID of the user a create or update is made by. It is
+// never fillable, so services read it with Actor before OnlyFillable drops it.
+const ActorKey = "_actor_id"
+
+// SetActor records actorID in data as the user making the change; nil leaves it unattributed
+func SetActor(data map[string]interface{}, actorID *uint) {
+	if data != nil && actorID != nil {
+		data[ActorKey] = *actorID
+	}
+}
+
+// Actor returns the user ID SetActor recorded in data, or nil for system changes
+func Actor(data map[string]interface{}) *uint {
+	if id, ok := data[ActorKey].(uint); ok {
+		return &id
+	}
+	return nil
+}
+
+// StampCreatedBy sets model's CreatedByID and UpdatedByID fields to actorID, for models that
+// have them. Either may be a uint or a *uint; a nil actorID leaves both unset.
+func (b *BaseCrudService) StampCreatedBy(model interface{}, actorID *uint) {
+	if actorID == nil {
+		return
+	}
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if value.Kind() != reflect.Struct {
+		return
+	}
+	for _, name := range []string{"CreatedByID", "UpdatedByID"} {
+		setAuthorField(value.FieldByName(name), *actorID)
+	}
+}
+
+// StampUpdatedBy adds updated_by_id = actorID to the columns of an update when model has an
+// UpdatedByID field
+func (b *BaseCrudService) StampUpdatedBy(model interface{}, columns map[string]interface{}, actorID *uint) {
+	if actorID == nil {
+		return
+	}
+	modelType := reflect.Indirect(reflect.ValueOf(model)).Type()
+	if modelType.Kind() != reflect.Struct {
+		return
+	}
+	if _, ok := modelType.FieldByName("UpdatedByID"); ok {
+		columns["updated_by_id"] = *actorID
+	}
+}
+
+// setAuthorField stores id in a uint or *uint field, ignoring fields of any other type
+func setAuthorField(field reflect.Value, id uint) {
+	if !field.IsValid() || !field.CanSet() {
+		return
+	}
+	switch {
+	case field.Kind() == reflect.Uint:
+		field.SetUint(uint64(id))
+	case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Uint:
+		field.Set(reflect.ValueOf(&id))
+	}
+}
+
+// WithTransaction runs fn inside a database transaction and commits when it returns nil. An error
+// or panic from fn rolls back every write made through tx, so multi-step changes either all land
+// or none do. fn's error is returned unchanged so callers can still match sentinel errors.
+func (b *BaseCrudService) WithTransaction(fn func(tx orm.Query) error) error {
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = tx.Rollback()
+			panic(recovered)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// IsQueryTimeout reports whether err comes from a query cancelled by its deadline or by the client going away
+func IsQueryTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
+
+// PAGINATION QUERY HELPERS
+
+// PaginateQuery counts the rows matched by newQuery and loads the requested page into dest.
+// newQuery is called once for the count and once for the data so the two queries never share state.
+// Relations are eager loaded on the data query only. Offset pages break ties on the primary key.
+func (b *BaseCrudService) PaginateQuery(newQuery func() orm.Query, orderBy string, req ListRequest, dest interface{}, relations ...string) (int64, error) {
+	var total int64
+	if err := newQuery().Count(&total); err != nil {
+		return 0, err
+	}
+
+	dataQuery := b.WithRelations(newQuery(), relations)
+
+	// A cursor switches to keyset pagination when the sort is a single cursor column
+	if column, direction, ok := b.KeysetColumn(orderBy); ok && req.Cursor != "" {
+		cursor, err := decodeKeysetCursor(req.Cursor)
+		if err != nil {
+			return 0, err
+		}
+
+		comparison := ">"
+		if direction == "DESC" {
+			comparison = "<"
+		}
+		columnRef := b.tableName + "." + column
+		pkRef := b.tableName + "." + b.primaryKey
+		if column == b.primaryKey {
+			dataQuery = dataQuery.Where(pkRef+" "+comparison+" ?", cursor.ID)
+		} else {
+			dataQuery = dataQuery.Where("("+columnRef+", "+pkRef+") "+comparison+" (?, ?)", cursor.Value, cursor.ID)
+		}
+
+		err = dataQuery.Order(columnRef + " " + direction).Order(pkRef + " " + direction).Limit(req.PageSize).Find(dest)
+		return total, err
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	if err := dataQuery.Order(b.StableOrder(orderBy)).Offset(offset).Limit(req.PageSize).Find(dest); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// KEYSET PAGINATION
+
+// keysetCursor marks the last row of a page: its sort column value and primary key
+type keysetCursor struct {
+	Value interface{} `json:"v"`
+	ID    uint        `json:"id"`
+}
+
+// KeysetColumn reports whether an ORDER BY clause sorts on a single cursor column, and which one
+func (b *BaseCrudService) KeysetColumn(orderBy string) (string, string, bool) {
+	parts := strings.Fields(orderBy)
+	if len(parts) != 2 || strings.Contains(orderBy, ",") {
+		return "", "", false
+	}
+	column, direction := strings.TrimPrefix(parts[0], b.tableName+"."), strings.ToUpper(parts[1])
+	if direction != "ASC" && direction != "DESC" {
+		return "", "", false
+	}
+	for _, cursorColumn := range b.cursorColumns {
+		if column == cursorColumn {
+			return column, direction, true
+		}
+	}
+	return "", "", false
+}
+
+// ApplyNextCursor sets NextCursor on a page sorted by a cursor column so the client can continue
+// with ?cursor= instead of ?page=. rows is the slice of models the page was loaded into.
+// In keyset mode HasNext is true whenever the page is full.
+func (b *BaseCrudService) ApplyNextCursor(result *PaginatedResult, req ListRequest, orderBy string, rows interface{}) {
+	column, _, ok := b.KeysetColumn(orderBy)
+	if !ok {
+		return
+	}
+	if req.Cursor != "" {
+		result.HasNext = len(result.Data) == req.PageSize
+		result.HasPrev = true
+	}
+	if !result.HasNext {
+		return
+	}
+
+	slice := reflect.Indirect(reflect.ValueOf(rows))
+	if slice.Kind() != reflect.Slice || slice.Len() == 0 {
+		return
+	}
+	last := reflect.Indirect(slice.Index(slice.Len() - 1))
+
+	cursor := keysetCursor{}
+	if id, ok := modelColumnValue(last, b.primaryKey); ok {
+		if idValue := reflect.ValueOf(id); idValue.CanUint() {
+			cursor.ID = uint(idValue.Uint())
+		}
+	}
+	if column != b.primaryKey {
+		value, ok := modelColumnValue(last, column)
+		if !ok {
+			return
+		}
+		if t, isTime := value.(time.Time); isTime {
+			value = t.Format(time.RFC3339Nano)
+		}
+		cursor.Value = value
+	}
+
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return
+	}
+	result.NextCursor = base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeKeysetCursor reverses ApplyNextCursor, turning timestamp strings back into time values
+func decodeKeysetCursor(raw string) (*keysetCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var cursor keysetCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil || cursor.ID == 0 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	if s, ok := cursor.Value.(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			cursor.Value = t
+		}
+	}
+	return &cursor, nil
+}
+
+// modelColumnValue finds the struct field mapped to a database column, following embedded
+// structs such as orm.Model. Columns come from a gorm column tag or the snake_case field name.
+func modelColumnValue(model reflect.Value, column string) (interface{}, bool) {
+	if model.Kind() != reflect.Struct {
+		return nil, false
+	}
+	modelType := model.Type()
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Anonymous {
+			if value, ok := modelColumnValue(reflect.Indirect(model.Field(i)), column); ok {
+				return value, true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := snakeColumnName(field.Name)
+		for _, option := range strings.Split(field.Tag.Get("gorm"), ";") {
+			if strings.HasPrefix(option, "column:") {
+				name = strings.TrimPrefix(option, "column:")
+			}
+		}
+		if name == column {
+			return model.Field(i).Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// snakeColumnName converts a Go field name to gorm's default column name, keeping acronyms together
+func snakeColumnName(name string) string {
+	runes := []rune(name)
+	var out []rune
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				out = append(out, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// BuildPaginatedResult wraps a page of data with the pagination metadata expected by the frontend
+func (b *BaseCrudService) BuildPaginatedResult(data []interface{}, total int64, req ListRequest) *PaginatedResult {
+	offset := (req.Page - 1) * req.PageSize
+	if int64(offset) > total {
+		offset = int(total)
+	}
+	lastPage := int((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+
+	return &PaginatedResult{
+		Data:        data,
+		Total:       total,
+		PerPage:     req.PageSize,
+		CurrentPage: req.Page,
+		LastPage:    lastPage,
+		From:        offset + 1,
+		To:          offset + len(data),
+		HasNext:     req.Page < lastPage,
+		HasPrev:     req.Page > 1,
+	}
+}
+
 // BULK OPERATIONS VALIDATION
 
 func (b *BaseCrudService) ValidateBulkOperation(ids []uint) error {
@@ -174,6 +1088,299 @@ func (b *BaseCrudService) ValidateBulkOperation(ids []uint) error {
 	return nil
 }
 
+// RunBulkOperation applies fn to every ID, continuing past failures.
+// Returns a *BulkOperationError listing the IDs that failed, or nil when all succeeded.
+func (b *BaseCrudService) RunBulkOperation(operation string, ids []uint, fn func(id uint) error) error {
+	failed := make(map[uint]string)
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			failed[id] = err.Error()
+		}
+	}
+
+	if len(failed) > 0 {
+		return &BulkOperationError{Operation: operation, Failed: failed}
+	}
+	return nil
+}
+
+// COUNT OPERATIONS
+
+// filterColumnPattern limits CountByFilter keys to plain column names
+var filterColumnPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// CountByFilter counts non-deleted rows matching every filter with a single COUNT(*).
+// Filter keys are column names; services with derived filters should override this.
+func (b *BaseCrudService) CountByFilter(filters map[string]interface{}) (int64, error) {
+	query := facades.Orm().Query().
+		Table(b.tableName).
+		Where(b.tableName + ".deleted_at IS NULL")
+
+	for field, value := range filters {
+		if !filterColumnPattern.MatchString(field) {
+			return 0, fmt.Errorf("invalid filter field: %s", field)
+		}
+		if !b.ValidateFilterValue(field, value) {
+			return 0, fmt.Errorf("invalid value for filter %s", field)
+		}
+		query = query.Where(b.FilterCondition(b.tableName+"."+field, value))
+	}
+
+	var count int64
+	if err := query.Count(&count); err != nil {
+		return 0, fmt.Errorf("failed to count records: %w", err)
+	}
+	return count, nil
+}
+
+// RANGE FILTERS
+
+// rangeFilterOperators maps range filter key suffixes to the comparison they apply
+var rangeFilterOperators = map[string]string{
+	"_from": ">=",
+	"_gte":  ">=",
+	"_to":   "<=",
+	"_lte":  "<=",
+}
+
+// rangeFilterDateLayouts are the date formats accepted for range filter values
+var rangeFilterDateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", rangeFilterDayLayout}
+
+const rangeFilterDayLayout = "2006-01-02"
+
+// RangeFilter is one bound parsed from a {field}_from, _to, _gte or _lte filter key
+type RangeFilter struct {
+	Field    string
+	Operator string
+	Value    interface{}
+}
+
+// RangeFilterError reports a range filter key or value that cannot be applied
+type RangeFilterError struct {
+	Key    string
+	Reason string
+}
+
+func (e *RangeFilterError) Error() string {
+	return fmt.Sprintf("invalid range filter %s: %s", e.Key, e.Reason)
+}
+
+// IsRangeFilterKey reports whether key carries a range suffix such as _from or _lte
+func IsRangeFilterKey(key string) bool {
+	field, _ := splitRangeFilterKey(key)
+	return field != ""
+}
+
+// BuildRangeFilters extracts range bounds such as created_at_from or price_lte from filters.
+// The base field must pass isFilterable and the value must parse as a date or number.
+// A date-only upper bound covers the whole day. Keys that are filterable as-is are left alone.
+func (b *BaseCrudService) BuildRangeFilters(filters map[string]interface{}, isFilterable func(field string) bool) ([]RangeFilter, error) {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var ranges []RangeFilter
+	for _, key := range keys {
+		if isFilterable(key) {
+			continue
+		}
+
+		field, operator := splitRangeFilterKey(key)
+		if field == "" {
+			continue
+		}
+		if !filterColumnPattern.MatchString(field) || !isFilterable(field) {
+			return nil, &RangeFilterError{Key: key, Reason: "field " + field + " is not filterable"}
+		}
+
+		value, err := parseRangeFilterValue(filters[key])
+		if err != nil {
+			return nil, &RangeFilterError{Key: key, Reason: err.Error()}
+		}
+
+		// A bare date as an upper bound means "through the end of that day"
+		if day, ok := value.(time.Time); ok && operator == "<=" && isRangeFilterDay(filters[key]) {
+			operator, value = "<", day.AddDate(0, 0, 1)
+		}
+
+		ranges = append(ranges, RangeFilter{Field: field, Operator: operator, Value: value})
+	}
+
+	return ranges, nil
+}
+
+// ApplyRangeFilters adds each range bound to a query as a column comparison
+func (b *BaseCrudService) ApplyRangeFilters(query orm.Query, ranges []RangeFilter) orm.Query {
+	for _, r := range ranges {
+		query = query.Where(b.tableName+"."+r.Field+" "+r.Operator+" ?", r.Value)
+	}
+	return query
+}
+
+// splitRangeFilterKey returns the base field and operator for a range filter key, or "" if it is not one
+func splitRangeFilterKey(key string) (string, string) {
+	for suffix, operator := range rangeFilterOperators {
+		if field := strings.TrimSuffix(key, suffix); field != key && field != "" {
+			return field, operator
+		}
+	}
+	return "", ""
+}
+
+// parseRangeFilterValue accepts numbers, numeric strings and dates in rangeFilterDateLayouts
+func parseRangeFilterValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case int, int32, int64, uint, uint32, uint64, float32, float64:
+		return v, nil
+	case string:
+		v = strings.TrimSpace(v)
+		if number, err := strconv.ParseFloat(v, 64); err == nil {
+			return number, nil
+		}
+		for _, layout := range rangeFilterDateLayouts {
+			if parsed, err := time.Parse(layout, v); err == nil {
+				return parsed, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not a number or a date (YYYY-MM-DD or RFC 3339)", v)
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// isRangeFilterDay reports whether a raw filter value is a date without a time
+func isRangeFilterDay(value interface{}) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	_, err := time.Parse(rangeFilterDayLayout, strings.TrimSpace(s))
+	return err == nil
+}
+
+// SOFT DELETE OPERATIONS
+
+// Restore clears deleted_at on a soft-deleted record so it is visible again
+func (b *BaseCrudService) Restore(id uint) error {
+	if id == 0 {
+		return fmt.Errorf("invalid ID: %d", id)
+	}
+
+	res, err := facades.Orm().Query().
+		Table(b.tableName).
+		Where(b.primaryKey+" = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if err != nil {
+		return fmt.Errorf("failed to restore record: %w", err)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("no deleted record found with ID %d", id)
+	}
+
+	b.ForgetRecord(id)
+	return nil
+}
+
+// PaginateTrashed counts and loads a page of soft-deleted rows of model's table into dest.
+// model is a pointer to an empty model; orderBy falls back to the most recently deleted first.
+func (b *BaseCrudService) PaginateTrashed(ctx context.Context, model interface{}, orderBy string, req ListRequest, dest interface{}, relations ...string) (int64, error) {
+	db, release, err := b.ListQuery(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	newQuery := func() orm.Query {
+		return db.Model(model).WithTrashed().Where(b.tableName + ".deleted_at IS NOT NULL")
+	}
+	if orderBy == "" {
+		orderBy = b.tableName + ".deleted_at DESC"
+	}
+	return b.PaginateQuery(newQuery, orderBy, req, dest, relations...)
+}
+
+// ErrRecordNotFound is returned by ForceDelete when no row, live or soft-deleted, has the ID
+var ErrRecordNotFound = errors.New("record not found")
+
+// ForceDelete permanently removes a record, including one that is already soft-deleted
+func (b *BaseCrudService) ForceDelete(id uint) error {
+	if id == 0 {
+		return fmt.Errorf("invalid ID: %d", id)
+	}
+
+	res, err := facades.Orm().Query().
+		Exec("DELETE FROM "+b.tableName+" WHERE "+b.primaryKey+" = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to permanently delete record: %w", err)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: no %s row with ID %d", ErrRecordNotFound, b.tableName, id)
+	}
+
+	b.ForgetRecord(id)
+	return nil
+}
+
+// DUPLICATE CHECKS
+
+// ErrNotAColumn is returned by RecordExists for a field that has no column to compare against,
+// such as a computed filter like minPrice
+var ErrNotAColumn = errors.New("field cannot be checked for duplicates")
+
+// RecordExists reports whether a row other than exceptID holds value in field. Soft-deleted rows
+// count: they still hold unique indexes, so creating a duplicate of one fails just the same.
+func (b *BaseCrudService) RecordExists(field string, value interface{}, exceptID uint) (bool, error) {
+	if !facades.Schema().HasColumn(b.tableName, field) {
+		return false, ErrNotAColumn
+	}
+
+	query := facades.Orm().Query().Table(b.tableName).Where(field+" = ?", value)
+	if exceptID > 0 {
+		query = query.Where(b.primaryKey+" <> ?", exceptID)
+	}
+	var count int64
+	if err := query.Count(&count); err != nil {
+		return false, fmt.Errorf("failed to check for duplicates: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ACTIVE STATE OPERATIONS
+
+// SetActive sets is_active on a non-deleted record
+func (b *BaseCrudService) SetActive(id uint, active bool) error {
+	if id == 0 {
+		return fmt.Errorf("invalid ID: %d", id)
+	}
+
+	if _, err := facades.Orm().Query().
+		Table(b.tableName).
+		Where(b.primaryKey+" = ? AND deleted_at IS NULL", id).
+		Update("is_active", active); err != nil {
+		return fmt.Errorf("failed to update active state: %w", err)
+	}
+
+	b.ForgetRecord(id)
+	return nil
+}
+
+// ApplyTrashedFilter scopes a query by soft-delete state.
+// TrashedWith includes deleted rows, TrashedOnly returns only deleted rows.
+func (b *BaseCrudService) ApplyTrashedFilter(query orm.Query, trashed string) (orm.Query, error) {
+	switch trashed {
+	case "":
+		return query, nil
+	case TrashedWith:
+		return query.WithTrashed(), nil
+	case TrashedOnly:
+		return query.WithTrashed().Where(b.tableName + ".deleted_at IS NOT NULL"), nil
+	default:
+		return nil, fmt.Errorf("invalid trashed filter %q: must be %q or %q", trashed, TrashedWith, TrashedOnly)
+	}
+}
+
 // METADATA GENERATION
 
 func (b *BaseCrudService) GenerateMetadata(name, version string, service CompleteCrudService) ServiceMetadata {
@@ -184,7 +1391,7 @@ func (b *BaseCrudService) GenerateMetadata(name, version string, service Complet
 		SortableFields:   service.GetSortableFields(),
 		FilterableFields: service.GetFilterableFields(),
 		SearchableFields: service.GetSearchableFields(),
-		MaxPageSize:      b.maxPageSize,
+		MaxPageSize:      b.GetMaxPageSize(),
 		DefaultPageSize:  b.defaultPageSize,
 	}
 }
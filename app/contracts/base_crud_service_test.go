@@ -0,0 +1,100 @@
+package contracts
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/goravel/framework/contracts/database"
+
+	"players/app/models"
+	"players/tests/testdb"
+)
+
+// TestFullTextSearchHonorsFieldModesOnSqlite runs the full-text search BookService uses against
+// an in-memory SQLite catalog indexed like books_fts: ISBNs match exactly, the rest by full text.
+func TestFullTextSearchHonorsFieldModesOnSqlite(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range []string{
+		`CREATE TABLE books (id INTEGER PRIMARY KEY, title TEXT, author TEXT, description TEXT, isbn TEXT)`,
+		`CREATE VIRTUAL TABLE books_fts USING fts5(title, author, description, isbn, content='books', content_rowid='id')`,
+		`INSERT INTO books VALUES
+			(1, 'Nineteen Eighty-Four', 'George Orwell', 'A dystopian novel', '9780452284234'),
+			(2, 'Animal Farm', 'George Orwell', 'A farm fable', '9780452284241'),
+			(3, 'Numbers', 'Ann Author', 'Mentions 9780452284234 in passing', '9780000000002')`,
+		`INSERT INTO books_fts(books_fts) VALUES ('rebuild')`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("%s: %v", statement, err)
+		}
+	}
+
+	service := NewBaseCrudService("books", "id")
+	service.SetSearchMode(SearchModeFullText, "books_fts")
+	fields := []string{"title", "author", "description", "isbn"}
+	modes := map[string]string{"isbn": SearchMatchExact}
+
+	tests := []struct {
+		term string
+		want []int
+	}{
+		{"orwell", []int{1, 2}},
+		{"farm", []int{2}},
+		// A partial ISBN still prefix-matches text such as book 3's description, but not the isbn column
+		{"978045228", []int{3}},
+		// The full ISBN matches book 1 exactly and book 3's description through FTS
+		{"9780452284234", []int{1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			search, ok := service.fullTextSearch(database.DriverSqlite, tt.term, fields, modes)
+			if !ok {
+				t.Fatal("fullTextSearch fell back to LIKE")
+			}
+
+			query := "SELECT books.id FROM books " + search.join + " WHERE " + search.condition + " ORDER BY books.id"
+			rows, err := db.Query(query, append(search.joinValues, search.values...)...)
+			if err != nil {
+				t.Fatalf("%s: %v", query, err)
+			}
+			defer rows.Close()
+
+			var got []int
+			for rows.Next() {
+				var id int
+				if err := rows.Scan(&id); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search %q matched %v, want %v", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestForceDeleteReportsMissingRowsApart lets handlers answer 404 only when there was nothing to
+// delete, and report any other failure as one
+func TestForceDeleteReportsMissingRowsApart(t *testing.T) {
+	db := testdb.Open(t, &models.Role{})
+	if err := db.Create(&models.Role{Name: "Member", Slug: "member", IsActive: true}).Error; err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+
+	if err := NewBaseCrudService("roles", "id").ForceDelete(99); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("ForceDelete(missing row) error = %v, want %v", err, ErrRecordNotFound)
+	}
+	if err := NewBaseCrudService("no_such_table", "id").ForceDelete(1); err == nil || errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("ForceDelete(broken query) error = %v, want a query failure", err)
+	}
+}
@@ -0,0 +1,53 @@
+package contracts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+// ConditionalResponse is SuccessResponse for GET endpoints that clients poll. The body is tagged
+// with an ETag derived from its serialized bytes, and a request whose If-None-Match already names
+// that tag gets an empty 304 instead of the full payload.
+func (c *BaseCrudController) ConditionalResponse(ctx http.Context, data interface{}, message string) http.Response {
+	body, err := json.Marshal(ResponseFormat{
+		Success: true,
+		Data:    data,
+		Message: message,
+	})
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to encode response: "+err.Error())
+	}
+
+	etag := ResponseETag(body)
+	// no-cache still lets the browser keep the body, but makes it revalidate on every poll
+	ctx.Response().Header("ETag", etag)
+	ctx.Response().Header("Cache-Control", "private, no-cache")
+
+	if ETagMatches(ctx.Request().Header("If-None-Match", ""), etag) {
+		return ctx.Response().NoContent(http.StatusNotModified)
+	}
+
+	return ctx.Response().Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// ResponseETag returns the quoted strong ETag of a serialized response body
+func ResponseETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ETagMatches reports whether an If-None-Match header names etag. The header may list several
+// tags, use "*", or carry weak W/ tags, which compare equal to their strong form for GET.
+func ETagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
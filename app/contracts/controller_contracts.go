@@ -145,11 +145,14 @@ type ControllerValidationResult struct {
 	InvalidResponses []string `json:"invalid_responses"`
 }
 
-// ResponseFormat defines standard API response structure
+// ResponseFormat defines standard API response structure. Error responses also carry a
+// machine-readable Code (see ErrorResponse) and structured Details.
 type ResponseFormat struct {
 	Success bool        `json:"success"`
+	Code    string      `json:"code,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 	Message string      `json:"message,omitempty"`
+	Details interface{} `json:"details,omitempty"`
 	Errors  interface{} `json:"errors,omitempty"`
 	Meta    interface{} `json:"meta,omitempty"`
 }
@@ -0,0 +1,55 @@
+package contracts
+
+import (
+	"github.com/goravel/framework/contracts/http"
+)
+
+// Error codes sent in the "code" field of error responses. The SPA branches on these rather
+// than on messages, so they are stable: add new codes, don't rename existing ones.
+const (
+	ErrorCodeBadRequest         = "BAD_REQUEST"
+	ErrorCodeValidationFailed   = "VALIDATION_FAILED"
+	ErrorCodeUnauthenticated    = "UNAUTHENTICATED"
+	ErrorCodeInvalidCredentials = "INVALID_CREDENTIALS"
+	ErrorCodeSessionExpired     = "SESSION_EXPIRED"
+	ErrorCodeEmailNotVerified   = "EMAIL_NOT_VERIFIED"
+	ErrorCodeAccountLocked      = "ACCOUNT_LOCKED"
+	ErrorCodeInvalidToken       = "INVALID_TOKEN"
+	ErrorCodePermissionDenied   = "PERMISSION_DENIED"
+	ErrorCodeResourceNotFound   = "RESOURCE_NOT_FOUND"
+	ErrorCodeConflict           = "CONFLICT"
+	ErrorCodeQueryTimeout       = "QUERY_TIMEOUT"
+	ErrorCodeMaintenance        = "MAINTENANCE"
+	ErrorCodePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	ErrorCodeInternal           = "INTERNAL_ERROR"
+)
+
+// ErrorResponse writes the error envelope every API error shares:
+//
+//	{"success": false, "code": "RESOURCE_NOT_FOUND", "message": "Role not found", "details": {...}}
+//
+// details is optional and omitted when nil. Controllers without BaseCrudController, such as
+// AuthController, call it directly; the others use the BaseCrudController helpers.
+func ErrorResponse(ctx http.Context, status int, code, message string, details interface{}) http.Response {
+	return ctx.Response().Json(status, ResponseFormat{
+		Success: false,
+		Code:    code,
+		Message: message,
+		Details: details,
+	})
+}
+
+// FieldErrorResponse is ErrorResponse for per-field messages. They are sent as details and, for
+// forms written before the envelope, under "errors" as well.
+func FieldErrorResponse(ctx http.Context, status int, code, message string, fields map[string]interface{}) http.Response {
+	response := ResponseFormat{
+		Success: false,
+		Code:    code,
+		Message: message,
+	}
+	if len(fields) > 0 {
+		response.Details = fields
+		response.Errors = fields
+	}
+	return ctx.Response().Json(status, response)
+}
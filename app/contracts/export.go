@@ -0,0 +1,229 @@
+package contracts
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+// Supported export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+// exportReservedParams are query parameters that are not treated as filters
+var exportReservedParams = map[string]bool{
+	"format": true, "fields": true, "page": true, "pageSize": true,
+	"search": true, "sort": true, "direction": true,
+}
+
+// ValidateExportRequest parses format, fields, search/sort and filters from the query string
+func (c *BaseCrudController) ValidateExportRequest(ctx http.Context) (*ExportRequest, error) {
+	req := &ExportRequest{
+		Format:  strings.ToLower(ctx.Request().Query("format", ExportFormatCSV)),
+		Filters: make(map[string]interface{}),
+	}
+	if req.Format != ExportFormatCSV && req.Format != ExportFormatJSON {
+		return nil, fmt.Errorf("unsupported export format: %s", req.Format)
+	}
+
+	if fields := ctx.Request().Query("fields", ""); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				req.Fields = append(req.Fields, field)
+			}
+		}
+	}
+
+	req.List = ListRequest{
+		Page:      1,
+		PageSize:  c.MaxPageSize(),
+		Search:    ctx.Request().Query("search", ""),
+		Sort:      ctx.Request().Query("sort", ""),
+		Direction: strings.ToUpper(ctx.Request().Query("direction", "")),
+	}
+	req.List.SetDefaults()
+
+	for key, value := range ctx.Request().Queries() {
+		if !exportReservedParams[key] && value != "" {
+			req.Filters[key] = value
+		}
+	}
+
+	return req, nil
+}
+
+// ExportResponse streams every page returned by fetch as CSV or JSON with download headers
+func (c *BaseCrudController) ExportResponse(ctx http.Context, filename string, req *ExportRequest, fetch func(ListRequest) (*PaginatedResult, error)) http.Response {
+	// Load the first page up front so errors can still be reported as JSON
+	first, err := fetch(req.List)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to export "+filename+": "+err.Error())
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if req.Format == ExportFormatJSON {
+		contentType = "application/json"
+	}
+	disposition := fmt.Sprintf(`attachment; filename="%s-%s.%s"`, filename, time.Now().Format("20060102-150405"), req.Format)
+
+	return ctx.Response().
+		Header("Content-Type", contentType).
+		Header("Content-Disposition", disposition).
+		Stream(http.StatusOK, func(w http.StreamWriter) error {
+			exporter := newRecordExporter(w, req.Format, req.Fields)
+			result := first
+			listReq := req.List
+			for {
+				for _, record := range result.Data {
+					if err := exporter.write(record); err != nil {
+						return err
+					}
+				}
+				if err := w.Flush(); err != nil {
+					return err
+				}
+				if !result.HasNext {
+					break
+				}
+
+				listReq.Page++
+				if result, err = fetch(listReq); err != nil {
+					return err
+				}
+			}
+			return exporter.close()
+		})
+}
+
+// recordExporter writes records to a stream in the requested format
+type recordExporter struct {
+	w       http.StreamWriter
+	format  string
+	fields  []string
+	csv     *csv.Writer
+	started bool
+}
+
+func newRecordExporter(w http.StreamWriter, format string, fields []string) *recordExporter {
+	exporter := &recordExporter{w: w, format: format, fields: fields}
+	if format == ExportFormatCSV {
+		exporter.csv = csv.NewWriter(w)
+	}
+	return exporter
+}
+
+func (e *recordExporter) write(record interface{}) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return err
+	}
+
+	// Derive the columns from the first record when no fields were requested
+	if len(e.fields) == 0 {
+		e.fields = orderedJSONKeys(raw)
+	}
+
+	if e.format == ExportFormatJSON {
+		selected := make(map[string]interface{}, len(e.fields))
+		for _, field := range e.fields {
+			selected[field] = values[field]
+		}
+		encoded, err := json.Marshal(selected)
+		if err != nil {
+			return err
+		}
+		prefix := ","
+		if !e.started {
+			prefix = "["
+		}
+		e.started = true
+		_, err = e.w.WriteString(prefix + string(encoded))
+		return err
+	}
+
+	if !e.started {
+		e.started = true
+		if err := e.csv.Write(e.fields); err != nil {
+			return err
+		}
+	}
+	row := make([]string, len(e.fields))
+	for i, field := range e.fields {
+		row[i] = formatExportValue(values[field])
+	}
+	if err := e.csv.Write(row); err != nil {
+		return err
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *recordExporter) close() error {
+	if e.format == ExportFormatJSON {
+		closing := "]"
+		if !e.started {
+			closing = "[]"
+		}
+		_, err := e.w.WriteString(closing)
+		return err
+	}
+
+	if !e.started && len(e.fields) > 0 {
+		if err := e.csv.Write(e.fields); err != nil {
+			return err
+		}
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+// orderedJSONKeys returns the top-level keys of a JSON object in document order
+func orderedJSONKeys(raw []byte) []string {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+
+	keys := []string{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return keys
+		}
+		keys = append(keys, token.(string))
+
+		var skip json.RawMessage
+		if err := decoder.Decode(&skip); err != nil {
+			return keys
+		}
+	}
+	return keys
+}
+
+// formatExportValue renders a decoded JSON value as a CSV cell
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
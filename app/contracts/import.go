@@ -0,0 +1,175 @@
+package contracts
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+// Import row statuses reported in ImportRowResult
+const (
+	ImportStatusCreated = "created"
+	ImportStatusUpdated = "updated"
+	ImportStatusFailed  = "failed"
+	ImportStatusSkipped = "skipped"
+)
+
+// maxImportRows matches the bulk operation limit
+const maxImportRows = 1000
+
+// ValidateImportRequest reads the uploaded "file" and parses it as CSV or JSON.
+// The format comes from the "format" field, falling back to the file extension.
+func (c *BaseCrudController) ValidateImportRequest(ctx http.Context) (*ImportRequest, error) {
+	file, err := ctx.Request().File("file")
+	if err != nil {
+		return nil, fmt.Errorf("a file upload is required: %w", err)
+	}
+
+	format := strings.ToLower(ctx.Request().Input("format"))
+	if format == "" {
+		format = strings.ToLower(strings.TrimPrefix(filepath.Ext(file.GetClientOriginalName()), "."))
+	}
+	if format != ExportFormatCSV && format != ExportFormatJSON {
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+
+	reader, err := os.Open(file.File())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	defer reader.Close()
+
+	var rows []ImportRow
+	if format == ExportFormatCSV {
+		rows, err = parseCSVImport(reader)
+	} else {
+		rows, err = parseJSONImport(reader)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("import file contains no rows")
+	}
+	if len(rows) > maxImportRows {
+		return nil, fmt.Errorf("import cannot exceed %d rows", maxImportRows)
+	}
+
+	return &ImportRequest{
+		Format:         format,
+		SkipErrors:     ctx.Request().InputBool("skipErrors"),
+		UpdateExisting: ctx.Request().InputBool("updateExisting"),
+		Rows:           rows,
+	}, nil
+}
+
+// ImportResponse runs importRow for every row and reports per-row outcomes.
+// importRow returns ImportStatusCreated or ImportStatusUpdated on success. Without
+// SkipErrors the import stops at the first failing row and the rest are reported as skipped.
+func (c *BaseCrudController) ImportResponse(ctx http.Context, req *ImportRequest, importRow func(row ImportRow) (string, error)) http.Response {
+	result := ImportResult{
+		Total: len(req.Rows),
+		Rows:  make([]ImportRowResult, 0, len(req.Rows)),
+	}
+
+	stopped := false
+	for _, row := range req.Rows {
+		rowResult := ImportRowResult{Line: row.Line}
+		if stopped {
+			rowResult.Status = ImportStatusSkipped
+			result.Skipped++
+			result.Rows = append(result.Rows, rowResult)
+			continue
+		}
+
+		status, err := importRow(row)
+		if err != nil {
+			rowResult.Status = ImportStatusFailed
+			rowResult.Error = err.Error()
+			if fieldErr, ok := err.(*FieldValidationError); ok {
+				rowResult.Errors = fieldErr.FieldErrors()
+			}
+			result.Failed++
+			stopped = !req.SkipErrors
+		} else {
+			rowResult.Status = status
+			if status == ImportStatusUpdated {
+				result.Updated++
+			} else {
+				result.Created++
+			}
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	message := fmt.Sprintf("Import completed: %d created, %d updated, %d failed, %d skipped",
+		result.Created, result.Updated, result.Failed, result.Skipped)
+	response := ResponseFormat{
+		Success: result.Failed == 0,
+		Data:    result,
+		Message: message,
+	}
+	return ctx.Response().Json(http.StatusOK, response)
+}
+
+// parseCSVImport maps each record to the header row. Line numbers count the header as line 1.
+func parseCSVImport(reader io.Reader) ([]ImportRow, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+	}
+
+	rows := []ImportRow{}
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// csv.ParseError already carries the line number
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := csvReader.FieldPos(0)
+
+		data := make(map[string]interface{}, len(header))
+		for i, column := range header {
+			// Empty cells are treated as absent so optional fields keep their defaults
+			if i < len(record) && column != "" && strings.TrimSpace(record[i]) != "" {
+				data[column] = strings.TrimSpace(record[i])
+			}
+		}
+		rows = append(rows, ImportRow{Line: line, Data: data})
+	}
+
+	return rows, nil
+}
+
+// parseJSONImport expects an array of objects. Line numbers are the 1-based array index.
+func parseJSONImport(reader io.Reader) ([]ImportRow, error) {
+	var records []map[string]interface{}
+	if err := json.NewDecoder(reader).Decode(&records); err != nil {
+		return nil, fmt.Errorf("invalid JSON: expected an array of objects: %w", err)
+	}
+
+	rows := make([]ImportRow, 0, len(records))
+	for i, record := range records {
+		rows = append(rows, ImportRow{Line: i + 1, Data: record})
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,96 @@
+package contracts
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/goravel/framework/facades"
+)
+
+// Lifecycle events a service dispatches once a change to a record has been saved
+const (
+	EventCreated      = "created"
+	EventUpdated      = "updated"
+	EventDeleted      = "deleted"
+	EventRestored     = "restored"      // a soft-deleted record was brought back
+	EventForceDeleted = "force_deleted" // a record was removed for good; the event carries its last state
+)
+
+// EventAny matches every resource or every event when registering an observer
+const EventAny = "*"
+
+// LifecycleListener receives lifecycle events; resource is the dispatching service's table name
+type LifecycleListener func(resource, event string, record interface{})
+
+// Observer runs a side effect, such as clearing a cache, with the model a lifecycle event is about
+type Observer func(record interface{})
+
+type lifecycleRegistration struct {
+	resource string
+	event    string
+	listener LifecycleListener
+}
+
+var (
+	lifecycleMu            sync.RWMutex
+	lifecycleRegistrations []lifecycleRegistration
+)
+
+// RegisterObserver runs fn whenever resource (a service's table name, e.g. "books") dispatches
+// event. Either may be EventAny. Observers run in registration order on the request goroutine,
+// so slow work such as HTTP calls belongs in a goroutine.
+func RegisterObserver(resource, event string, fn Observer) {
+	registerLifecycleListener(resource, event, func(_, _ string, record interface{}) {
+		fn(record)
+	})
+}
+
+// OnLifecycleEvent registers listener for every event of every service built on BaseCrudService
+func OnLifecycleEvent(listener LifecycleListener) {
+	registerLifecycleListener(EventAny, EventAny, listener)
+}
+
+func registerLifecycleListener(resource, event string, listener LifecycleListener) {
+	lifecycleMu.Lock()
+	defer lifecycleMu.Unlock()
+	lifecycleRegistrations = append(lifecycleRegistrations, lifecycleRegistration{
+		resource: resource,
+		event:    event,
+		listener: listener,
+	})
+}
+
+// DispatchEvent drops record's cached copy and tells the matching observers that it was created,
+// updated, deleted, restored or force deleted. Call it after the change is committed so observers never see a write that
+// was rolled back. The change is already saved, so an observer that panics is logged instead of
+// failing the caller.
+func (b *BaseCrudService) DispatchEvent(event string, record interface{}) {
+	b.ForgetRecord(lifecycleRecordID(record))
+
+	lifecycleMu.RLock()
+	registrations := append([]lifecycleRegistration(nil), lifecycleRegistrations...)
+	lifecycleMu.RUnlock()
+
+	for _, registration := range registrations {
+		if registration.resource != EventAny && registration.resource != b.tableName {
+			continue
+		}
+		if registration.event != EventAny && registration.event != event {
+			continue
+		}
+		b.runLifecycleListener(registration.listener, event, record)
+	}
+}
+
+func (b *BaseCrudService) runLifecycleListener(listener LifecycleListener, event string, record interface{}) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			facades.Log().Error("Lifecycle observer panicked", map[string]interface{}{
+				"resource": b.tableName,
+				"event":    event,
+				"error":    fmt.Sprint(recovered),
+			})
+		}
+	}()
+	listener(b.tableName, event, record)
+}
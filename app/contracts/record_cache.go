@@ -0,0 +1,87 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/goravel/framework/contracts/cache"
+	"github.com/goravel/framework/facades"
+)
+
+// SetRecordCache opts the service in or out of caching GetByID results. Leave it off for records
+// that change outside Update/Delete, such as users whose login state is written on every sign-in.
+func (b *BaseCrudService) SetRecordCache(enabled bool) {
+	b.cacheRecords = enabled
+}
+
+// RecordCacheTTL is how long a cached record lives, cache.records.ttl in seconds (default 300).
+// 0 turns record caching off for every service.
+func RecordCacheTTL() time.Duration {
+	return time.Duration(facades.Config().GetInt("cache.records.ttl", 300)) * time.Second
+}
+
+// RecordCacheKey is the cache key of one record, {resource}:{id}
+func (b *BaseCrudService) RecordCacheKey(id uint) string {
+	return fmt.Sprintf("%s:%d", b.tableName, id)
+}
+
+// CachedRecord is cache-aside for GetByID. A hit is decoded into dest, a pointer to an empty
+// model; a miss calls load and caches its result as JSON so any store, Redis included, can hold
+// it. Without SetRecordCache(true), or with a TTL of 0, load is called every time.
+func (b *BaseCrudService) CachedRecord(id uint, dest interface{}, load func() (interface{}, error)) (interface{}, error) {
+	ttl := RecordCacheTTL()
+	if !b.cacheRecords || ttl <= 0 {
+		return load()
+	}
+
+	key := b.RecordCacheKey(id)
+	if cached := recordCache().GetString(key); cached != "" {
+		if err := json.Unmarshal([]byte(cached), dest); err == nil {
+			return dest, nil
+		}
+		_ = recordCache().Forget(key)
+	}
+
+	record, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if payload, err := json.Marshal(record); err == nil {
+		if err := recordCache().Put(key, string(payload), ttl); err != nil {
+			facades.Log().Warning("Failed to cache record", map[string]interface{}{"key": key, "error": err.Error()})
+		}
+	}
+	return record, nil
+}
+
+// ForgetRecord drops id's cached copy. DispatchEvent calls it for every lifecycle event, and
+// Restore, ForceDelete and SetActive call it directly.
+func (b *BaseCrudService) ForgetRecord(id uint) {
+	if !b.cacheRecords || id == 0 {
+		return
+	}
+	_ = recordCache().Forget(b.RecordCacheKey(id))
+}
+
+// recordCache is the store named by cache.records.store, or the default store when it is empty
+func recordCache() cache.Driver {
+	if store := facades.Config().GetString("cache.records.store"); store != "" {
+		return facades.Cache().Store(store)
+	}
+	return facades.Cache()
+}
+
+// lifecycleRecordID reads the ID field of the model a lifecycle event carries
+func lifecycleRecordID(record interface{}) uint {
+	value := reflect.Indirect(reflect.ValueOf(record))
+	if value.Kind() != reflect.Struct {
+		return 0
+	}
+	field := value.FieldByName("ID")
+	if !field.IsValid() || !field.CanUint() {
+		return 0
+	}
+	return uint(field.Uint())
+}
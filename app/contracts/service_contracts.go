@@ -1,11 +1,14 @@
 package contracts
 
+import "context"
+
 // CrudServiceContract defines the mandatory interface for all CRUD services
 // This contract FORCES implementation of pagination, sorting, and filtering
 type CrudServiceContract interface {
 	// Core CRUD operations - ALL must be implemented
-	GetList(req ListRequest) (*PaginatedResult, error)
-	GetListAdvanced(req ListRequest, filters map[string]interface{}) (*PaginatedResult, error)
+	// List queries run under ctx, so they stop when the request is cancelled or times out
+	GetList(ctx context.Context, req ListRequest) (*PaginatedResult, error)
+	GetListAdvanced(ctx context.Context, req ListRequest, filters map[string]interface{}) (*PaginatedResult, error)
 	GetByID(id uint) (interface{}, error)
 	Create(data map[string]interface{}) (interface{}, error)
 	Update(id uint, data map[string]interface{}) (interface{}, error)
@@ -24,7 +27,7 @@ type CrudServiceContract interface {
 // PaginationServiceContract enforces pagination functionality
 type PaginationServiceContract interface {
 	// GetPaginatedList MUST implement proper pagination
-	GetPaginatedList(req ListRequest) (*PaginatedResult, error)
+	GetPaginatedList(ctx context.Context, req ListRequest) (*PaginatedResult, error)
 	
 	// ValidatePaginationParams ensures valid pagination parameters
 	ValidatePaginationParams(page, pageSize int) error
@@ -64,6 +67,9 @@ type FilterableServiceContract interface {
 	
 	// ValidateFilterValue checks if value is valid for the field
 	ValidateFilterValue(field string, value interface{}) bool
+
+	// GetFilterFieldTypes declares the value type (bool/int/float/string) of filters that need coercion
+	GetFilterFieldTypes() map[string]string
 	
 	// GetSearchableFields returns fields that support text search
 	GetSearchableFields() []string
@@ -75,13 +81,19 @@ type FilterableServiceContract interface {
 // SearchableServiceContract enforces search functionality
 type SearchableServiceContract interface {
 	// Search performs full-text search across searchable fields
-	Search(query string, req ListRequest) (*PaginatedResult, error)
+	Search(ctx context.Context, query string, req ListRequest) (*PaginatedResult, error)
 	
 	// GetSearchableFields returns fields that support search
 	GetSearchableFields() []string
 	
 	// ValidateSearchQuery validates the search query
 	ValidateSearchQuery(query string) error
+
+	// GetSearchConstraints returns the allowed search query length in characters
+	GetSearchConstraints() (minLength, maxLength int)
+
+	// GetSearchFieldModes returns the match strategy per searchable field; unlisted fields use contains
+	GetSearchFieldModes() map[string]string
 }
 
 // BulkOperationsContract enforces bulk operations
@@ -99,6 +111,75 @@ type BulkOperationsContract interface {
 	ValidateBulkOperation(ids []uint) error
 }
 
+// SoftDeleteServiceContract exposes soft-delete recovery for services whose models use orm.SoftDeletes
+type SoftDeleteServiceContract interface {
+	// Restore brings back a soft-deleted record
+	Restore(id uint) error
+	// ForceDelete permanently removes a record, whether or not it is soft-deleted
+	ForceDelete(id uint) error
+}
+
+// TrashedServiceContract lists soft-deleted records for trash screens
+type TrashedServiceContract interface {
+	// GetTrashed pages through soft-deleted records with the usual pagination and sorting
+	GetTrashed(ctx context.Context, req ListRequest) (*PaginatedResult, error)
+}
+
+// RelatedServiceContract pages through one relation of a record for
+// GET /{resource}/{id}/related/{relation}
+type RelatedServiceContract interface {
+	// GetByID loads the record whose relation is requested
+	GetByID(id uint) (interface{}, error)
+	// GetRelations lists the relations that may be requested, besides GetRelatedRelations
+	GetRelations() []string
+	// PaginateRelated returns one page of the relation on the loaded record parent
+	PaginateRelated(ctx context.Context, parent interface{}, relation string, req ListRequest) (*PaginatedResult, error)
+}
+
+// RelatedRelationsContract lets a service offer relations on the related endpoint that aren't
+// eager loaded with every record, such as a book's loans. Each maps to the permission needed on
+// top of the resource's view permission, or "" for none.
+type RelatedRelationsContract interface {
+	GetRelatedRelations() map[string]string
+}
+
+// ExistsServiceContract answers the duplicate checks create and edit forms make before submitting
+type ExistsServiceContract interface {
+	FilterableServiceContract
+	// RecordExists reports whether any record other than exceptID, soft-deleted ones included,
+	// holds value in the field column
+	RecordExists(field string, value interface{}, exceptID uint) (bool, error)
+}
+
+// SchemaServiceContract describes which fields a resource's lists can sort, filter and search on
+type SchemaServiceContract interface {
+	SortableServiceContract
+	FilterableServiceContract
+	// GetColumnMapping maps the field names clients send to database columns
+	GetColumnMapping() map[string]string
+}
+
+// FillableServiceContract lists the fields create and update may write. Services drop everything
+// else with OnlyFillable, and strict mode (http.strict_fields) rejects a body carrying it.
+type FillableServiceContract interface {
+	// GetFillableFields returns the data keys create and update write, as clients send them
+	GetFillableFields() []string
+}
+
+// ActivatableServiceContract toggles is_active for services whose models carry that column
+type ActivatableServiceContract interface {
+	// SetActive sets is_active on a non-deleted record
+	SetActive(id uint, active bool) error
+	// GetByID loads the record returned after the change
+	GetByID(id uint) (interface{}, error)
+}
+
+// Trashed filter values accepted by GetListAdvanced
+const (
+	TrashedWith = "with" // include soft-deleted rows
+	TrashedOnly = "only" // only soft-deleted rows
+)
+
 // CrudServiceConfiguration defines configuration that services must provide
 type CrudServiceConfiguration interface {
 	// GetTableName returns the primary table name
@@ -113,8 +194,14 @@ type CrudServiceConfiguration interface {
 	// GetValidationRules returns validation rules for create/update
 	GetValidationRules() map[string]interface{}
 	
+	// GetFillableFields returns the fields create/update may write; the rest are guarded
+	GetFillableFields() []string
+	
 	// GetColumnMapping returns frontend->database column mapping
 	GetColumnMapping() map[string]string
+	
+	// GetRelations returns the relations GetList, GetListAdvanced and GetByID eager load
+	GetRelations() []string
 }
 
 // CompleteCrudService combines all contracts into one interface
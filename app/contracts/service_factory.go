@@ -83,10 +83,10 @@ func (sf *ServiceFactory) validateServiceContracts(service interface{}) error {
 		"GetList", "GetListAdvanced", "GetByID", "Create", "Update", "Delete",
 		"GetPaginatedList", "ValidatePaginationParams", "GetMaxPageSize", "GetDefaultPageSize",
 		"GetSortableFields", "ValidateSortField", "ValidateSortDirection", "GetDefaultSort", "MapSortField",
-		"GetFilterableFields", "ValidateFilterField", "ValidateFilterValue", "GetSearchableFields", "BuildFilterQuery",
-		"Search", "ValidateSearchQuery",
+		"GetFilterableFields", "ValidateFilterField", "ValidateFilterValue", "GetFilterFieldTypes", "GetSearchableFields", "BuildFilterQuery",
+		"Search", "ValidateSearchQuery", "GetSearchConstraints", "GetSearchFieldModes",
 		"BulkCreate", "BulkUpdate", "BulkDelete", "ValidateBulkOperation",
-		"GetTableName", "GetPrimaryKey", "GetModel", "GetValidationRules", "GetColumnMapping",
+		"GetTableName", "GetPrimaryKey", "GetModel", "GetValidationRules", "GetColumnMapping", "GetRelations",
 	}
 	
 	missing := []string{}
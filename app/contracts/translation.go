@@ -0,0 +1,73 @@
+package contracts
+
+import (
+	"strings"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/translation"
+	"github.com/goravel/framework/facades"
+)
+
+// TRANSLATION
+//
+// User-facing lines live in lang/<locale>/<group>.json and are looked up in the request's locale,
+// set by the Locale middleware. A line missing from that locale falls back to English.
+
+// Trans resolves key, e.g. "responses.not_found", in the request's locale. replace fills the
+// line's :placeholders; a key with no line anywhere comes back unchanged.
+func Trans(ctx http.Context, key string, replace ...map[string]string) string {
+	option := translation.Option{}
+	if len(replace) > 0 {
+		option.Replace = replace[0]
+	}
+	return facades.Lang(ctx).Get(key, option)
+}
+
+// ValidationMessages resolves the custom messages of a form request for resource. Each key is a
+// "field.rule" pair, optionally followed by the rule's argument as in "title.max:255". A pair is
+// looked up as validation.<resource>.<field>.<rule>; without its own line it uses the generic
+// validation.<rule> line, with :attribute set to the field's name and :<rule> to the argument.
+// Rules with neither keep the validator's default message.
+func ValidationMessages(ctx http.Context, resource string, keys ...string) map[string]string {
+	messages := make(map[string]string, len(keys))
+	for _, key := range keys {
+		key, argument, _ := strings.Cut(key, ":")
+		separator := strings.LastIndex(key, ".")
+		if separator < 0 {
+			continue
+		}
+		field, rule := key[:separator], key[separator+1:]
+
+		specific := "validation." + resource + "." + key
+		if line := Trans(ctx, specific); line != specific {
+			messages[key] = line
+			continue
+		}
+		generic := "validation." + rule
+		if line := Trans(ctx, generic, map[string]string{
+			"attribute": validationAttribute(ctx, resource, field),
+			rule:        argument,
+		}); line != generic {
+			messages[key] = line
+		}
+	}
+	return messages
+}
+
+// ValidationAttributes resolves the display names of fields as attributes.<resource>.<field>,
+// falling back to the field name with underscores as spaces
+func ValidationAttributes(ctx http.Context, resource string, fields ...string) map[string]string {
+	attributes := make(map[string]string, len(fields))
+	for _, field := range fields {
+		attributes[field] = validationAttribute(ctx, resource, field)
+	}
+	return attributes
+}
+
+func validationAttribute(ctx http.Context, resource, field string) string {
+	key := "attributes." + resource + "." + field
+	if name := Trans(ctx, key); name != key {
+		return name
+	}
+	return strings.ReplaceAll(strings.TrimSuffix(field, ".*"), "_", " ")
+}
@@ -1,5 +1,7 @@
 package contracts
 
+import "fmt"
+
 // ListRequest for pagination, sorting, and filtering
 type ListRequest struct {
 	Page      int                    `form:"page" json:"page"`
@@ -8,6 +10,7 @@ type ListRequest struct {
 	Direction string                 `form:"direction" json:"direction"`
 	Search    string                 `form:"search" json:"search"`
 	Filters   map[string]interface{} `form:"filters" json:"filters"`
+	Cursor    string                 `form:"cursor" json:"cursor"` // opaque nextCursor from a previous page; switches to keyset pagination
 }
 
 // ListResponse for paginated results
@@ -30,6 +33,7 @@ type PaginatedResult struct {
 	To          int           `json:"to"`
 	HasNext     bool          `json:"hasNext"`
 	HasPrev     bool          `json:"hasPrev"`
+	NextCursor  string        `json:"nextCursor,omitempty"`
 }
 
 // SetDefaults applies sensible defaults to ListRequest
@@ -40,12 +44,10 @@ func (r *ListRequest) SetDefaults() {
 	if r.PageSize <= 0 {
 		r.PageSize = 20
 	}
-	if r.PageSize > 100 {
-		r.PageSize = 100
-	}
-	if r.Sort == "" {
-		r.Sort = "id"
+	if r.PageSize > MaxPageSize() {
+		r.PageSize = MaxPageSize()
 	}
+	// An empty sort is left for the service to fill in with its GetDefaultSort
 	if r.Direction == "" {
 		r.Direction = "DESC"
 	}
@@ -53,4 +55,78 @@ func (r *ListRequest) SetDefaults() {
 	if r.PageSize == 0 {
 		r.PageSize = 20
 	}
-}
\ No newline at end of file
+}
+
+// BulkActionRequest is the payload accepted by bulk endpoints
+type BulkActionRequest struct {
+	IDs      []uint                 `form:"ids" json:"ids"`
+	Data     map[string]interface{} `form:"data" json:"data"`
+	Status   string                 `form:"status" json:"status"`
+	IsActive *bool                  `form:"is_active" json:"is_active"`
+}
+
+// BulkOperationError reports the records that failed during a bulk operation
+type BulkOperationError struct {
+	Operation string
+	Failed    map[uint]string
+}
+
+func (e *BulkOperationError) Error() string {
+	return fmt.Sprintf("bulk %s failed for %d record(s)", e.Operation, len(e.Failed))
+}
+
+// BulkOperationResult summarizes the outcome of a bulk operation
+type BulkOperationResult struct {
+	Requested int              `json:"requested"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Errors    map[uint]string  `json:"errors,omitempty"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// BulkItemResult is the outcome of a bulk operation for one requested ID
+type BulkItemResult struct {
+	ID      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ImportRequest holds the parsed upload and options for an import
+type ImportRequest struct {
+	Format         string      `json:"format"`
+	SkipErrors     bool        `json:"skipErrors"`
+	UpdateExisting bool        `json:"updateExisting"`
+	Rows           []ImportRow `json:"rows"`
+}
+
+// ImportRow is one record from an import file with the line it came from
+type ImportRow struct {
+	Line int                    `json:"line"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// ImportRowResult reports what happened to a single imported row
+type ImportRowResult struct {
+	Line   int                    `json:"line"`
+	Status string                 `json:"status"`
+	Error  string                 `json:"error,omitempty"`
+	Errors map[string]interface{} `json:"errors,omitempty"`
+}
+
+// ImportResult summarizes an import with per-row outcomes
+type ImportResult struct {
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Skipped int               `json:"skipped"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// ExportRequest holds the parsed parameters for an export
+type ExportRequest struct {
+	Format  string                 `json:"format"`
+	Fields  []string               `json:"fields"`
+	List    ListRequest            `json:"list"`
+	Filters map[string]interface{} `json:"filters"`
+}
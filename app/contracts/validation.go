@@ -1,6 +1,13 @@
 package contracts
 
-import "github.com/goravel/framework/contracts/http"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
 
 // ValidationRequest defines the contract for validation requests
 type ValidationRequest interface {
@@ -13,7 +20,7 @@ type ValidationRequest interface {
 	Authorize(ctx http.Context) error
 
 	// Optional custom validation
-	PrepareForValidation(ctx http.Context) error
+	PrepareForValidation(ctx http.Context, data validation.Data) error
 	PassedValidation(ctx http.Context) error
 }
 
@@ -45,11 +52,14 @@ const (
 	// String validations
 	MinLength = "min:%d"        // min:3
 	MaxLength = "max:%d"        // max:255
+	MinLen    = "min_len:%d"    // min_len:2; min and max compare numbers, so lengths use these
+	MaxLen    = "max_len:%d"    // max_len:255
 	Email     = "email"
 	URL       = "url"
 	Alpha     = "alpha"
 	AlphaNum  = "alpha_num"
 	Regex     = "regex:%s"      // regex:^[a-zA-Z0-9_]+$
+	ISBN      = "isbn"          // ISBN-10 or ISBN-13 with a correct check digit
 
 	// Numeric validations
 	Numeric  = "numeric"
@@ -65,8 +75,9 @@ const (
 	After      = "after:%s"       // after:2024-01-01
 
 	// Database validations
-	Unique = "unique:%s,%s" // unique:users,email
-	Exists = "exists:%s,%s" // exists:categories,id
+	Unique       = "unique:%s,%s"    // unique:users,email; soft-deleted rows don't count
+	UniqueExcept = "unique:%s,%s,%v" // unique:users,email,5 also skips the record being updated
+	Exists       = "exists:%s,%s"    // exists:categories,id
 
 	// File validations
 	File    = "file"
@@ -78,4 +89,77 @@ const (
 	Array    = "array"
 	ArrayMin = "min:%d" // For arrays: min:1
 	ArrayMax = "max:%d" // For arrays: max:10
-)
\ No newline at end of file
+
+	// Object validations
+	Map = "map" // A JSON object, e.g. a metadata document
+)
+
+// FieldValidationError carries the per-field messages of a failed validation, from the request
+// layer or a service, so controllers can return them keyed by field. Every validation failure
+// reaches the client in this one shape: a list of messages per field.
+type FieldValidationError struct {
+	Fields map[string][]string
+}
+
+// NewFieldValidationError creates an empty FieldValidationError to Add messages to
+func NewFieldValidationError() *FieldValidationError {
+	return &FieldValidationError{Fields: make(map[string][]string)}
+}
+
+// FieldValidationErrorFromValidator wraps the output of validation.Errors.All(), keeping each
+// field's messages in rule name order
+func FieldValidationErrorFromValidator(fields map[string]map[string]string) *FieldValidationError {
+	e := NewFieldValidationError()
+	for field, rules := range fields {
+		ruleNames := make([]string, 0, len(rules))
+		for rule := range rules {
+			ruleNames = append(ruleNames, rule)
+		}
+		sort.Strings(ruleNames)
+		for _, rule := range ruleNames {
+			e.Add(field, rules[rule])
+		}
+	}
+	return e
+}
+
+// Add records a message against field
+func (e *FieldValidationError) Add(field, message string) {
+	e.Fields[field] = append(e.Fields[field], message)
+}
+
+// HasErrors reports whether any messages were added
+func (e *FieldValidationError) HasErrors() bool {
+	return len(e.Fields) > 0
+}
+
+// Err returns e when messages were added and nil otherwise, so validators can end with return errs.Err()
+func (e *FieldValidationError) Err() error {
+	if !e.HasErrors() {
+		return nil
+	}
+	return e
+}
+
+func (e *FieldValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, strings.Join(e.Fields[field], ", ")))
+	}
+	return "validation errors: " + strings.Join(messages, "; ")
+}
+
+// FieldErrors returns every message for each field, e.g. {"name": ["name is required"]}
+func (e *FieldValidationError) FieldErrors() map[string]interface{} {
+	result := make(map[string]interface{}, len(e.Fields))
+	for field, messages := range e.Fields {
+		result[field] = messages
+	}
+	return result
+}
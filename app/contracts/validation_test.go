@@ -0,0 +1,31 @@
+package contracts
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFieldValidationErrorShape checks that request and service validation failures reach the
+// client the same way: every message for a field, as a list
+func TestFieldValidationErrorShape(t *testing.T) {
+	fromValidator := FieldValidationErrorFromValidator(map[string]map[string]string{
+		"title": {"required": "title is required", "max_len": "title is too long"},
+	})
+	fromService := NewFieldValidationError()
+	fromService.Add("title", "title is too long")
+	fromService.Add("title", "title is required")
+
+	want := map[string]interface{}{"title": []string{"title is too long", "title is required"}}
+	for name, err := range map[string]*FieldValidationError{"validator": fromValidator, "service": fromService} {
+		if got := err.FieldErrors(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s FieldErrors() = %v, want %v", name, got, want)
+		}
+	}
+
+	if err := NewFieldValidationError().Err(); err != nil {
+		t.Errorf("Err() without messages = %v, want nil", err)
+	}
+	if got := fromService.Error(); got != "validation errors: title: title is too long, title is required" {
+		t.Errorf("Error() = %q", got)
+	}
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/goravel/framework/contracts/http"
+	"players/app/auth"
+	"players/app/contracts"
+	"players/app/services"
+)
+
+// ApiKeysController lets admins mint and revoke API keys for service-to-service calls. The routes
+// require the api_keys.manage permission.
+type ApiKeysController struct {
+	*contracts.BaseCrudController
+	apiKeys *services.ApiKeyService
+}
+
+// NewApiKeysController creates a new API keys controller
+func NewApiKeysController() *ApiKeysController {
+	return &ApiKeysController{
+		BaseCrudController: contracts.NewBaseCrudController("api_keys"),
+		apiKeys:            services.NewApiKeyService(),
+	}
+}
+
+// Index GET /api/api-keys - Every key with its owner, scopes and when it was last used
+func (c *ApiKeysController) Index(ctx http.Context) http.Response {
+	keys, err := c.apiKeys.List()
+	if err != nil {
+		return c.QueryFailedResponse(ctx, "Failed to retrieve API keys", err)
+	}
+	return c.SuccessResponse(ctx, keys, "API keys retrieved successfully")
+}
+
+// Store POST /api/api-keys - Mint a key owned by the caller, limited to the given permission slugs.
+// The key is only shown in this response.
+func (c *ApiKeysController) Store(ctx http.Context) http.Response {
+	user, err := auth.GetPermissionHelper().RequireAuthentication(ctx)
+	if err != nil {
+		return c.UnauthorizedResponse(ctx, "Authentication required")
+	}
+
+	var request struct {
+		Name   string   `form:"name" json:"name"`
+		Scopes []string `form:"scopes" json:"scopes"`
+	}
+	if err := ctx.Request().Bind(&request); err != nil {
+		return c.BadRequestResponse(ctx, "Invalid request data", nil)
+	}
+	if strings.TrimSpace(request.Name) == "" {
+		return c.BadRequestResponse(ctx, "name is required", nil)
+	}
+	if len(request.Scopes) == 0 {
+		return c.BadRequestResponse(ctx, "scopes must be a non-empty array of permission slugs", nil)
+	}
+
+	key, apiKey, err := c.apiKeys.Create(user, request.Name, request.Scopes)
+	if err != nil {
+		if errors.Is(err, services.ErrApiKeyScopeNotHeld) {
+			return c.ForbiddenResponse(ctx, err.Error())
+		}
+		return c.InternalErrorResponse(ctx, "Failed to create API key: "+err.Error())
+	}
+
+	return c.CreatedResponse(ctx, map[string]interface{}{
+		"key":     key,
+		"api_key": apiKey,
+	}, "API key created; store it now, it won't be shown again")
+}
+
+// Destroy DELETE /api/api-keys/{id} - Revoke a key; requests made with it are refused from then on
+func (c *ApiKeysController) Destroy(ctx http.Context) http.Response {
+	user, err := auth.GetPermissionHelper().RequireAuthentication(ctx)
+	if err != nil {
+		return c.UnauthorizedResponse(ctx, "Authentication required")
+	}
+
+	id, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid API key ID", nil)
+	}
+
+	apiKey, err := c.apiKeys.Revoke(user.ID, uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrApiKeyNotFound) {
+			return c.NotFoundResponse(ctx, "API key not found")
+		}
+		return c.InternalErrorResponse(ctx, "Failed to revoke API key: "+err.Error())
+	}
+
+	return c.SuccessResponse(ctx, apiKey, "API key revoked")
+}
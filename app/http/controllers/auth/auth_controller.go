@@ -1,20 +1,39 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/contracts/validation"
 	"github.com/goravel/framework/facades"
+	"players/app/auth"
+	"players/app/contracts"
+	"players/app/http/resources"
 	"players/app/models" // Assuming your User model is here
+	"players/app/services"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// refreshTokenCookie holds the refresh token between logins; the access token lives in "token"
+const refreshTokenCookie = "refresh_token"
+
 type AuthController struct {
-	// Dependencies can be injected here
+	impersonations *services.ImpersonationService
+	refreshTokens  *services.RefreshTokenService
+	userService    *services.UserService
+	verification   *services.EmailVerificationService
 }
 
 func NewAuthController() *AuthController {
-	return &AuthController{}
+	return &AuthController{
+		impersonations: services.NewImpersonationService(),
+		refreshTokens:  services.NewRefreshTokenService(),
+		userService:    services.NewUserService(),
+		verification:   services.NewEmailVerificationService(),
+	}
 }
 
 // LoginRequest defines the structure for login requests.
@@ -66,59 +85,305 @@ func (r *AuthController) Login(ctx http.Context) http.Response {
 		// For Inertia, it's often better to redirect back with errors
 		// or return a JSON response that Inertia can handle to show errors on the form.
 		// However, for a direct API-like error, this is fine.
-		return ctx.Response().Status(http.StatusInternalServerError).Json(http.Json{
-			"message": "Error validating request: " + err.Error(),
-		})
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error validating request: "+err.Error(), nil)
 	}
 	if errors != nil {
 		// Redirect back with validation errors for Inertia to display
 		// This assumes your frontend is set up to handle these errors.
 		// If using inertia-react, errors are typically passed as props.
 		// For simplicity in this step, we'll return JSON, but a redirect back is common.
-		return ctx.Response().Status(http.StatusUnprocessableEntity).Json(errors.All())
+		fields := contracts.FieldValidationErrorFromValidator(errors.All()).FieldErrors()
+		return contracts.FieldErrorResponse(ctx, http.StatusUnprocessableEntity, contracts.ErrorCodeValidationFailed, "Validation failed", fields)
 	}
 
 	var user models.User
 	// Find user by email
 	if err := facades.Orm().Query().Where("email", loginRequest.Email).First(&user); err != nil {
 		// Return error that can be displayed on the login form
-		return ctx.Response().Status(http.StatusUnauthorized).Json(http.Json{
-			"errors": map[string]string{"email": "Invalid credentials (email not found)"},
+		return contracts.FieldErrorResponse(ctx, http.StatusUnauthorized, contracts.ErrorCodeInvalidCredentials, "Invalid credentials", map[string]interface{}{
+			"email": "Invalid credentials (email not found)",
 		})
 	}
 
+	// Locked accounts are refused before the password is checked
+	if user.IsLocked(time.Now()) {
+		return lockedResponse(ctx, &user)
+	}
+
 	// Check password
 	if !facades.Hash().Check(loginRequest.Password, user.Password) {
+		if err := r.userService.RecordFailedLogin(&user); err != nil {
+			facades.Log().Error("Error recording failed login: " + err.Error())
+		}
+		if user.IsLocked(time.Now()) {
+			return lockedResponse(ctx, &user)
+		}
+
 		// Return error that can be displayed on the login form
-		return ctx.Response().Status(http.StatusUnauthorized).Json(http.Json{
-			"errors": map[string]string{"password": "Invalid credentials (password mismatch)"},
+		return contracts.FieldErrorResponse(ctx, http.StatusUnauthorized, contracts.ErrorCodeInvalidCredentials, "Invalid credentials", map[string]interface{}{
+			"password": "Invalid credentials (password mismatch)",
 		})
 	}
 
+	// With verification required, unverified users get a fresh link instead of a session
+	if r.verification.Required() && user.EmailVerifiedAt == nil && !user.EmailVerified {
+		r.verification.SendVerification(&user)
+		return contracts.FieldErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodeEmailNotVerified, "Email address not verified", map[string]interface{}{
+			"email": "Please verify your email address. We've sent you a new verification link.",
+		})
+	}
+
+	if err := r.userService.RecordSuccessfulLogin(&user); err != nil {
+		facades.Log().Error("Error recording login: " + err.Error())
+	}
+
 	// Log the user in and get the token
 	token, err := facades.Auth(ctx).Login(&user)
 	if err != nil {
-		return ctx.Response().Status(http.StatusInternalServerError).Json(http.Json{
-			"message": "Error during login: " + err.Error(),
-		})
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error during login: "+err.Error(), nil)
+	}
+
+	// Issue a refresh token so the short-lived access token can be renewed without logging in again
+	refreshToken, err := r.refreshTokens.Issue(user.ID)
+	if err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error during login: "+err.Error(), nil)
+	}
+
+	// Set both tokens in HTTP-only cookies
+	r.setAuthCookies(ctx, token, refreshToken)
+
+	// Redirect to dashboard on successful login.
+	// Use 303 See Other to ensure the next request is a GET, which is best practice for Inertia.
+	return ctx.Response().Redirect(http.StatusSeeOther, "/dashboard")
+}
+
+// Me GET /api/auth/me - The authenticated user with their active role slugs and flattened
+// permission list, so the SPA can rebuild permission-aware navigation after a reload
+func (r *AuthController) Me(ctx http.Context) http.Response {
+	user, err := auth.GetPermissionHelper().RequireAuthentication(ctx)
+	if err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusUnauthorized, contracts.ErrorCodeUnauthenticated, "Unauthenticated", nil)
+	}
+
+	roles := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		if role.IsActive {
+			roles = append(roles, role.Slug)
+		}
+	}
+
+	// Copy before sorting; the service returns its cached slice
+	permissions := append([]string{}, auth.GetPermissionService().GetUserPermissions(user)...)
+	sort.Strings(permissions)
+
+	// The SPA shows a banner with a way back while an admin is acting as this user
+	var impersonator interface{}
+	if impersonation := r.impersonations.Active(accessToken(ctx)); impersonation != nil && impersonation.Impersonator != nil {
+		impersonator = resources.NewUserResource(impersonation.Impersonator)
+	}
+
+	return ctx.Response().Success().Json(http.Json{
+		"user":           resources.NewUserResource(user),
+		"roles":          roles,
+		"permissions":    permissions,
+		"is_super_admin": user.IsSuperAdminUser(),
+		"impersonator":   impersonator,
+	})
+}
+
+// Impersonate POST /api/users/{id}/impersonate - Act as another user. The caller's access token is
+// replaced by one for the target user; their refresh token is kept so the session can be resumed.
+// API keys are refused: a key scoped to users.impersonate must not turn into a full session.
+func (r *AuthController) Impersonate(ctx http.Context) http.Response {
+	if ctx.Value(auth.ApiKeyContextKey) != nil {
+		return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, "API keys cannot impersonate users", nil)
+	}
+
+	impersonator, err := auth.GetPermissionHelper().RequireAuthentication(ctx)
+	if err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusUnauthorized, contracts.ErrorCodeUnauthenticated, "Unauthenticated", nil)
+	}
+
+	id, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeBadRequest, "Invalid user ID", nil)
+	}
+
+	token, impersonation, err := r.impersonations.Start(impersonator, accessToken(ctx), uint(id), func(userID uint) (string, error) {
+		return facades.Auth(ctx).LoginUsingID(userID)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrImpersonationTargetNotFound):
+			return contracts.ErrorResponse(ctx, http.StatusNotFound, contracts.ErrorCodeResourceNotFound, "User not found", nil)
+		case errors.Is(err, services.ErrImpersonationDenied):
+			return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, "You cannot impersonate this user", nil)
+		case errors.Is(err, services.ErrAlreadyImpersonating):
+			return contracts.ErrorResponse(ctx, http.StatusConflict, contracts.ErrorCodeConflict, "Stop impersonating before impersonating another user", nil)
+		}
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error starting impersonation: "+err.Error(), nil)
+	}
+
+	r.setAccessTokenCookie(ctx, token, impersonation.ExpiresAt)
+	return ctx.Response().Success().Json(http.Json{
+		"message":      "Now impersonating " + impersonation.Impersonated.Name,
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_at":   impersonation.ExpiresAt,
+		"user":         resources.NewUserResource(impersonation.Impersonated),
+		"impersonator": resources.NewUserResource(impersonator),
+	})
+}
+
+// StopImpersonating POST /api/auth/stop-impersonating - End the current impersonation and return
+// to the impersonator's own account. The impersonation token is blacklisted.
+func (r *AuthController) StopImpersonating(ctx http.Context) http.Response {
+	impersonation, err := r.impersonations.Stop(accessToken(ctx))
+	if err != nil {
+		if errors.Is(err, services.ErrNotImpersonating) {
+			return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeBadRequest, "You are not impersonating a user", nil)
+		}
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error stopping impersonation: "+err.Error(), nil)
+	}
+
+	if err := facades.Auth(ctx).Logout(); err != nil {
+		facades.Log().Error("Error invalidating impersonation token: " + err.Error())
 	}
 
-	// Set token in HTTP-only cookie
-	ttl := facades.Config().GetInt("jwt.ttl", 720) // Default to 12 hours (720 minutes) if not set
+	// An impersonator deactivated in the meantime is signed out instead of getting a token back
+	impersonator := impersonation.Impersonator
+	if impersonator == nil || !impersonator.IsActive {
+		ctx.Response().WithoutCookie("token")
+		return contracts.ErrorResponse(ctx, http.StatusUnauthorized, contracts.ErrorCodeSessionExpired, "Session expired, please log in again", nil)
+	}
+
+	token, err := facades.Auth(ctx).LoginUsingID(impersonator.ID)
+	if err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error stopping impersonation: "+err.Error(), nil)
+	}
+
+	ttl := r.setAccessTokenCookie(ctx, token, time.Time{})
+	return ctx.Response().Success().Json(http.Json{
+		"message":      "Stopped impersonating",
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   int(ttl.Seconds()),
+		"user":         resources.NewUserResource(impersonator),
+	})
+}
+
+// accessToken returns the request's access token, from the Authorization header or the token
+// cookie, the same places JwtAuth reads it from
+func accessToken(ctx http.Context) string {
+	if parts := strings.Fields(ctx.Request().Header("Authorization", "")); len(parts) == 2 && strings.EqualFold(parts[0], "bearer") {
+		return parts[1]
+	}
+	return ctx.Request().Cookie("token")
+}
+
+// Verify GET /api/auth/verify/{token} - Confirm a user's email address from a verification link
+func (r *AuthController) Verify(ctx http.Context) http.Response {
+	user, err := r.verification.Verify(ctx.Request().Route("token"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidVerificationToken) {
+			return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeInvalidToken, "This verification link is invalid or has expired", nil)
+		}
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error verifying email: "+err.Error(), nil)
+	}
+
+	return ctx.Response().Success().Json(http.Json{
+		"message":           "Email verified, you can now log in",
+		"email_verified_at": user.EmailVerifiedAt,
+	})
+}
+
+// lockedResponse tells the user their account is locked and until when
+func lockedResponse(ctx http.Context, user *models.User) http.Response {
+	// The login form shows the email field error; API clients read locked_until from details
+	return ctx.Response().Json(http.StatusLocked, contracts.ResponseFormat{
+		Success: false,
+		Code:    contracts.ErrorCodeAccountLocked,
+		Message: "Account locked after too many failed login attempts",
+		Details: map[string]interface{}{"locked_until": user.LockedUntil},
+		Errors: map[string]interface{}{
+			"email": "Account locked after too many failed login attempts. Try again after " + user.LockedUntil.Format("2006-01-02 15:04 MST") + ".",
+		},
+	})
+}
+
+// Refresh POST /api/auth/refresh - Exchange a refresh token, from the refresh_token cookie or the
+// "refresh_token" body field, for a new access token. The refresh token is rotated on every call.
+func (r *AuthController) Refresh(ctx http.Context) http.Response {
+	plain := ctx.Request().Input("refresh_token", ctx.Request().Cookie(refreshTokenCookie))
+	userID, refreshToken, err := r.refreshTokens.Rotate(plain)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) {
+			ctx.Response().WithoutCookie(refreshTokenCookie)
+			return contracts.ErrorResponse(ctx, http.StatusUnauthorized, contracts.ErrorCodeSessionExpired, "Session expired, please log in again", nil)
+		}
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error refreshing session: "+err.Error(), nil)
+	}
+
+	// Deactivated or deleted users lose every session instead of getting a new token
+	var user models.User
+	if err := facades.Orm().Query().Where("id = ?", userID).First(&user); err != nil || user.ID == 0 || !user.IsActive {
+		if err := r.refreshTokens.RevokeAll(userID); err != nil {
+			facades.Log().Error("Error revoking refresh tokens: " + err.Error())
+		}
+		ctx.Response().WithoutCookie(refreshTokenCookie)
+		return contracts.ErrorResponse(ctx, http.StatusUnauthorized, contracts.ErrorCodeSessionExpired, "Session expired, please log in again", nil)
+	}
+
+	token, err := facades.Auth(ctx).LoginUsingID(user.ID)
+	if err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Error refreshing session: "+err.Error(), nil)
+	}
+
+	ttl := r.setAuthCookies(ctx, token, refreshToken)
+	return ctx.Response().Success().Json(http.Json{
+		"access_token":  token,
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(ttl.Seconds()),
+	})
+}
+
+// setAuthCookies stores the access and refresh tokens in HTTP-only cookies and returns the access token lifetime
+func (r *AuthController) setAuthCookies(ctx http.Context, token, refreshToken string) time.Duration {
+	ttl := r.setAccessTokenCookie(ctx, token, time.Time{})
+	ctx.Response().Cookie(http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    refreshToken,
+		Expires:  time.Now().Add(r.refreshTokens.TTL()),
+		Path:     "/",
+		HttpOnly: true,
+	})
+	return ttl
+}
+
+// setAccessTokenCookie stores the access token in an HTTP-only cookie that expires at expires, or
+// after jwt.ttl when expires is zero, and returns the cookie's lifetime
+func (r *AuthController) setAccessTokenCookie(ctx http.Context, token string, expires time.Time) time.Duration {
+	if expires.IsZero() {
+		expires = time.Now().Add(time.Duration(facades.Config().GetInt("jwt.ttl", 720)) * time.Minute) // Default to 12 hours (720 minutes) if not set
+	}
 	ctx.Response().Cookie(http.Cookie{
 		Name:     "token",
 		Value:    token,
-		Expires:  time.Now().Add(time.Duration(ttl) * time.Minute),
+		Expires:  expires,
 		Path:     "/",
 		HttpOnly: true,
 	})
-
-	// Redirect to dashboard on successful login.
-	// Use 303 See Other to ensure the next request is a GET, which is best practice for Inertia.
-	return ctx.Response().Redirect(http.StatusSeeOther, "/dashboard")
+	return time.Until(expires)
 }
 
 func (r *AuthController) Logout(ctx http.Context) http.Response {
+	// Revoke the refresh token first so the session cannot be renewed even if logout fails
+	if err := r.refreshTokens.Revoke(ctx.Request().Cookie(refreshTokenCookie)); err != nil {
+		facades.Log().Error("Error revoking refresh token: " + err.Error())
+	}
+	ctx.Response().WithoutCookie(refreshTokenCookie)
+
 	if err := facades.Auth(ctx).Logout(); err != nil {
 		// It's good to log this, but for the user, redirecting is usually best.
 		facades.Log().Error("Error during logout: " + err.Error())
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	goravelgin "github.com/goravel/gin"
+
+	"players/app/auth"
+	"players/app/models"
+)
+
+// TestApiKeyCannotImpersonate sends the request as ApiKeyAuth leaves it for a key scoped to
+// users.impersonate: the handler must refuse before any session token is issued.
+func TestApiKeyCannotImpersonate(t *testing.T) {
+	owner := &models.User{Email: "admin@example.com", IsActive: true, IsSuperAdmin: true}
+	owner.ID = 1
+
+	recorder := httptest.NewRecorder()
+	ginCtx, _ := gin.CreateTestContext(recorder)
+	ginCtx.Request = httptest.NewRequest(nethttp.MethodPost, "/api/users/2/impersonate", nil)
+	ginCtx.Params = gin.Params{{Key: "id", Value: "2"}}
+	ctx := goravelgin.NewContext(ginCtx)
+	ctx.WithValue(auth.ApiKeyContextKey, &models.ApiKey{UserID: owner.ID, Scopes: models.JSON(`["users.impersonate"]`), User: owner})
+
+	controller := &AuthController{}
+	if err := controller.Impersonate(ctx).Render(); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if recorder.Code != nethttp.StatusForbidden {
+		t.Errorf("Impersonate with an API key = %d, want %d: %s", recorder.Code, nethttp.StatusForbidden, recorder.Body)
+	}
+}
@@ -2,34 +2,98 @@ package auth
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
 	"players/app/auth"
+	"players/app/contracts"
 	"players/app/models"
+	"players/app/services"
 )
 
-// PermissionsController handles API endpoints for permission assignment
+// PermissionsController handles API endpoints for listing and assigning permissions
 type PermissionsController struct {
+	permissionsService *services.PermissionsService
 }
 
-// Assign POST /api/permissions/assign - Assign a permission to a role
-func (c *PermissionsController) Assign(ctx http.Context) http.Response {
-	// Check permissions
-	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequireServicePermission(ctx, auth.ServicePermissions, auth.PermissionUpdate)
+// NewPermissionsController creates a new permissions controller
+func NewPermissionsController() *PermissionsController {
+	return &PermissionsController{
+		permissionsService: services.NewPermissionsService(),
+	}
+}
+
+// permissionSummary is the public shape of a permission in the list endpoint
+type permissionSummary struct {
+	ID          uint   `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
+
+// Index GET /api/permissions - Active permissions grouped by category, sorted by category name.
+// ?flat=true returns a single array instead.
+func (c *PermissionsController) Index(ctx http.Context) http.Response {
+	byCategory, err := c.permissionsService.GetPermissionsByCategory()
 	if err != nil {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Insufficient permissions",
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Failed to load permissions", nil)
+	}
+
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	if ctx.Request().QueryBool("flat", false) {
+		flat := make([]permissionSummary, 0)
+		for _, category := range categories {
+			for _, permission := range byCategory[category] {
+				flat = append(flat, summarizePermission(permission))
+			}
+		}
+		return ctx.Response().Json(http.StatusOK, map[string]interface{}{
+			"permissions": flat,
 		})
 	}
 
+	groups := make([]map[string]interface{}, 0, len(categories))
+	for _, category := range categories {
+		permissions := make([]permissionSummary, 0, len(byCategory[category]))
+		for _, permission := range byCategory[category] {
+			permissions = append(permissions, summarizePermission(permission))
+		}
+		groups = append(groups, map[string]interface{}{
+			"category":    category,
+			"permissions": permissions,
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
+		"categories": groups,
+	})
+}
+
+func summarizePermission(permission models.Permission) permissionSummary {
+	return permissionSummary{
+		ID:          permission.ID,
+		Slug:        permission.Slug,
+		Name:        permission.Name,
+		Description: permission.Description,
+		Category:    permission.Category,
+	}
+}
+
+// Assign POST /api/permissions/assign - Assign a permission to a role
+func (c *PermissionsController) Assign(ctx http.Context) http.Response {
 	// Parse request data
 	var requestData map[string]interface{}
 	if err := ctx.Request().Bind(&requestData); err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request data",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeBadRequest, "Invalid request data", nil)
 	}
 
 	// Extract role_id, service, and action
@@ -38,23 +102,19 @@ func (c *PermissionsController) Assign(ctx http.Context) http.Response {
 	action, actionOk := requestData["action"].(string)
 
 	if !roleOk || !serviceOk || !actionOk {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "role_id, service, and action are required",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeBadRequest, "role_id, service, and action are required", nil)
 	}
 
 	roleID := uint(roleIDFloat)
 
 	// Find the role
 	var role models.Role
-	err = facades.Orm().Query().
+	err := facades.Orm().Query().
 		Where("id = ? AND is_active = ?", roleID, true).
 		First(&role)
 
 	if err != nil {
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": "Role not found",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusNotFound, contracts.ErrorCodeResourceNotFound, "Role not found", nil)
 	}
 
 	// Build permission slug
@@ -67,9 +127,13 @@ func (c *PermissionsController) Assign(ctx http.Context) http.Response {
 		First(&permission)
 
 	if err != nil {
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": fmt.Sprintf("Permission '%s' not found", permissionSlug),
-		})
+		return contracts.ErrorResponse(ctx, http.StatusNotFound, contracts.ErrorCodeResourceNotFound, fmt.Sprintf("Permission '%s' not found", permissionSlug), nil)
+	}
+
+	// Non-super-admins may only grant permissions they hold and that are marked can_delegate
+	user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
+	if !auth.GetPermissionService().CanDelegatePermission(user, &permission) {
+		return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, fmt.Sprintf("You cannot delegate permission '%s'", permissionSlug), nil)
 	}
 
 	// Check if permission is already assigned
@@ -79,25 +143,28 @@ func (c *PermissionsController) Assign(ctx http.Context) http.Response {
 		Count(&count)
 
 	if count > 0 {
-		return ctx.Response().Json(http.StatusConflict, map[string]string{
-			"error": "Permission already assigned to role",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusConflict, contracts.ErrorCodeConflict, "Permission already assigned to role", nil)
 	}
 
 	// Create role-permission assignment
 	rolePermission := models.RolePermission{
 		RoleID:       roleID,
 		PermissionID: permission.ID,
+		GrantedAt:    time.Now(),
 		IsActive:     true,
 	}
+	if user != nil {
+		rolePermission.GrantedByID = &user.ID
+	}
 
 	err = facades.Orm().Query().Create(&rolePermission)
 	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to assign permission",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Failed to assign permission", nil)
 	}
 
+	// Drop cached grants so the change applies on the next permission check
+	auth.GetPermissionService().ClearCache()
+
 	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
 		"message": fmt.Sprintf("Permission '%s' assigned to role '%s' successfully", permissionSlug, role.Name),
 	})
@@ -105,21 +172,10 @@ func (c *PermissionsController) Assign(ctx http.Context) http.Response {
 
 // Revoke DELETE /api/permissions/revoke - Revoke a permission from a role
 func (c *PermissionsController) Revoke(ctx http.Context) http.Response {
-	// Check permissions
-	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequireServicePermission(ctx, auth.ServicePermissions, auth.PermissionUpdate)
-	if err != nil {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Insufficient permissions",
-		})
-	}
-
 	// Parse request data
 	var requestData map[string]interface{}
 	if err := ctx.Request().Bind(&requestData); err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request data",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeBadRequest, "Invalid request data", nil)
 	}
 
 	// Extract role_id, service, and action
@@ -128,23 +184,19 @@ func (c *PermissionsController) Revoke(ctx http.Context) http.Response {
 	action, actionOk := requestData["action"].(string)
 
 	if !roleOk || !serviceOk || !actionOk {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "role_id, service, and action are required",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeBadRequest, "role_id, service, and action are required", nil)
 	}
 
 	roleID := uint(roleIDFloat)
 
 	// Find the role
 	var role models.Role
-	err = facades.Orm().Query().
+	err := facades.Orm().Query().
 		Where("id = ? AND is_active = ?", roleID, true).
 		First(&role)
 
 	if err != nil {
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": "Role not found",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusNotFound, contracts.ErrorCodeResourceNotFound, "Role not found", nil)
 	}
 
 	// Build permission slug
@@ -157,23 +209,175 @@ func (c *PermissionsController) Revoke(ctx http.Context) http.Response {
 		First(&permission)
 
 	if err != nil {
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": fmt.Sprintf("Permission '%s' not found", permissionSlug),
-		})
+		return contracts.ErrorResponse(ctx, http.StatusNotFound, contracts.ErrorCodeResourceNotFound, fmt.Sprintf("Permission '%s' not found", permissionSlug), nil)
+	}
+
+	// Non-super-admins may only revoke permissions they hold and that are marked can_delegate
+	user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
+	if !auth.GetPermissionService().CanDelegatePermission(user, &permission) {
+		return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, fmt.Sprintf("You cannot delegate permission '%s'", permissionSlug), nil)
 	}
 
-	// Remove role-permission assignment
+	// Remove role-permission assignment; revoked grants are hard-deleted
 	_, err = facades.Orm().Query().
 		Where("role_id = ? AND permission_id = ?", roleID, permission.ID).
-		Delete(&models.RolePermission{})
+		ForceDelete(&models.RolePermission{})
 
 	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to revoke permission",
-		})
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Failed to revoke permission", nil)
 	}
 
+	// Drop cached grants so the change applies on the next permission check
+	auth.GetPermissionService().ClearCache()
+
 	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
 		"message": fmt.Sprintf("Permission '%s' revoked from role '%s' successfully", permissionSlug, role.Name),
 	})
 }
+
+// UpdateMatrix PUT /api/permissions/matrix - Replace the permissions of several roles at once.
+// The body maps role IDs to the complete list of permission slugs each should hold, e.g.
+// {"matrix": {"2": ["books.view", "books.create"]}}; roles left out are unchanged. All roles are
+// saved in one transaction and the response counts what each one gained and lost.
+func (c *PermissionsController) UpdateMatrix(ctx http.Context) http.Response {
+	// Same super-admin guard as a single role's permission update
+	if err := requireRBACSuperAdmin(ctx, "permissions.update"); err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, "Super admin access required", nil)
+	}
+
+	var request struct {
+		Matrix map[string][]string `json:"matrix"`
+	}
+	if err := ctx.Request().Bind(&request); err != nil || len(request.Matrix) == 0 {
+		return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeBadRequest, "matrix must map role IDs to permission slugs", nil)
+	}
+
+	desired := make(map[uint][]string, len(request.Matrix))
+	roleIDs := make([]uint, 0, len(request.Matrix))
+	slugs := make([]string, 0)
+	for key, roleSlugs := range request.Matrix {
+		roleID, err := strconv.ParseUint(key, 10, 32)
+		if err != nil || roleID == 0 {
+			return contracts.ErrorResponse(ctx, http.StatusBadRequest, contracts.ErrorCodeBadRequest, fmt.Sprintf("Invalid role ID '%s'", key), nil)
+		}
+		desired[uint(roleID)] = roleSlugs
+		roleIDs = append(roleIDs, uint(roleID))
+		slugs = append(slugs, roleSlugs...)
+	}
+	sort.Slice(roleIDs, func(i, j int) bool { return roleIDs[i] < roleIDs[j] })
+
+	var roles []models.Role
+	if err := facades.Orm().Query().Where("id IN ? AND is_active = ?", roleIDs, true).Find(&roles); err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Failed to load roles", nil)
+	}
+	rolesByID := make(map[uint]models.Role, len(roles))
+	for _, role := range roles {
+		rolesByID[role.ID] = role
+	}
+	missingRoles := make([]uint, 0)
+	for _, roleID := range roleIDs {
+		if _, ok := rolesByID[roleID]; !ok {
+			missingRoles = append(missingRoles, roleID)
+		}
+	}
+	if len(missingRoles) > 0 {
+		return contracts.ErrorResponse(ctx, http.StatusNotFound, contracts.ErrorCodeResourceNotFound, "Role not found", map[string]interface{}{
+			"role_ids": missingRoles,
+		})
+	}
+
+	// Resolve the slugs to active permissions; unlike a single role's update, an unknown slug
+	// fails the whole save rather than silently dropping a grant from the grid
+	var permissions []models.Permission
+	if len(slugs) > 0 {
+		if err := facades.Orm().Query().Where("slug IN ? AND is_active = ?", slugs, true).Find(&permissions); err != nil {
+			return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Failed to load permissions", nil)
+		}
+	}
+	permissionsBySlug := make(map[string]models.Permission, len(permissions))
+	for _, permission := range permissions {
+		permissionsBySlug[permission.Slug] = permission
+	}
+	unknown := make([]string, 0)
+	for _, slug := range slugs {
+		if _, ok := permissionsBySlug[slug]; !ok {
+			unknown = append(unknown, slug)
+			permissionsBySlug[slug] = models.Permission{}
+		}
+	}
+	if len(unknown) > 0 {
+		return contracts.ErrorResponse(ctx, http.StatusUnprocessableEntity, contracts.ErrorCodeValidationFailed, "Unknown permissions in matrix", map[string]interface{}{
+			"permissions": unknown,
+		})
+	}
+
+	// Current grants, to work out what each role gains and loses
+	var current []models.RolePermission
+	if err := facades.Orm().Query().Where("role_id IN ?", roleIDs).With("Permission").Find(&current); err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Failed to load current permissions", nil)
+	}
+	granted := make(map[uint]map[uint]models.Permission, len(roleIDs))
+	for _, rp := range current {
+		if !rp.IsActive || rp.Permission.ID == 0 {
+			continue
+		}
+		if granted[rp.RoleID] == nil {
+			granted[rp.RoleID] = make(map[uint]models.Permission)
+		}
+		granted[rp.RoleID][rp.PermissionID] = rp.Permission
+	}
+
+	matrix := make(map[uint][]uint, len(roleIDs))
+	changes := make([]map[string]interface{}, 0, len(roleIDs))
+	changed := make([]models.Permission, 0)
+	for _, roleID := range roleIDs {
+		wanted := make(map[uint]bool)
+		permissionIDs := make([]uint, 0, len(desired[roleID]))
+		added := 0
+		for _, slug := range desired[roleID] {
+			permission := permissionsBySlug[slug]
+			if wanted[permission.ID] {
+				continue
+			}
+			wanted[permission.ID] = true
+			permissionIDs = append(permissionIDs, permission.ID)
+			if _, ok := granted[roleID][permission.ID]; !ok {
+				changed = append(changed, permission)
+				added++
+			}
+		}
+		removed := 0
+		for permissionID, permission := range granted[roleID] {
+			if !wanted[permissionID] {
+				changed = append(changed, permission)
+				removed++
+			}
+		}
+
+		matrix[roleID] = permissionIDs
+		changes = append(changes, map[string]interface{}{
+			"role_id": roleID,
+			"role":    rolesByID[roleID].Name,
+			"added":   added,
+			"removed": removed,
+		})
+	}
+
+	// Non-super-admins may only change grants they hold and that are marked can_delegate
+	user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
+	if denied := auth.GetPermissionService().UndelegablePermissions(user, changed); len(denied) > 0 {
+		return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, "You cannot delegate some of the requested permissions", map[string]interface{}{
+			"permissions": denied,
+		})
+	}
+
+	// The service saves every role in one transaction and clears the permission cache
+	if err := c.permissionsService.SyncPermissionMatrix(matrix); err != nil {
+		return contracts.ErrorResponse(ctx, http.StatusInternalServerError, contracts.ErrorCodeInternal, "Failed to update permissions: "+err.Error(), nil)
+	}
+
+	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
+		"message": fmt.Sprintf("Permissions updated for %d roles", len(roleIDs)),
+		"roles":   changes,
+	})
+}
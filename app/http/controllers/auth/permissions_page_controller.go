@@ -48,9 +48,7 @@ func (c *PermissionsPageController) Index(ctx http.Context) http.Response {
 		Find(&roles)
 
 	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to load roles: " + err.Error(),
-		})
+		return c.InternalErrorResponse(ctx, "Failed to load roles: "+err.Error())
 	}
 
 	// Format roles for frontend with user counts
@@ -143,9 +141,7 @@ func (c *PermissionsPageController) Index(ctx http.Context) http.Response {
 	// Get roles with permissions using the fixed method
 	rolesWithPermissions, err := c.getRolesWithPermissions()
 	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to load roles with permissions: " + err.Error(),
-		})
+		return c.InternalErrorResponse(ctx, "Failed to load roles with permissions: "+err.Error())
 	}
 
 	// Prepare matrix data
@@ -289,25 +285,29 @@ func (c *PermissionsPageController) getRolesWithPermissions() ([]map[string]inte
 
 // requireSuperAdmin ensures the user is a super-admin
 func (c *PermissionsPageController) requireSuperAdmin(ctx http.Context) error {
+	return requireRBACSuperAdmin(ctx, "permissions.read")
+}
+
+// requireRBACSuperAdmin is the super-admin (or legacy ADMIN) check guarding RBAC management,
+// shared by the permissions page and its JSON counterparts. A request made with an API key also
+// needs permission among the key's scopes.
+func requireRBACSuperAdmin(ctx http.Context, permission string) error {
 	permHelper := auth.GetPermissionHelper()
 	user, err := permHelper.RequireAuthentication(ctx)
 	if err != nil {
-		fmt.Printf("DEBUG: Authentication failed: %v\n", err)
 		return fmt.Errorf("authentication required: %w", err)
 	}
 
-	fmt.Printf("DEBUG: User loaded for permissions check: ID=%d, Email=%s, Role=%s\n", user.ID, user.Email, user.Role)
-	fmt.Printf("DEBUG: User roles count: %d\n", len(user.Roles))
-	for _, role := range user.Roles {
-		fmt.Printf("DEBUG: User has role: %s (active: %t)\n", role.Slug, role.IsActive)
-	}
-	fmt.Printf("DEBUG: IsSuperAdmin() result: %t\n", user.IsSuperAdminUser())
-	fmt.Printf("DEBUG: HasRole('super-admin') result: %t\n", user.HasRole("super-admin"))
-	fmt.Printf("DEBUG: Legacy role check (role == 'ADMIN'): %t\n", user.Role == "ADMIN")
-
 	if !user.IsSuperAdminUser() && user.Role != "ADMIN" {
+		facades.Log().With(map[string]interface{}{
+			"user_id": user.ID,
+			"role":    user.Role,
+		}).Debug("RBAC super-admin check denied")
 		return fmt.Errorf("super-admin access required")
 	}
+	if !auth.GetPermissionService().ScopeAllows(user, permission) {
+		return fmt.Errorf("API key is not scoped for %s", permission)
+	}
 
 	return nil
 }
@@ -345,37 +345,7 @@ func (c *PermissionsPageController) RequireAuthentication(ctx http.Context) erro
 }
 
 func (c *PermissionsPageController) BuildPermissionsMap(ctx http.Context, resourceType string) map[string]bool {
-	// For super-admin permission matrix, always return full access
-	user := c.GetCurrentUser(ctx)
-	if user == nil {
-		return map[string]bool{
-			"canCreate": false,
-			"canView":   false,
-			"canEdit":   false,
-			"canDelete": false,
-			"canManage": false,
-		}
-	}
-
-	// Check if user is super-admin
-	userModel, ok := user.(*models.User)
-	if ok && userModel.IsSuperAdminUser() {
-		return map[string]bool{
-			"canCreate": true,
-			"canView":   true,
-			"canEdit":   true,
-			"canDelete": true,
-			"canManage": true,
-		}
-	}
-
-	return map[string]bool{
-		"canCreate": false,
-		"canView":   false,
-		"canEdit":   false,
-		"canDelete": false,
-		"canManage": false,
-	}
+	return auth.GetPermissionHelper().BuildPermissionsMap(ctx, resourceType)
 }
 
 // RolePermissions GET /admin/roles/:id/permissions - Manage role permissions page
@@ -387,7 +357,6 @@ func (c *PermissionsPageController) RolePermissions(ctx http.Context) http.Respo
 
 	// Get role ID from route
 	roleID := ctx.Request().Route("id")
-	fmt.Printf("DEBUG: RolePermissions - requested role ID: %s\n", roleID)
 
 	var role models.Role
 	err := facades.Orm().Query().
@@ -395,14 +364,13 @@ func (c *PermissionsPageController) RolePermissions(ctx http.Context) http.Respo
 		First(&role)
 
 	if err != nil {
-		fmt.Printf("DEBUG: RolePermissions - role not found: %v\n", err)
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": "Role not found",
-		})
+		facades.Log().With(map[string]interface{}{
+			"role_id": roleID,
+			"error":   err.Error(),
+		}).Debug("RBAC role permissions page: role not found")
+		return c.NotFoundResponse(ctx, "Role not found")
 	}
 
-	fmt.Printf("DEBUG: RolePermissions - found role: ID=%d, Name=%s, Slug=%s\n", role.ID, role.Name, role.Slug)
-
 	// Get all services and actions for the permission matrix (using hardcoded auth constants)
 	services := auth.GetAllServiceRegistries()
 	actions := auth.GetAllCorePermissionActions()
@@ -446,23 +414,7 @@ func (c *PermissionsPageController) RolePermissions(ctx http.Context) http.Respo
 		Find(&rolePermissions)
 
 	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to load role permissions: " + err.Error(),
-		})
-	}
-	
-	fmt.Printf("DEBUG: RolePermissions - Found %d active permission assignments for role %d\n", len(rolePermissions), role.ID)
-	
-	// Debug: Also check ALL permissions (active and inactive) for this role
-	var allRolePermissions []models.RolePermission
-	facades.Orm().Query().
-		Model(&models.RolePermission{}).
-		Where("role_id = ?", role.ID).
-		Find(&allRolePermissions)
-	
-	fmt.Printf("DEBUG: RolePermissions - Total permission assignments for role %d: %d (including inactive)\n", role.ID, len(allRolePermissions))
-	for _, rp := range allRolePermissions {
-		fmt.Printf("DEBUG: RolePermission - RoleID: %d, PermID: %d, IsActive: %v\n", rp.RoleID, rp.PermissionID, rp.IsActive)
+		return c.InternalErrorResponse(ctx, "Failed to load role permissions: "+err.Error())
 	}
 
 	// Now load the permissions manually
@@ -491,28 +443,12 @@ func (c *PermissionsPageController) RolePermissions(ctx http.Context) http.Respo
 		permissionSlugs = append(permissionSlugs, perm.Slug)
 	}
 
-	// Additional debug logging
-	fmt.Printf("DEBUG: RolePermissions - Active permissions loaded: %d\n", len(permissions))
-	for _, perm := range permissions {
-		fmt.Printf("DEBUG: Permission - ID: %d, Slug: %s, IsActive: %v\n", perm.ID, perm.Slug, perm.IsActive)
-	}
-
-	fmt.Printf("DEBUG: RolePermissions - services count: %d\n", len(servicesData))
-	fmt.Printf("DEBUG: RolePermissions - actions count: %d\n", len(actionsData))
-	fmt.Printf("DEBUG: RolePermissions - permission slugs count: %d\n", len(permissionSlugs))
-	fmt.Printf("DEBUG: RolePermissions - permission slugs: %v\n", permissionSlugs)
-
-	// Log first service for debugging
-	if len(servicesData) > 0 {
-		fmt.Printf("DEBUG: RolePermissions - first service: %+v\n", servicesData[0])
-	}
-
-	// Log first action for debugging
-	if len(actionsData) > 0 {
-		fmt.Printf("DEBUG: RolePermissions - first action: %+v\n", actionsData[0])
-	}
+	facades.Log().With(map[string]interface{}{
+		"role_id":     role.ID,
+		"assignments": len(rolePermissions),
+		"permissions": permissionSlugs,
+	}).Debug("RBAC role permissions page loaded")
 
-	// Final debug output before rendering
 	roleData := map[string]interface{}{
 		"id":          role.ID,
 		"name":        role.Name,
@@ -522,9 +458,6 @@ func (c *PermissionsPageController) RolePermissions(ctx http.Context) http.Respo
 		"is_active":   role.IsActive,
 		"permissions": permissionSlugs,
 	}
-	
-	fmt.Printf("DEBUG: Final role data being sent to frontend: %+v\n", roleData)
-	fmt.Printf("DEBUG: Role permissions array: %v\n", permissionSlugs)
 
 	// Render Inertia page for permission management
 	return inertia.Render(ctx, "Permissions/RolePermissions", map[string]interface{}{
@@ -1,41 +1,91 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
 	"players/app/auth"
+	"players/app/contracts"
+	"players/app/http/resources"
 	"players/app/models"
+	"players/app/services"
 )
 
 // RolesController handles API endpoints for role management
 type RolesController struct {
+	*contracts.BaseCrudController
+	permissionsService *services.PermissionsService
+	roleService        *services.RoleService
+	userService        *services.UserService
 }
 
-// Index GET /api/roles - List all roles
+// NewRolesController creates a new roles controller
+func NewRolesController() *RolesController {
+	return &RolesController{
+		BaseCrudController: contracts.NewBaseCrudController("roles"),
+		permissionsService: services.NewPermissionsService(),
+		roleService:        services.NewRoleService(),
+		userService:        services.NewUserService(),
+	}
+}
+
+// Index GET /api/roles - Paginated list of active roles with their permissions; supports
+// search on name/slug and sort, like the books and users lists. include_inactive=true also
+// lists deleted roles so they can be restored
 func (c *RolesController) Index(ctx http.Context) http.Response {
-	// Check permissions
-	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequireServicePermission(ctx, auth.ServiceRoles, auth.PermissionRead)
+	req, err := c.ValidatePaginationRequest(ctx)
 	if err != nil {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Insufficient permissions",
+		return c.BadRequestResponse(ctx, "Invalid pagination parameters", map[string]interface{}{
+			"validation_error": err.Error(),
 		})
 	}
 
-	var roles []models.Role
-	err = facades.Orm().Query().
-		Where("is_active = ?", true).
-		With("Permissions").
-		Find(&roles)
+	includeInactive, ok := contracts.CoerceFilterValue(ctx.Request().Query("include_inactive", "false"), contracts.FilterTypeBool)
+	if !ok {
+		return c.BadRequestResponse(ctx, "include_inactive must be true or false", nil)
+	}
+	req.Filters["include_inactive"] = includeInactive
 
+	result, err := c.roleService.GetList(ctx.Context(), *req)
 	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to load roles",
-		})
+		return c.QueryFailedResponse(ctx, "Failed to retrieve roles", err)
+	}
+
+	return c.ConditionalResponse(ctx, c.BuildPaginatedResponse(result, req), "Roles retrieved successfully")
+}
+
+// Matrix GET /api/roles/matrix - Roles, grouped permissions, the role to permission ID matrix and stats,
+// the same data the permissions page renders
+func (c *RolesController) Matrix(ctx http.Context) http.Response {
+	if err := requireRBACSuperAdmin(ctx, "roles.read"); err != nil {
+		return c.ForbiddenResponse(ctx, "Super-admin access required")
+	}
+
+	matrix, err := c.permissionsService.GetPermissionMatrix()
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to load permission matrix: "+err.Error())
+	}
+
+	return ctx.Response().Json(http.StatusOK, matrix)
+}
+
+// Assignable GET /api/roles/assignable - Roles the current user may assign, i.e. those below
+// their highest role level (all roles for super admins)
+func (c *RolesController) Assignable(ctx http.Context) http.Response {
+	permHelper := auth.GetPermissionHelper()
+	user, err := permHelper.RequireAuthentication(ctx)
+	if err != nil {
+		return c.UnauthorizedResponse(ctx, "Authentication required")
+	}
+
+	roles, err := auth.GetPermissionService().GetAssignableRoles(user)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to load roles")
 	}
 
 	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
@@ -43,31 +93,97 @@ func (c *RolesController) Index(ctx http.Context) http.Response {
 	})
 }
 
-// Store POST /api/roles - Create a new role
-func (c *RolesController) Store(ctx http.Context) http.Response {
-	// Check permissions
-	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequireServicePermission(ctx, auth.ServiceRoles, auth.PermissionCreate)
+// AssignUsers POST /api/roles/{id}/users - Assign the role to many users at once.
+// Expects {"user_ids": [...]} and reports which users were assigned, skipped, denied or not found.
+func (c *RolesController) AssignUsers(ctx http.Context) http.Response {
+	// roles.assign is enforced by the route's RequirePermission middleware
+	user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
+
+	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid role ID", nil)
+	}
+
+	var request struct {
+		UserIDs []uint `form:"user_ids" json:"user_ids"`
+	}
+	if err := ctx.Request().Bind(&request); err != nil || len(request.UserIDs) == 0 {
+		return c.BadRequestResponse(ctx, "user_ids must be a non-empty array of user IDs", nil)
+	}
+
+	result, err := auth.GetPermissionService().AssignRoleToUsers(uint(roleID), request.UserIDs, user)
+	switch {
+	case errors.Is(err, auth.ErrRoleNotFound):
+		return c.NotFoundResponse(ctx, "Role not found")
+	case errors.Is(err, auth.ErrRoleAboveAssigner):
+		return c.ForbiddenResponse(ctx, "Cannot assign a role at or above your own")
+	case err != nil:
+		return c.InternalErrorResponse(ctx, "Failed to assign role: "+err.Error())
+	}
+
+	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
+		"message":         fmt.Sprintf("Role assigned to %d users, %d skipped", len(result.Assigned), len(result.Skipped)),
+		"assigned_count":  len(result.Assigned),
+		"skipped_count":   len(result.Skipped),
+		"denied_count":    len(result.Denied),
+		"not_found_count": len(result.NotFound),
+		"users":           result,
+	})
+}
+
+// Users GET /api/roles/{id}/users - Paginated list of users holding the role, i.e. the users that
+// must be reassigned before Destroy will delete it. Supports the usual search and sort parameters.
+func (c *RolesController) Users(ctx http.Context) http.Response {
+	roleID, err := c.ValidateID(ctx, "id")
 	if err != nil {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Insufficient permissions",
+		return c.BadRequestResponse(ctx, "Invalid role ID", nil)
+	}
+
+	var role models.Role
+	err = facades.Orm().Query().
+		Where("id = ? AND is_active = ?", roleID, true).
+		First(&role)
+
+	if err != nil || role.ID == 0 {
+		return c.NotFoundResponse(ctx, "Role not found")
+	}
+
+	req, err := c.ValidatePaginationRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid pagination parameters", map[string]interface{}{
+			"validation_error": err.Error(),
 		})
 	}
 
+	result, err := c.userService.GetListAdvanced(ctx.Context(), *req, map[string]interface{}{"role": role.Slug})
+	if err != nil {
+		return c.QueryFailedResponse(ctx, "Failed to retrieve role users", err)
+	}
+
+	// Users are serialized through the user resource, the same shape the users list returns
+	users := make([]interface{}, len(result.Data))
+	for i, record := range result.Data {
+		users[i] = resources.User(record)
+	}
+
+	response := c.BuildPaginatedResponse(result, req)
+	response["data"] = users
+	response["role"] = role
+	return c.ConditionalResponse(ctx, response, "Role users retrieved successfully")
+}
+
+// Store POST /api/roles - Create a new role
+func (c *RolesController) Store(ctx http.Context) http.Response {
 	// Parse request data
 	var requestData map[string]interface{}
 	if err := ctx.Request().Bind(&requestData); err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request data",
-		})
+		return c.BadRequestResponse(ctx, "Invalid request data", nil)
 	}
 
 	// Validate required fields
 	name, nameOk := requestData["name"].(string)
 	if !nameOk || strings.TrimSpace(name) == "" {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Role name is required",
-		})
+		return c.BadRequestResponse(ctx, "Role name is required", nil)
 	}
 
 	description, _ := requestData["description"].(string)
@@ -81,18 +197,37 @@ func (c *RolesController) Store(ctx http.Context) http.Response {
 
 	// Validate slug is not empty
 	if slug == "" {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Role name cannot be empty",
-		})
+		return c.BadRequestResponse(ctx, "Role name cannot be empty", nil)
 	}
 
 	// Check if role with this slug already exists (only check non-empty slugs)
 	var existingRole models.Role
-	err = facades.Orm().Query().Where("slug = ?", slug).First(&existingRole)
+	err := facades.Orm().Query().Where("slug = ?", slug).First(&existingRole)
 	if err == nil && existingRole.ID > 0 && existingRole.Slug != "" {
-		return ctx.Response().Json(http.StatusConflict, map[string]string{
-			"error": "A role with this name already exists",
-		})
+		return c.ConflictResponse(ctx, "A role with this name already exists", nil)
+	}
+
+	// Resolve requested permissions up front so a denied grant creates nothing
+	user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
+	var grants []models.Permission
+	if permissions, ok := requestData["permissions"].([]interface{}); ok && len(permissions) > 0 {
+		slugs := make([]string, 0, len(permissions))
+		for _, p := range permissions {
+			if permSlug, ok := p.(string); ok {
+				slugs = append(slugs, permSlug)
+			}
+		}
+		if len(slugs) > 0 {
+			facades.Orm().Query().
+				Where("slug IN ? AND is_active = ?", slugs, true).
+				Find(&grants)
+		}
+
+		if denied := auth.GetPermissionService().UndelegablePermissions(user, grants); len(denied) > 0 {
+			return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, "You cannot delegate some of the requested permissions", map[string]interface{}{
+				"permissions": denied,
+			})
+		}
 	}
 
 	// Create new role
@@ -106,57 +241,147 @@ func (c *RolesController) Store(ctx http.Context) http.Response {
 
 	err = facades.Orm().Query().Create(&role)
 	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create role",
-		})
+		return c.InternalErrorResponse(ctx, "Failed to create role")
 	}
 
-	// Handle permission assignments if provided
-	if permissions, ok := requestData["permissions"].([]interface{}); ok && len(permissions) > 0 {
-		for _, p := range permissions {
-			if permSlug, ok := p.(string); ok {
-				// Find the permission by slug
-				var permission models.Permission
-				err := facades.Orm().Query().
-					Where("slug = ? AND is_active = ?", permSlug, true).
-					First(&permission)
-
-				if err == nil {
-					// Create role-permission assignment
-					rolePermission := models.RolePermission{
-						RoleID:       role.ID,
-						PermissionID: permission.ID,
-						IsActive:     true,
-					}
-					facades.Orm().Query().Create(&rolePermission)
-				}
-			}
+	// Grant the resolved permissions
+	for _, permission := range grants {
+		rolePermission := models.RolePermission{
+			RoleID:       role.ID,
+			PermissionID: permission.ID,
+			GrantedAt:    time.Now(),
+			IsActive:     true,
 		}
+		if user != nil {
+			rolePermission.GrantedByID = &user.ID
+		}
+		facades.Orm().Query().Create(&rolePermission)
 	}
 
+	// Drop cached grants so the change applies on the next permission check
+	auth.GetPermissionService().ClearCache()
+
 	return ctx.Response().Json(http.StatusCreated, map[string]interface{}{
 		"message": "Role created successfully",
 		"role":    role,
 	})
 }
 
-// Show GET /api/roles/{id} - Get a specific role
-func (c *RolesController) Show(ctx http.Context) http.Response {
-	// Check permissions
-	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequireServicePermission(ctx, auth.ServiceRoles, auth.PermissionRead)
+// Clone POST /api/roles/{id}/clone - Create a new role from an existing one
+func (c *RolesController) Clone(ctx http.Context) http.Response {
+	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
 	if err != nil {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Insufficient permissions",
+		return c.BadRequestResponse(ctx, "Invalid role ID", nil)
+	}
+
+	var source models.Role
+	err = facades.Orm().Query().
+		Where("id = ? AND is_active = ?", roleID, true).
+		First(&source)
+
+	if err != nil || source.ID == 0 {
+		return c.NotFoundResponse(ctx, "Role not found")
+	}
+
+	var requestData map[string]interface{}
+	if err := ctx.Request().Bind(&requestData); err != nil {
+		return c.BadRequestResponse(ctx, "Invalid request data", nil)
+	}
+
+	name, _ := requestData["name"].(string)
+	name = strings.TrimSpace(name)
+	slug := strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	if slug == "" {
+		return c.BadRequestResponse(ctx, "Role name is required", nil)
+	}
+
+	var existingRole models.Role
+	err = facades.Orm().Query().Where("slug = ?", slug).First(&existingRole)
+	if err == nil && existingRole.ID > 0 {
+		return c.ConflictResponse(ctx, "A role with this name already exists", nil)
+	}
+
+	// Copy the source role's active grants
+	var rolePermissions []models.RolePermission
+	facades.Orm().Query().
+		Where("role_id = ? AND is_active = ?", source.ID, true).
+		With("Permission").
+		Find(&rolePermissions)
+
+	grants := make([]models.Permission, 0, len(rolePermissions))
+	permissionIDs := make([]uint, 0, len(rolePermissions))
+	for _, rp := range rolePermissions {
+		if rp.Permission.ID > 0 {
+			grants = append(grants, rp.Permission)
+			permissionIDs = append(permissionIDs, rp.PermissionID)
+		}
+	}
+
+	// Cloning grants every copied permission, so the delegation rule applies
+	user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
+	if denied := auth.GetPermissionService().UndelegablePermissions(user, grants); len(denied) > 0 {
+		return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, "You cannot delegate some of the source role's permissions", map[string]interface{}{
+			"permissions": denied,
 		})
 	}
 
+	description, ok := requestData["description"].(string)
+	if !ok {
+		description = strings.TrimSpace("Copy of " + source.Name + ". " + source.Description)
+	}
+
+	// Anyone but a super admin gets a copy that sits below their own highest role, so cloning
+	// can't mint a role at or above the caller's level
+	level := source.Level
+	if user == nil || !user.IsSuperAdminUser() {
+		ceiling := 0
+		if user != nil {
+			if highest := user.GetHighestRole(); highest != nil {
+				ceiling = highest.Level - 1
+			}
+		}
+		if level > ceiling {
+			level = ceiling
+		}
+	}
+
+	role := models.Role{
+		Name:        name,
+		Slug:        slug,
+		Description: description,
+		Level:       level,
+		IsActive:    true,
+	}
+
+	if err := facades.Orm().Query().Create(&role); err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to create role")
+	}
+
+	// The service copies the grants in one transaction and clears the permission cache
+	if err := c.permissionsService.SyncRolePermissions(role.ID, permissionIDs); err != nil {
+		if _, delErr := facades.Orm().Query().ForceDelete(&role); delErr != nil {
+			facades.Log().Error("Failed to remove role after clone failure: " + delErr.Error())
+		}
+		return c.InternalErrorResponse(ctx, "Failed to copy permissions: "+err.Error())
+	}
+
+	facades.Orm().Query().
+		Where("id = ?", role.ID).
+		With("Permissions").
+		First(&role)
+
+	return ctx.Response().Json(http.StatusCreated, map[string]interface{}{
+		"message": fmt.Sprintf("Role '%s' cloned from '%s'", role.Name, source.Name),
+		"role":    role,
+	})
+}
+
+// Show GET /api/roles/{id} - Get a specific role
+func (c *RolesController) Show(ctx http.Context) http.Response {
 	// Get role ID from URL
 	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
 	if err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid role ID",
-		})
+		return c.BadRequestResponse(ctx, "Invalid role ID", nil)
 	}
 
 	var role models.Role
@@ -165,10 +390,8 @@ func (c *RolesController) Show(ctx http.Context) http.Response {
 		With("Permissions").
 		First(&role)
 
-	if err != nil {
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": "Role not found",
-		})
+	if err != nil || role.ID == 0 {
+		return c.NotFoundResponse(ctx, "Role not found")
 	}
 
 	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
@@ -178,21 +401,10 @@ func (c *RolesController) Show(ctx http.Context) http.Response {
 
 // Update PUT /api/roles/{id} - Update a role
 func (c *RolesController) Update(ctx http.Context) http.Response {
-	// Check permissions
-	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequireServicePermission(ctx, auth.ServiceRoles, auth.PermissionUpdate)
-	if err != nil {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Insufficient permissions",
-		})
-	}
-
 	// Get role ID from URL
 	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
 	if err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid role ID",
-		})
+		return c.BadRequestResponse(ctx, "Invalid role ID", nil)
 	}
 
 	// Find existing role
@@ -201,18 +413,14 @@ func (c *RolesController) Update(ctx http.Context) http.Response {
 		Where("id = ? AND is_active = ?", roleID, true).
 		First(&role)
 
-	if err != nil {
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": "Role not found",
-		})
+	if err != nil || role.ID == 0 {
+		return c.NotFoundResponse(ctx, "Role not found")
 	}
 
 	// Parse request data
 	var requestData map[string]interface{}
 	if err := ctx.Request().Bind(&requestData); err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request data",
-		})
+		return c.BadRequestResponse(ctx, "Invalid request data", nil)
 	}
 
 	// Update fields if provided
@@ -229,16 +437,11 @@ func (c *RolesController) Update(ctx http.Context) http.Response {
 		role.Level = int(levelFloat)
 	}
 
-	// Save changes
-	err = facades.Orm().Query().Save(&role)
-	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to update role",
-		})
-	}
-
-	// Handle permission updates if provided
-	if permissions, ok := requestData["permissions"].([]interface{}); ok {
+	// Work out permission changes before saving so a denied change leaves the role untouched
+	var permsToAdd []models.Permission
+	var permsToRemove []models.Permission
+	permissions, syncPermissions := requestData["permissions"].([]interface{})
+	if syncPermissions {
 		// Get current role permissions
 		var currentPermissions []models.Permission
 		facades.Orm().Query().
@@ -277,37 +480,55 @@ func (c *RolesController) Update(ctx http.Context) http.Response {
 			}
 		}
 
-		// Add new permissions
 		if len(toAdd) > 0 {
-			var permsToAdd []models.Permission
 			facades.Orm().Query().
 				Where("slug IN ? AND is_active = ?", toAdd, true).
 				Find(&permsToAdd)
-
-			if len(permsToAdd) > 0 {
-				facades.Orm().Query().
-					Model(&role).
-					Association("Permissions").
-					Append(&permsToAdd)
-			}
 		}
-
-		// Remove permissions
 		if len(toRemove) > 0 {
-			var permsToRemove []models.Permission
 			facades.Orm().Query().
 				Where("slug IN ?", toRemove).
 				Find(&permsToRemove)
+		}
 
-			if len(permsToRemove) > 0 {
-				facades.Orm().Query().
-					Model(&role).
-					Association("Permissions").
-					Delete(&permsToRemove)
-			}
+		// Non-super-admins may only grant or revoke permissions they can delegate
+		user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
+		changed := append(append([]models.Permission{}, permsToAdd...), permsToRemove...)
+		if denied := auth.GetPermissionService().UndelegablePermissions(user, changed); len(denied) > 0 {
+			return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, "You cannot delegate some of the requested permissions", map[string]interface{}{
+				"permissions": denied,
+			})
 		}
 	}
 
+	// Save changes
+	err = facades.Orm().Query().Save(&role)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to update role")
+	}
+
+	// Apply permission updates if provided
+	if syncPermissions {
+		// Add new permissions
+		if len(permsToAdd) > 0 {
+			facades.Orm().Query().
+				Model(&role).
+				Association("Permissions").
+				Append(&permsToAdd)
+		}
+
+		// Remove permissions
+		if len(permsToRemove) > 0 {
+			facades.Orm().Query().
+				Model(&role).
+				Association("Permissions").
+				Delete(&permsToRemove)
+		}
+	}
+
+	// Drop cached grants so the change applies on the next permission check
+	auth.GetPermissionService().ClearCache()
+
 	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
 		"message": "Role updated successfully",
 		"role":    role,
@@ -316,21 +537,10 @@ func (c *RolesController) Update(ctx http.Context) http.Response {
 
 // Destroy DELETE /api/roles/{id} - Delete a role
 func (c *RolesController) Destroy(ctx http.Context) http.Response {
-	// Check permissions
-	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequireServicePermission(ctx, auth.ServiceRoles, auth.PermissionDelete)
-	if err != nil {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Insufficient permissions",
-		})
-	}
-
 	// Get role ID from URL
 	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
 	if err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid role ID",
-		})
+		return c.BadRequestResponse(ctx, "Invalid role ID", nil)
 	}
 
 	// Find existing role
@@ -339,10 +549,8 @@ func (c *RolesController) Destroy(ctx http.Context) http.Response {
 		Where("id = ? AND is_active = ?", roleID, true).
 		First(&role)
 
-	if err != nil {
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": "Role not found",
-		})
+	if err != nil || role.ID == 0 {
+		return c.NotFoundResponse(ctx, "Role not found")
 	}
 
 	// Check if role has users assigned
@@ -352,8 +560,9 @@ func (c *RolesController) Destroy(ctx http.Context) http.Response {
 		Count(&userCount)
 
 	if userCount > 0 {
-		return ctx.Response().Json(http.StatusConflict, map[string]string{
-			"error": fmt.Sprintf("Cannot delete role: %d users are assigned to this role", userCount),
+		return c.ConflictResponse(ctx, fmt.Sprintf("Cannot delete role: %d users are assigned to this role", userCount), map[string]interface{}{
+			"user_count": userCount,
+			"users_url":  fmt.Sprintf("/api/roles/%d/users", role.ID),
 		})
 	}
 
@@ -361,49 +570,63 @@ func (c *RolesController) Destroy(ctx http.Context) http.Response {
 	role.IsActive = false
 	err = facades.Orm().Query().Save(&role)
 	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to delete role",
-		})
+		return c.InternalErrorResponse(ctx, "Failed to delete role")
 	}
 
+	// Drop cached grants so the change applies on the next permission check
+	auth.GetPermissionService().ClearCache()
+
 	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
 		"message": "Role deleted successfully",
 	})
 }
 
-// contains checks if a slice contains a string
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
+// Restore POST /api/roles/{id}/restore - Reactivate a deleted role; its permissions were kept
+// when it was deleted, so it comes back with the same grants
+func (c *RolesController) Restore(ctx http.Context) http.Response {
+	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid role ID", nil)
 	}
-	return false
+
+	var role models.Role
+	err = facades.Orm().Query().
+		Where("id = ? AND is_active = ?", roleID, false).
+		First(&role)
+
+	if err != nil || role.ID == 0 {
+		return c.NotFoundResponse(ctx, "Deleted role not found")
+	}
+
+	role.IsActive = true
+	err = facades.Orm().Query().Save(&role)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to restore role")
+	}
+
+	// Drop cached grants so the role's permissions apply again on the next check
+	auth.GetPermissionService().ClearCache()
+
+	facades.Orm().Query().With("Permissions").Where("id = ?", role.ID).First(&role)
+
+	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
+		"message": "Role restored successfully",
+		"role":    role,
+	})
 }
 
 // UpdatePermissions PUT /api/roles/{id}/permissions - Update role permissions
 func (c *RolesController) UpdatePermissions(ctx http.Context) http.Response {
 	// Check permissions - require super admin for permission management
-	permHelper := auth.GetPermissionHelper()
-	user, err := permHelper.RequireAuthentication(ctx)
-	if err != nil {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Authentication required",
-		})
-	}
-
-	if !user.IsSuperAdminUser() && user.Role != "ADMIN" {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Super admin access required",
-		})
+	if err := requireRBACSuperAdmin(ctx, "permissions.update"); err != nil {
+		return c.ForbiddenResponse(ctx, "Super admin access required")
 	}
+	user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
 
 	// Get role ID from URL
 	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
 	if err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid role ID",
-		})
+		return c.BadRequestResponse(ctx, "Invalid role ID", nil)
 	}
 
 	// Find existing role
@@ -411,44 +634,30 @@ func (c *RolesController) UpdatePermissions(ctx http.Context) http.Response {
 	err = facades.Orm().Query().
 		Where("id = ? AND is_active = ?", roleID, true).
 		First(&role)
-	
-	// Load ALL role_permissions for this role, not just active ones
+
+	// Load the role's current grants
 	var rolePermissions []models.RolePermission
 	facades.Orm().Query().
 		Where("role_id = ?", roleID).
 		With("Permission").
 		Find(&rolePermissions)
-	
-	fmt.Printf("DEBUG: Found %d role_permissions records for role %d\n", len(rolePermissions), roleID)
-	for _, rp := range rolePermissions {
-		fmt.Printf("DEBUG: RolePermission ID=%d, PermissionID=%d, IsActive=%v\n", rp.ID, rp.PermissionID, rp.IsActive)
-	}
 
-	if err != nil {
-		return ctx.Response().Json(http.StatusNotFound, map[string]string{
-			"error": "Role not found",
-		})
+	if err != nil || role.ID == 0 {
+		return c.NotFoundResponse(ctx, "Role not found")
 	}
 
 	// Parse request data
 	var requestData map[string]interface{}
 	if err := ctx.Request().Bind(&requestData); err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request data",
-		})
+		return c.BadRequestResponse(ctx, "Invalid request data", nil)
 	}
 
 	// Get permissions from request
 	permissions, ok := requestData["permissions"].([]interface{})
 	if !ok {
-		return ctx.Response().Json(http.StatusBadRequest, map[string]string{
-			"error": "Permissions array is required",
-		})
+		return c.BadRequestResponse(ctx, "Permissions array is required", nil)
 	}
 
-	fmt.Printf("DEBUG: UpdatePermissions - Role ID: %d, Role Name: %s\n", roleID, role.Name)
-	fmt.Printf("DEBUG: UpdatePermissions - Received permissions: %v\n", permissions)
-
 	// Convert to string array
 	permissionSlugs := make([]string, 0)
 	for _, p := range permissions {
@@ -456,157 +665,72 @@ func (c *RolesController) UpdatePermissions(ctx http.Context) http.Response {
 			permissionSlugs = append(permissionSlugs, strings.TrimSpace(slug))
 		}
 	}
-	
-	fmt.Printf("DEBUG: UpdatePermissions - Permission slugs: %v\n", permissionSlugs)
 
-	// Get current active permissions from role_permissions table
-	currentPermissionSlugs := make([]string, 0)
-	for _, rp := range rolePermissions {
-		if rp.IsActive && rp.Permission.ID > 0 {
-			currentPermissionSlugs = append(currentPermissionSlugs, rp.Permission.Slug)
-			fmt.Printf("DEBUG: Active permission: %s\n", rp.Permission.Slug)
+	// Resolve the requested slugs to active permissions; unknown slugs are ignored
+	var requestedPerms []models.Permission
+	if len(permissionSlugs) > 0 {
+		if err := facades.Orm().Query().Where("slug IN ? AND is_active = ?", permissionSlugs, true).Find(&requestedPerms); err != nil {
+			return c.InternalErrorResponse(ctx, "Failed to load permissions: "+err.Error())
 		}
 	}
-	fmt.Printf("DEBUG: Current active permission slugs: %v\n", currentPermissionSlugs)
 
-	// Create maps for efficient lookup
-	currentPermMap := make(map[string]bool)
-	for _, slug := range currentPermissionSlugs {
-		currentPermMap[slug] = true
+	permissionIDs := make([]uint, 0, len(requestedPerms))
+	newPermMap := make(map[string]bool)
+	for _, perm := range requestedPerms {
+		permissionIDs = append(permissionIDs, perm.ID)
+		newPermMap[perm.Slug] = true
 	}
 
-	newPermMap := make(map[string]bool)
-	for _, slug := range permissionSlugs {
-		newPermMap[slug] = true
+	// Current grants, used only to report what changed
+	currentPermMap := make(map[string]bool)
+	for _, rp := range rolePermissions {
+		if rp.IsActive && rp.Permission.ID > 0 {
+			currentPermMap[rp.Permission.Slug] = true
+		}
 	}
 
-	// Find permissions to add and remove
 	var toAdd []string
 	var toRemove []string
-
-	// Find permissions to add
 	for slug := range newPermMap {
 		if !currentPermMap[slug] {
 			toAdd = append(toAdd, slug)
 		}
 	}
-
-	// Find permissions to remove
 	for slug := range currentPermMap {
 		if !newPermMap[slug] {
 			toRemove = append(toRemove, slug)
 		}
 	}
 
-	// Remove old permission assignments
-	if len(toRemove) > 0 {
-		// Get permission IDs to remove
-		var permsToRemove []models.Permission
-		facades.Orm().Query().
-			Where("slug IN ? AND is_active = ?", toRemove, true).
-			Find(&permsToRemove)
-
-		if len(permsToRemove) > 0 {
-			for _, perm := range permsToRemove {
-				// Update role_permission records to inactive instead of deleting
-				_, updateErr := facades.Orm().Query().
-					Model(&models.RolePermission{}).
-					Where("role_id = ? AND permission_id = ?", roleID, perm.ID).
-					Update("is_active", false)
-				
-				if updateErr != nil {
-					fmt.Printf("DEBUG: Failed to remove permission %s for role %d: %v\n", perm.Slug, roleID, updateErr)
-				} else {
-					fmt.Printf("DEBUG: Removed permission %s for role %d\n", perm.Slug, roleID)
-				}
-			}
+	// Admins who are not super admins may only change grants they can delegate
+	changed := make([]models.Permission, 0, len(toAdd)+len(toRemove))
+	for _, perm := range requestedPerms {
+		if !currentPermMap[perm.Slug] {
+			changed = append(changed, perm)
 		}
 	}
-
-	// Add new permission assignments
-	if len(toAdd) > 0 {
-		fmt.Printf("DEBUG: UpdatePermissions - Permissions to add: %v\n", toAdd)
-		
-		// Debug what we currently have
-		fmt.Printf("DEBUG: Current permission slugs from role: %v\n", currentPermissionSlugs)
-		fmt.Printf("DEBUG: New permission slugs requested: %v\n", permissionSlugs)
-		
-		// Get permission records to add
-		var permsToAdd []models.Permission
-		
-		// Debug: First check what permissions exist in DB
-		var allPerms []models.Permission
-		facades.Orm().Query().Find(&allPerms)
-		fmt.Printf("DEBUG: Total permissions in DB: %d\n", len(allPerms))
-		
-		// List all permission slugs in DB
-		dbPermSlugs := make([]string, 0)
-		for _, p := range allPerms {
-			dbPermSlugs = append(dbPermSlugs, p.Slug)
-			if contains(toAdd, p.Slug) {
-				fmt.Printf("DEBUG: Permission to add found in DB: %s (ID: %d, IsActive: %v)\n", p.Slug, p.ID, p.IsActive)
-			}
-		}
-		fmt.Printf("DEBUG: All permission slugs in DB: %v\n", dbPermSlugs)
-		
-		err := facades.Orm().Query().
-			Where("slug IN ? AND is_active = ?", toAdd, true).
-			Find(&permsToAdd)
-		
-		if err != nil {
-			fmt.Printf("DEBUG: UpdatePermissions - Error finding permissions to add: %v\n", err)
-		}
-		
-		fmt.Printf("DEBUG: UpdatePermissions - Found %d permissions in database for %d slugs\n", len(permsToAdd), len(toAdd))
-		fmt.Printf("DEBUG: UpdatePermissions - Looking for slugs: %v\n", toAdd)
-		for _, perm := range permsToAdd {
-			fmt.Printf("DEBUG: UpdatePermissions - Found permission: ID=%d, Slug=%s, IsActive=%v\n", perm.ID, perm.Slug, perm.IsActive)
+	for _, rp := range rolePermissions {
+		if rp.Permission.ID > 0 && !newPermMap[rp.Permission.Slug] {
+			changed = append(changed, rp.Permission)
 		}
+	}
+	if denied := auth.GetPermissionService().UndelegablePermissions(user, changed); len(denied) > 0 {
+		return contracts.ErrorResponse(ctx, http.StatusForbidden, contracts.ErrorCodePermissionDenied, "You cannot delegate some of the requested permissions", map[string]interface{}{
+			"permissions": denied,
+		})
+	}
 
-		if len(permsToAdd) > 0 {
-			for _, perm := range permsToAdd {
-				// Check if role_permission record already exists (maybe inactive)
-				var existingRP models.RolePermission
-				err := facades.Orm().Query().
-					Where("role_id = ? AND permission_id = ?", roleID, perm.ID).
-					First(&existingRP)
-				
-				if err == nil && existingRP.ID > 0 {
-					// Record exists, update it to active
-					fmt.Printf("DEBUG: Found existing RolePermission record ID=%d for permission %s (IsActive=%v)\n", existingRP.ID, perm.Slug, existingRP.IsActive)
-					
-					// Use direct update instead of Save
-					updateResult, updateErr := facades.Orm().Query().
-						Model(&models.RolePermission{}).
-						Where("id = ?", existingRP.ID).
-						Update("is_active", true)
-					
-					if updateErr != nil {
-						fmt.Printf("DEBUG: Failed to update permission %s to active for role %d: %v\n", perm.Slug, roleID, updateErr)
-					} else {
-						// Verify the update
-						var verifyRP models.RolePermission
-						facades.Orm().Query().Where("id = ?", existingRP.ID).First(&verifyRP)
-						fmt.Printf("DEBUG: Updated permission %s to active for role %d (ID: %d, IsActive after save: %v, rows affected: %d)\n", perm.Slug, roleID, existingRP.ID, verifyRP.IsActive, updateResult.RowsAffected)
-					}
-				} else {
-					// Create new role_permission record
-					rolePermission := models.RolePermission{
-						RoleID:       uint(roleID),
-						PermissionID: perm.ID,
-						IsActive:     true,
-					}
-					createErr := facades.Orm().Query().Create(&rolePermission)
-					if createErr != nil {
-						fmt.Printf("DEBUG: Failed to create permission %s for role %d: %v\n", perm.Slug, roleID, createErr)
-					} else {
-						fmt.Printf("DEBUG: Created permission %s for role %d\n", perm.Slug, roleID)
-					}
-				}
-			}
-		}
+	// The service replaces the role's grants in one transaction and clears the permission cache
+	if err := c.permissionsService.SyncRolePermissions(uint(roleID), permissionIDs); err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to update permissions: "+err.Error())
 	}
 
+	facades.Log().With(map[string]interface{}{
+		"role_id": roleID,
+		"added":   toAdd,
+		"removed": toRemove,
+	}).Debug("RBAC role permissions updated")
+
 	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
 		"message": fmt.Sprintf("Permissions updated successfully. Added: %d, Removed: %d", len(toAdd), len(toRemove)),
 		"added":   len(toAdd),
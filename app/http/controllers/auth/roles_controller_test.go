@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	goravelgin "github.com/goravel/gin"
+
+	"players/app/auth"
+	"players/app/models"
+	"players/tests/testdb"
+)
+
+// TestCloneKeepsTheCopyBelowTheCaller clones the super-admin role: an admin's copy is capped below
+// their own level, while a super admin's copy keeps the source level.
+func TestCloneKeepsTheCopyBelowTheCaller(t *testing.T) {
+	tests := []struct {
+		name       string
+		superAdmin bool
+		wantLevel  int
+	}{
+		{"admin", false, 79},
+		{"super admin", true, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testdb.Open(t, &models.Permission{}, &models.Role{}, &models.RolePermission{}, &models.User{}, &models.UserRole{})
+			source := models.Role{Name: "Super Admin", Slug: "super-admin", Level: 100, IsActive: true}
+			adminRole := models.Role{Name: "Admin", Slug: "admin", Level: 80, IsActive: true}
+			for _, role := range []*models.Role{&source, &adminRole} {
+				if err := db.Create(role).Error; err != nil {
+					t.Fatalf("create role: %v", err)
+				}
+			}
+			caller := &models.User{Email: "admin@example.com", IsActive: true, IsSuperAdmin: tt.superAdmin, Roles: []models.Role{adminRole}}
+			caller.ID = 1
+
+			recorder := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(recorder)
+			ginCtx.Request = httptest.NewRequest(nethttp.MethodPost, "/api/roles/1/clone", strings.NewReader(`{"name":"Copy"}`))
+			ginCtx.Request.Header.Set("Content-Type", "application/json")
+			ginCtx.Params = gin.Params{{Key: "id", Value: "1"}}
+			ctx := goravelgin.NewContext(ginCtx)
+			ctx.WithValue(auth.ApiKeyContextKey, &models.ApiKey{UserID: caller.ID, Scopes: models.JSON(`["roles.create"]`), User: caller})
+
+			if err := NewRolesController().Clone(ctx).Render(); err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if recorder.Code != nethttp.StatusCreated {
+				t.Fatalf("Clone = %d, want %d: %s", recorder.Code, nethttp.StatusCreated, recorder.Body)
+			}
+			var body struct {
+				Role models.Role `json:"role"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Role.Level != tt.wantLevel {
+				t.Errorf("cloned role level = %d, want %d", body.Role.Level, tt.wantLevel)
+			}
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
 	"players/app/auth"
 	"players/app/contracts"
 	"players/app/helpers"
 	"players/app/http/requests"
+	"players/app/http/resources"
+	"players/app/models"
 	"players/app/services"
 )
 
@@ -15,8 +19,9 @@ import (
 // Only accessible by super admins
 type UserController struct {
 	*contracts.BaseCrudController
-	userService *services.UserService
-	authHelper  contracts.AuthHelper
+	userService  *services.UserService
+	auditService *services.AuditService
+	authHelper   contracts.AuthHelper
 }
 
 // NewUserController creates a new user controller that implements all contracts
@@ -24,30 +29,37 @@ func NewUserController() *UserController {
 	controller := &UserController{
 		BaseCrudController: contracts.NewBaseCrudController("user"),
 		userService:        services.NewUserService(),
+		auditService:       services.NewAuditService(),
 		authHelper:         helpers.NewAuthHelper(),
 	}
 
+	controller.SetResourceTransformer(resources.User)
+
 	// Register controller with validation
 	contracts.MustRegisterCrudController("users", controller)
 
 	return controller
 }
 
-// checkSuperAdmin verifies if the current user is a super admin
-func (c *UserController) checkSuperAdmin(ctx http.Context) error {
+// checkSuperAdmin verifies if the current user is a super admin. A request made with an API key
+// also needs permission among the key's scopes.
+func (c *UserController) checkSuperAdmin(ctx http.Context, permission string) error {
 	permHelper := auth.GetPermissionHelper()
 	user := permHelper.GetAuthenticatedUser(ctx)
 	if user == nil || !user.IsSuperAdmin {
 		return fmt.Errorf("super admin access required")
 	}
+	if !auth.GetPermissionService().ScopeAllows(user, permission) {
+		return fmt.Errorf("API key is not scoped for %s", permission)
+	}
 	return nil
 }
 
 // Index GET /users - Implements CrudControllerContract
 func (c *UserController) Index(ctx http.Context) http.Response {
 	// Check super admin access
-	if err := c.checkSuperAdmin(ctx); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: Super admin privileges required")
+	if err := c.checkSuperAdmin(ctx, "users.viewAny"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
 	}
 
 	// Validate pagination request using contract
@@ -58,22 +70,30 @@ func (c *UserController) Index(ctx http.Context) http.Response {
 		})
 	}
 
-	// Get users using service
-	result, err := c.userService.GetList(*req)
+	// Get users using service; range bounds such as created_at_from narrow the list
+	var result *contracts.PaginatedResult
+	if filters := c.RangeFilterParams(ctx, nil); len(filters) > 0 {
+		result, err = c.userService.GetListAdvanced(ctx.Context(), *req, filters)
+	} else {
+		result, err = c.userService.GetList(ctx.Context(), *req)
+	}
 	if err != nil {
-		return c.InternalErrorResponse(ctx, "Failed to retrieve users: "+err.Error())
+		if _, ok := err.(*contracts.RangeFilterError); ok {
+			return c.BadRequestResponse(ctx, err.Error(), nil)
+		}
+		return c.QueryFailedResponse(ctx, "Failed to retrieve users", err)
 	}
 
 	// Build standardized paginated response
 	response := c.BuildPaginatedResponse(result, req)
-	return c.SuccessResponse(ctx, response, "Users retrieved successfully")
+	return c.ConditionalResponse(ctx, response, "Users retrieved successfully")
 }
 
 // Show GET /users/{id} - Implements CrudControllerContract
 func (c *UserController) Show(ctx http.Context) http.Response {
 	// Check super admin access
-	if err := c.checkSuperAdmin(ctx); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: Super admin privileges required")
+	if err := c.checkSuperAdmin(ctx, "users.view"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
 	}
 
 	// Validate ID parameter using contract
@@ -90,32 +110,78 @@ func (c *UserController) Show(ctx http.Context) http.Response {
 		return c.ResourceNotFoundResponse(ctx, "user", id)
 	}
 
-	return c.SuccessResponse(ctx, user, "User details retrieved successfully")
+	return c.ConditionalResponse(ctx, c.TransformResource(user), "User details retrieved successfully")
+}
+
+// Permissions GET /users/{id}/permissions - Effective permissions and the roles granting them
+func (c *UserController) Permissions(ctx http.Context) http.Response {
+	// Check super admin access
+	if err := c.checkSuperAdmin(ctx, "users.view"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
+	}
+
+	// Validate ID parameter using contract
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid user ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	// Get the user
+	user, err := c.userService.GetByID(id)
+	if err != nil {
+		return c.ResourceNotFoundResponse(ctx, "user", id)
+	}
+
+	grants, err := auth.GetPermissionService().GetEffectivePermissions(user.(*models.User))
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to load user permissions: "+err.Error())
+	}
+
+	slugs := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		slugs = append(slugs, grant.Slug)
+	}
+
+	return c.SuccessResponse(ctx, map[string]interface{}{
+		"user_id":     id,
+		"permissions": slugs,
+		"grants":      grants,
+	}, "User permissions retrieved successfully")
 }
 
 // Store POST /users - Implements CrudControllerContract
 func (c *UserController) Store(ctx http.Context) http.Response {
 	// Check super admin access
-	if err := c.checkSuperAdmin(ctx); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: Super admin privileges required")
+	if err := c.checkSuperAdmin(ctx, "users.create"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
 	}
 
 	// Validate create request using contract
 	data, err := c.ValidateCreateRequest(ctx)
 	if err != nil {
-		return c.ValidationErrorResponse(ctx, map[string]interface{}{
-			"validation_error": err.Error(),
-		})
+		return c.ValidationFailedResponse(ctx, err)
 	}
 
-	// Create the user using validated data
-	user, err := c.userService.Create(data)
+	// The user and their role are created together, with the role checked against the actor's hierarchy
+	actor, _ := c.GetCurrentUser(ctx).(*models.User)
+	roleID := popRoleID(data)
+	superAdmin, _ := popSuperAdmin(data)
+
+	user, err := c.audited(ctx).CreateUsing(func() (interface{}, error) {
+		return c.userService.CreateWithRole(data, roleID, actor, superAdmin)
+	})
 	if err != nil {
-		// Check for specific validation errors
-		if err.Error() == "email already exists" {
-			return c.ValidationErrorResponse(ctx, map[string]interface{}{
-				"validation_error": "The email address is already in use",
-			})
+		// Service-level validation failures are returned by field like request validation
+		if _, ok := err.(*contracts.FieldValidationError); ok {
+			return c.ValidationFailedResponse(ctx, err)
+		}
+		if response, ok := c.emailConflictResponse(ctx, err); ok {
+			return response
+		}
+		if isRoleChangeError(err) {
+			return c.roleChangeErrorResponse(ctx, err)
 		}
 		return c.InternalErrorResponse(ctx, "Failed to create user: "+err.Error())
 	}
@@ -125,9 +191,19 @@ func (c *UserController) Store(ctx http.Context) http.Response {
 
 // Update PUT /users/{id} - Implements CrudControllerContract
 func (c *UserController) Update(ctx http.Context) http.Response {
+	return c.update(ctx, c.ValidateUpdateRequest)
+}
+
+// Patch PATCH /users/{id} - updates only the fields present in the JSON body
+func (c *UserController) Patch(ctx http.Context) http.Response {
+	return c.update(ctx, c.ValidatePatchRequest)
+}
+
+// update runs a PUT or PATCH with the data produced by validate
+func (c *UserController) update(ctx http.Context, validate func(ctx http.Context, id uint) (map[string]interface{}, error)) http.Response {
 	// Check super admin access
-	if err := c.checkSuperAdmin(ctx); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: Super admin privileges required")
+	if err := c.checkSuperAdmin(ctx, "users.update"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
 	}
 
 	// Validate ID parameter using contract
@@ -145,21 +221,50 @@ func (c *UserController) Update(ctx http.Context) http.Response {
 	}
 
 	// Validate update request using contract
-	data, err := c.ValidateUpdateRequest(ctx, id)
+	data, err := validate(ctx, id)
 	if err != nil {
-		return c.ValidationErrorResponse(ctx, map[string]interface{}{
-			"validation_error": err.Error(),
-		})
+		return c.ValidationFailedResponse(ctx, err)
 	}
 
-	// Update the user using validated data
-	updatedUser, err := c.userService.Update(id, data)
+	// Role changes are checked against the actor's place in the hierarchy before anything is saved
+	roleID := popRoleID(data)
+	actor, _ := c.GetCurrentUser(ctx).(*models.User)
+	if roleID > 0 {
+		if err := c.userService.CheckRoleChange(id, roleID, actor); err != nil {
+			return c.roleChangeErrorResponse(ctx, err)
+		}
+	}
+
+	// Update the user using validated data; the caller is a super admin, so a super admin flag
+	// that was sent is saved with it. The role is applied only once the update has succeeded, so a
+	// failed update changes nothing and the audit entry covers both.
+	superAdmin, setSuperAdmin := popSuperAdmin(data)
+	updatedUser, err := c.audited(ctx).UpdateUsing(id, func() (interface{}, error) {
+		var updated interface{}
+		var err error
+		if setSuperAdmin {
+			updated, err = c.userService.UpdateWithSuperAdmin(id, data, superAdmin)
+		} else {
+			updated, err = c.userService.Update(id, data)
+		}
+		if err != nil || roleID == 0 {
+			return updated, err
+		}
+		if err := c.userService.SetRole(id, roleID, actor); err != nil {
+			return nil, err
+		}
+		return c.userService.GetByID(id)
+	})
 	if err != nil {
-		// Check for specific validation errors
-		if err.Error() == "email already exists" {
-			return c.ValidationErrorResponse(ctx, map[string]interface{}{
-				"validation_error": "The email address is already in use",
-			})
+		if isRoleChangeError(err) {
+			return c.roleChangeErrorResponse(ctx, err)
+		}
+		// Service-level validation failures are returned by field like request validation
+		if _, ok := err.(*contracts.FieldValidationError); ok {
+			return c.ValidationFailedResponse(ctx, err)
+		}
+		if response, ok := c.emailConflictResponse(ctx, err); ok {
+			return response
 		}
 		return c.InternalErrorResponse(ctx, "Failed to update user: "+err.Error())
 	}
@@ -167,11 +272,77 @@ func (c *UserController) Update(ctx http.Context) http.Response {
 	return c.ResourceUpdatedResponse(ctx, updatedUser, "user")
 }
 
+// popSuperAdmin removes is_super_admin from validated request data and returns it, with ok false
+// when it wasn't sent; the service guards the flag, so it is passed to the service separately
+func popSuperAdmin(data map[string]interface{}) (superAdmin bool, ok bool) {
+	value, present := data["is_super_admin"]
+	delete(data, "is_super_admin")
+	if !present {
+		return false, false
+	}
+	superAdmin, ok = value.(bool)
+	return superAdmin, ok
+}
+
+// popRoleID removes role_id from validated request data and returns it; the service ignores it
+func popRoleID(data map[string]interface{}) uint {
+	value, _ := contracts.CoerceFilterValue(data["role_id"], contracts.FilterTypeInt)
+	delete(data, "role_id")
+	roleID, _ := value.(int64)
+	if roleID <= 0 {
+		return 0
+	}
+	return uint(roleID)
+}
+
+// isRoleChangeError reports whether err is a refused or unknown role from the user service
+func isRoleChangeError(err error) bool {
+	return errors.Is(err, auth.ErrRoleAboveAssigner) || errors.Is(err, auth.ErrRoleAssignDenied) || errors.Is(err, auth.ErrRoleNotFound)
+}
+
+// roleChangeErrorResponse maps a refused role assignment to 403, an unknown role to 422
+func (c *UserController) roleChangeErrorResponse(ctx http.Context, err error) http.Response {
+	switch {
+	case errors.Is(err, auth.ErrRoleAboveAssigner):
+		return c.ForbiddenResponse(ctx, "You cannot assign or remove a role at or above your own level")
+	case errors.Is(err, auth.ErrRoleAssignDenied):
+		return c.ForbiddenResponse(ctx, "You do not have permission to assign roles")
+	case errors.Is(err, auth.ErrRoleNotFound):
+		return c.ValidationErrorResponse(ctx, map[string]interface{}{
+			"validation_error": "The selected role does not exist",
+			"role_id":          []string{"The selected role does not exist"},
+		})
+	}
+	return c.InternalErrorResponse(ctx, "Failed to update user role: "+err.Error())
+}
+
+// emailConflictResponse turns an email uniqueness error from the service into a validation
+// response. An email held by a deleted user carries that user's ID and restore URL so the
+// form can offer to restore the account instead.
+func (c *UserController) emailConflictResponse(ctx http.Context, err error) (http.Response, bool) {
+	var deletedOwner *services.DeletedEmailOwnerError
+	if errors.As(err, &deletedOwner) {
+		return c.ValidationErrorResponse(ctx, map[string]interface{}{
+			"validation_error": "The email address belongs to a deleted account; restore or permanently delete that user first",
+			"email":            []string{"The email address belongs to a deleted account"},
+			"deleted_user_id":  deletedOwner.UserID,
+			"restore_url":      fmt.Sprintf("/api/users/%d/restore", deletedOwner.UserID),
+		}), true
+	}
+	if errors.Is(err, services.ErrEmailTaken) {
+		return c.ValidationErrorResponse(ctx, map[string]interface{}{
+			"validation_error": "The email address is already in use",
+			"email":            []string{"The email address is already in use"},
+		}), true
+	}
+	return nil, false
+}
+
 // Delete DELETE /users/{id} - Implements CrudControllerContract
 func (c *UserController) Delete(ctx http.Context) http.Response {
 	// Check super admin access
-	if err := c.checkSuperAdmin(ctx); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: Super admin privileges required")
+	if err := c.checkSuperAdmin(ctx, "users.delete"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
 	}
 
 	// Validate ID parameter using contract
@@ -182,6 +353,17 @@ func (c *UserController) Delete(ctx http.Context) http.Response {
 		})
 	}
 
+	// ?force=true permanently removes the user, including an already soft-deleted one
+	if ctx.Request().QueryBool("force") {
+		if err := c.audited(ctx).ForceDelete(id); err != nil {
+			if errors.Is(err, contracts.ErrRecordNotFound) {
+				return c.ResourceNotFoundResponse(ctx, "user", id)
+			}
+			return c.QueryFailedResponse(ctx, "Failed to delete user", err)
+		}
+		return c.ResourceDeletedResponse(ctx, "user", id)
+	}
+
 	// Check if user exists
 	_, err = c.userService.GetByID(id)
 	if err != nil {
@@ -189,7 +371,7 @@ func (c *UserController) Delete(ctx http.Context) http.Response {
 	}
 
 	// Delete the user
-	err = c.userService.Delete(id)
+	err = c.audited(ctx).Delete(id)
 	if err != nil {
 		return c.InternalErrorResponse(ctx, "Failed to delete user: "+err.Error())
 	}
@@ -197,11 +379,100 @@ func (c *UserController) Delete(ctx http.Context) http.Response {
 	return c.ResourceDeletedResponse(ctx, "user", id)
 }
 
+// Restore POST /users/{id}/restore - brings back a soft-deleted user
+func (c *UserController) Restore(ctx http.Context) http.Response {
+	// Check super admin access
+	if err := c.checkSuperAdmin(ctx, "users.restore"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
+	}
+
+	// Validate ID parameter using contract
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid user ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	if err := c.audited(ctx).Restore(id); err != nil {
+		return c.ResourceNotFoundResponse(ctx, "deleted user", id)
+	}
+
+	user, err := c.userService.GetByID(id)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to load restored user: "+err.Error())
+	}
+
+	return c.SuccessResponse(ctx, c.TransformResource(user), "User restored successfully")
+}
+
+// Unlock POST /users/{id}/unlock - clears a lockout caused by repeated failed logins
+func (c *UserController) Unlock(ctx http.Context) http.Response {
+	// Check super admin access
+	if err := c.checkSuperAdmin(ctx, "users.update"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
+	}
+
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid user ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	before, err := c.userService.GetByID(id)
+	if err != nil {
+		return c.ResourceNotFoundResponse(ctx, "user", id)
+	}
+
+	if err := c.userService.UnlockUser(id); err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to unlock user: "+err.Error())
+	}
+
+	user, err := c.userService.GetByID(id)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to load unlocked user: "+err.Error())
+	}
+
+	if err := c.auditService.Record(services.AuditActor(c.GetCurrentUser(ctx)), "users", id, services.AuditActionUpdate, before, user); err != nil {
+		facades.Log().Error("Failed to record audit log: " + err.Error())
+	}
+
+	return c.SuccessResponse(ctx, c.TransformResource(user), "User unlocked successfully")
+}
+
+// Audit GET /users/{id}/audit - change history for one user, newest first
+func (c *UserController) Audit(ctx http.Context) http.Response {
+	// Check super admin access
+	if err := c.checkSuperAdmin(ctx, "users.view"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
+	}
+
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid user ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	entries, err := c.auditService.GetTrail("users", id)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to retrieve audit trail: "+err.Error())
+	}
+
+	return c.SuccessResponse(ctx, entries, "Audit trail retrieved successfully")
+}
+
+// audited wraps the user service so changes are audit logged against the current user
+func (c *UserController) audited(ctx http.Context) *services.AuditedService {
+	return services.NewAuditedService(c.userService, "users", services.AuditActor(c.GetCurrentUser(ctx)))
+}
+
 // GetRoles GET /users/roles - Get all available roles for assignment
 func (c *UserController) GetRoles(ctx http.Context) http.Response {
 	// Check super admin access
-	if err := c.checkSuperAdmin(ctx); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: Super admin privileges required")
+	if err := c.checkSuperAdmin(ctx, "users.viewAny"); err != nil {
+		return c.ForbiddenResponse(ctx, contracts.Trans(ctx, "responses.super_admin_required"))
 	}
 
 	roles, err := c.userService.GetAllRoles()
@@ -216,21 +487,22 @@ func (c *UserController) GetRoles(ctx http.Context) http.Response {
 
 // ValidationControllerContract implementation
 func (c *UserController) ValidateCreateRequest(ctx http.Context) (map[string]interface{}, error) {
-	var createRequest requests.UserCreateRequest
-	
-	// Bind the data to the struct
-	if err := ctx.Request().Bind(&createRequest); err != nil {
-		return nil, fmt.Errorf("data binding failed: %w", err)
+	if err := c.RejectUnknownFields(ctx, c.userService, userHandledFields...); err != nil {
+		return nil, err
 	}
-	
-	// Manual validation
-	if len(createRequest.Name) < 2 || len(createRequest.Name) > 255 {
-		return nil, fmt.Errorf("validation errors: name must be between 2 and 255 characters")
+
+	var createRequest requests.UserCreateRequest
+
+	// The request's rules report field errors, a taken email included
+	errors, err := ctx.Request().ValidateRequest(&createRequest)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
 	}
-	if createRequest.Email == "" {
-		return nil, fmt.Errorf("validation errors: email is required")
+	if errors != nil {
+		return nil, contracts.FieldValidationErrorFromValidator(errors.All())
 	}
-	if createRequest.Password == "" || len(createRequest.Password) < 8 {
+
+	if len(createRequest.Password) < 8 {
 		return nil, fmt.Errorf("validation errors: password must be at least 8 characters")
 	}
 
@@ -238,18 +510,21 @@ func (c *UserController) ValidateCreateRequest(ctx http.Context) (map[string]int
 }
 
 func (c *UserController) ValidateUpdateRequest(ctx http.Context, id uint) (map[string]interface{}, error) {
+	if err := c.RejectUnknownFields(ctx, c.userService, userHandledFields...); err != nil {
+		return nil, err
+	}
+
 	var updateRequest requests.UserUpdateRequest
 	updateRequest.ID = id // Set the ID for validation context
 
-	// Bind the data to the struct
-	if err := ctx.Request().Bind(&updateRequest); err != nil {
-		return nil, fmt.Errorf("data binding failed: %w", err)
+	errors, err := ctx.Request().ValidateRequest(&updateRequest)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
 	}
-	
-	// Manual validation for update (all fields optional)
-	if updateRequest.Name != "" && (len(updateRequest.Name) < 2 || len(updateRequest.Name) > 255) {
-		return nil, fmt.Errorf("validation errors: name must be between 2 and 255 characters")
+	if errors != nil {
+		return nil, contracts.FieldValidationErrorFromValidator(errors.All())
 	}
+
 	if updateRequest.Password != "" && len(updateRequest.Password) < 8 {
 		return nil, fmt.Errorf("validation errors: password must be at least 8 characters")
 	}
@@ -257,14 +532,86 @@ func (c *UserController) ValidateUpdateRequest(ctx http.Context, id uint) (map[s
 	return updateRequest.ToUpdateData(), nil
 }
 
+// userHandledFields are body fields the controller applies itself rather than the service writing
+// them: the role, the super admin flag and whether to email a verification link
+var userHandledFields = []string{"role_id", "is_super_admin", "send_verification"}
+
+// userPatchFields lists the keys a PATCH may change and the type each is coerced to. The
+// password is left untyped so it isn't trimmed; ValidatePatchRequest checks it is a string.
+var userPatchFields = map[string]string{
+	"name":           contracts.FilterTypeString,
+	"email":          contracts.FilterTypeString,
+	"password":       "",
+	"is_active":      contracts.FilterTypeBool,
+	"is_super_admin": contracts.FilterTypeBool,
+	"role_id":        contracts.FilterTypeInt,
+}
+
+// ValidatePatchRequest keeps only the fields sent in the body. A field that is sent must hold a
+// usable value, so name and email can't be blanked; the service validates the rest.
+func (c *UserController) ValidatePatchRequest(ctx http.Context, id uint) (map[string]interface{}, error) {
+	if err := c.RejectUnknownFields(ctx, c.userService, userHandledFields...); err != nil {
+		return nil, err
+	}
+
+	data, err := c.PartialUpdateData(ctx, userPatchFields)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := contracts.NewFieldValidationError()
+	if name, ok := data["name"].(string); ok && (len(name) < 2 || len(name) > 255) {
+		errs.Add("name", "name must be between 2 and 255 characters")
+	}
+	if email, ok := data["email"].(string); ok && email == "" {
+		errs.Add("email", "email cannot be empty")
+	}
+	if password, present := data["password"]; present {
+		if value, ok := password.(string); !ok || len(value) < 8 {
+			errs.Add("password", "password must be at least 8 characters")
+		}
+	}
+	return data, errs.Err()
+}
+
 func (c *UserController) GetValidationRules() map[string]interface{} {
 	return c.userService.GetValidationRules()
 }
 
+// Trashed GET /users/trashed - soft-deleted users for the trash screen
+func (c *UserController) Trashed(ctx http.Context) http.Response {
+	return c.TrashedResponse(ctx, "users.viewAny", c, c.userService)
+}
+
+// Exists GET /users/exists?field=email&value=... - duplicate check for the user form
+func (c *UserController) Exists(ctx http.Context) http.Response {
+	return c.ExistsResponse(ctx, "users.viewAny", c, c.userService)
+}
+
+// Schema GET /users/schema - the fields user lists can sort, filter and search on
+func (c *UserController) Schema(ctx http.Context) http.Response {
+	return c.SchemaResponse(ctx, "users.viewAny", c, c.userService)
+}
+
+// Related GET /users/{id}/related/{relation} - one page of a user's roles
+func (c *UserController) Related(ctx http.Context) http.Response {
+	return c.RelatedResponse(ctx, "users.view", c, c.userService)
+}
+
+// Activate POST /users/{id}/activate
+func (c *UserController) Activate(ctx http.Context) http.Response {
+	return c.SetActiveResponse(ctx, true, "users.update", c, c.audited(ctx))
+}
+
+// Deactivate POST /users/{id}/deactivate
+func (c *UserController) Deactivate(ctx http.Context) http.Response {
+	return c.SetActiveResponse(ctx, false, "users.update", c, c.audited(ctx))
+}
+
 // AuthorizationControllerContract implementation
 func (c *UserController) CheckPermission(ctx http.Context, permission string, resource interface{}) error {
 	// For user management, we only check super admin status
-	return c.checkSuperAdmin(ctx)
+	return c.checkSuperAdmin(ctx, permission)
 }
 
 func (c *UserController) GetCurrentUser(ctx http.Context) interface{} {
@@ -281,17 +628,5 @@ func (c *UserController) RequireAuthentication(ctx http.Context) error {
 }
 
 func (c *UserController) BuildPermissionsMap(ctx http.Context, resourceType string) map[string]bool {
-	// For super admin only access, all permissions are based on super admin status
-	permHelper := auth.GetPermissionHelper()
-	user := permHelper.GetAuthenticatedUser(ctx)
-	isSuperAdmin := user != nil && user.IsSuperAdmin
-	
-	return map[string]bool{
-		"canCreate": isSuperAdmin,
-		"canEdit":   isSuperAdmin,
-		"canDelete": isSuperAdmin,
-		"canManage": isSuperAdmin,
-		"canExport": isSuperAdmin,
-		"canViewReports": isSuperAdmin,
-	}
+	return auth.GetPermissionHelper().BuildPermissionsMap(ctx, resourceType)
 }
\ No newline at end of file
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	goravelgin "github.com/goravel/gin"
+
+	"players/app/auth"
+	"players/app/contracts"
+	"players/app/models"
+)
+
+// TestSuperAdminApiKeyIsLimitedToItsScopes checks the guard every /api/users handler runs, with the
+// request as ApiKeyAuth leaves it: a super admin's key only reaches user management when scoped for it.
+func TestSuperAdminApiKeyIsLimitedToItsScopes(t *testing.T) {
+	owner := &models.User{Email: "admin@example.com", IsActive: true, IsSuperAdmin: true}
+	owner.ID = 1
+
+	tests := []struct {
+		name    string
+		scopes  string
+		allowed bool
+	}{
+		{"narrow scope", `["books.view"]`, false},
+		{"no scopes", `[]`, false},
+		{"scoped for the action", `["users.viewAny"]`, true},
+		{"wildcard scope", `["users.*"]`, true},
+	}
+
+	controller := &UserController{BaseCrudController: contracts.NewBaseCrudController("user")}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ginCtx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ginCtx.Request = httptest.NewRequest(nethttp.MethodGet, "/api/users", nil)
+			ctx := goravelgin.NewContext(ginCtx)
+			ctx.WithValue(auth.ApiKeyContextKey, &models.ApiKey{UserID: owner.ID, Scopes: models.JSON(tt.scopes), User: owner})
+
+			err := controller.CheckPermission(ctx, "users.viewAny", nil)
+			if allowed := err == nil; allowed != tt.allowed {
+				t.Errorf("CheckPermission(users.viewAny) allowed = %v, want %v (err: %v)", allowed, tt.allowed, err)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"players/app/contracts"
 	"players/app/helpers"
 	"players/app/http/inertia"
+	"players/app/http/resources"
 	"players/app/services"
 )
 
@@ -48,7 +49,7 @@ func (c *UserPageController) Index(ctx http.Context) http.Response {
 	// Check super admin access
 	if err := c.checkSuperAdmin(ctx); err != nil {
 		return inertia.Render(ctx, "Errors/403", map[string]interface{}{
-			"message": "Access denied: Super admin privileges required",
+			"message": contracts.Trans(ctx, "responses.super_admin_required"),
 		})
 	}
 
@@ -60,11 +61,11 @@ func (c *UserPageController) Index(ctx http.Context) http.Response {
 		req.SetDefaults()
 	}
 
-	// Build permissions map (all true for super admin)
-	permissions := c.BuildPermissionsMap(ctx, "user")
+	// Build permissions map from the user's grants on users
+	permissions := c.BuildPermissionsMap(ctx, "users")
 
 	// Get users data
-	usersResult, err := c.userService.GetList(*req)
+	usersResult, err := c.userService.GetList(ctx.Context(), *req)
 	if err != nil {
 		// Handle error gracefully, provide empty result
 		usersResult = &contracts.PaginatedResult{
@@ -84,7 +85,7 @@ func (c *UserPageController) Index(ctx http.Context) http.Response {
 
 	// Build standardized page props using contract
 	data := map[string]interface{}{
-		"data":        usersResult.Data,
+		"data":        resources.Collection(usersResult.Data, resources.User),
 		"total":       usersResult.Total,
 		"currentPage": usersResult.CurrentPage,
 		"lastPage":    usersResult.LastPage,
@@ -132,52 +133,35 @@ func (c *UserPageController) getUserStatistics() map[string]interface{} {
 
 // getUserTotalCount gets the total number of users
 func (c *UserPageController) getUserTotalCount() int {
-	req := contracts.ListRequest{
-		PageSize: 1,
-	}
-	result, err := c.userService.GetList(req)
+	count, err := c.userService.CountByFilter(nil)
 	if err != nil {
 		return 0
 	}
-	return int(result.Total)
+	return int(count)
 }
 
 // getUserCountByStatus gets user count by active status
 func (c *UserPageController) getUserCountByStatus(isActive bool) int {
-	req := contracts.ListRequest{
-		PageSize: 1,
-		Filters: map[string]interface{}{
-			"is_active": isActive,
-		},
-	}
-
-	result, err := c.userService.GetListAdvanced(req, map[string]interface{}{
+	count, err := c.userService.CountByFilter(map[string]interface{}{
 		"is_active": isActive,
 	})
 	if err != nil {
 		return 0
 	}
 
-	return int(result.Total)
+	return int(count)
 }
 
 // getSuperAdminCount gets the number of super admin users
 func (c *UserPageController) getSuperAdminCount() int {
-	req := contracts.ListRequest{
-		PageSize: 1,
-		Filters: map[string]interface{}{
-			"is_super_admin": true,
-		},
-	}
-
-	result, err := c.userService.GetListAdvanced(req, map[string]interface{}{
+	count, err := c.userService.CountByFilter(map[string]interface{}{
 		"is_super_admin": true,
 	})
 	if err != nil {
 		return 0
 	}
 
-	return int(result.Total)
+	return int(count)
 }
 
 // CONTRACT IMPLEMENTATIONS - Required by PageControllerContract interface
@@ -201,17 +185,5 @@ func (c *UserPageController) RequireAuthentication(ctx http.Context) error {
 }
 
 func (c *UserPageController) BuildPermissionsMap(ctx http.Context, resourceType string) map[string]bool {
-	// For super admin only access, all permissions are based on super admin status
-	permHelper := auth.GetPermissionHelper()
-	user := permHelper.GetAuthenticatedUser(ctx)
-	isSuperAdmin := user != nil && user.IsSuperAdmin
-	
-	return map[string]bool{
-		"canCreate": isSuperAdmin,
-		"canEdit":   isSuperAdmin,
-		"canDelete": isSuperAdmin,
-		"canManage": isSuperAdmin,
-		"canExport": isSuperAdmin,
-		"canViewReports": isSuperAdmin,
-	}
+	return auth.GetPermissionHelper().BuildPermissionsMap(ctx, resourceType)
 }
\ No newline at end of file
@@ -1,14 +1,20 @@
 package books
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+	"github.com/goravel/framework/validation"
 	"players/app/auth"
 	"players/app/contracts"
 	"players/app/helpers"
 	"players/app/http/requests"
+	"players/app/http/resources"
+	"players/app/models"
 	"players/app/services"
 )
 
@@ -16,8 +22,9 @@ import (
 // Implements ResourceControllerContract interface
 type BookController struct {
 	*contracts.BaseCrudController
-	bookService *services.BookService
-	authHelper  contracts.AuthHelper
+	bookService  *services.BookService
+	auditService *services.AuditService
+	authHelper   contracts.AuthHelper
 }
 
 // NewBookController creates a new book controller that implements all contracts
@@ -25,9 +32,12 @@ func NewBookController() *BookController {
 	controller := &BookController{
 		BaseCrudController: contracts.NewBaseCrudController("book"),
 		bookService:        services.NewBookService(),
+		auditService:       services.NewAuditService(),
 		authHelper:         helpers.NewAuthHelper(),
 	}
 
+	controller.SetResourceTransformer(resources.Book)
+
 	// Register controller with validation
 	contracts.MustRegisterCrudController("books", controller)
 
@@ -45,14 +55,14 @@ func (c *BookController) Index(ctx http.Context) http.Response {
 	}
 
 	// Get books using service
-	result, err := c.bookService.GetList(*req)
+	result, err := c.bookService.GetList(ctx.Context(), *req)
 	if err != nil {
-		return c.InternalErrorResponse(ctx, "Failed to retrieve books: "+err.Error())
+		return c.QueryFailedResponse(ctx, "Failed to retrieve books", err)
 	}
 
 	// Build standardized paginated response
 	response := c.BuildPaginatedResponse(result, req)
-	return c.SuccessResponse(ctx, response, "Books retrieved successfully")
+	return c.ConditionalResponse(ctx, response, "Books retrieved successfully")
 }
 
 // Show GET /books/{id} - Implements CrudControllerContract (JSON for modals)
@@ -73,33 +83,98 @@ func (c *BookController) Show(ctx http.Context) http.Response {
 		return c.ResourceNotFoundResponse(ctx, "book", id)
 	}
 
-	return c.SuccessResponse(ctx, book, "Book details retrieved successfully")
+	return c.ConditionalResponse(ctx, c.TransformResource(book), "Book details retrieved successfully")
 }
 
 // Store POST /books - Implements CrudControllerContract
 func (c *BookController) Store(ctx http.Context) http.Response {
 	// Check authorization using new permission format
 	if err := c.CheckPermission(ctx, "books_create", nil); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: "+err.Error())
+		return c.AccessDeniedResponse(ctx, err)
 	}
 
 	// Validate create request using contract
 	data, err := c.ValidateCreateRequest(ctx)
 	if err != nil {
-		return c.ValidationErrorResponse(ctx, map[string]interface{}{
-			"validation_error": err.Error(),
-		})
+		return c.ValidationFailedResponse(ctx, err)
 	}
 
 	// Create the book using validated data
-	book, err := c.bookService.Create(data)
+	book, err := c.audited(ctx).Create(data)
 	if err != nil {
+		// Service-level validation failures are returned by field like request validation
+		if _, ok := err.(*contracts.FieldValidationError); ok {
+			return c.ValidationFailedResponse(ctx, err)
+		}
 		return c.InternalErrorResponse(ctx, "Failed to create book: "+err.Error())
 	}
 
 	return c.ResourceCreatedResponse(ctx, book, "book")
 }
 
+// Import POST /books/import - creates books from a CSV or JSON upload, or updates
+// books with a matching ISBN when updateExisting is set
+func (c *BookController) Import(ctx http.Context) http.Response {
+	// Check authorization using new permission format
+	if err := c.CheckPermission(ctx, "books_create", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	req, err := c.ValidateImportRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid import file", map[string]interface{}{
+			"import_error": err.Error(),
+		})
+	}
+
+	if req.UpdateExisting {
+		if err := c.CheckPermission(ctx, "books_update", nil); err != nil {
+			return c.AccessDeniedResponse(ctx, err)
+		}
+	}
+
+	return c.ImportResponse(ctx, req, func(row contracts.ImportRow) (string, error) {
+		return c.importBook(ctx, row.Data, req.UpdateExisting)
+	})
+}
+
+// importBook validates one imported row with the create request rules and saves it
+func (c *BookController) importBook(ctx http.Context, data map[string]interface{}, updateExisting bool) (string, error) {
+	var createRequest requests.BookCreateRequest
+
+	validator, err := facades.Validation().Make(data, createRequest.Rules(ctx),
+		validation.Messages(createRequest.Messages(ctx)),
+		validation.Attributes(createRequest.Attributes(ctx)),
+		validation.PrepareForValidation(ctx, createRequest.PrepareForValidation),
+	)
+	if err != nil {
+		return "", fmt.Errorf("validation failed: %w", err)
+	}
+	if validator.Fails() {
+		return "", contracts.FieldValidationErrorFromValidator(validator.Errors().All())
+	}
+	if err := validator.Bind(&createRequest); err != nil {
+		return "", fmt.Errorf("invalid row: %w", err)
+	}
+
+	bookData := createRequest.ToCreateData()
+
+	if updateExisting {
+		existing, err := c.bookService.GetByISBN(createRequest.ISBN)
+		if err == nil && existing.ID > 0 {
+			if _, err := c.audited(ctx).Update(existing.ID, bookData); err != nil {
+				return "", err
+			}
+			return contracts.ImportStatusUpdated, nil
+		}
+	}
+
+	if _, err := c.audited(ctx).Create(bookData); err != nil {
+		return "", err
+	}
+	return contracts.ImportStatusCreated, nil
+}
+
 // Update PUT /books/{id} - Implements CrudControllerContract
 func (c *BookController) Update(ctx http.Context) http.Response {
 	// Validate ID parameter using contract
@@ -110,28 +185,37 @@ func (c *BookController) Update(ctx http.Context) http.Response {
 		})
 	}
 
+	// Check authorization before the lookup, then against the loaded record for ownership
+	if err := c.CheckPermission(ctx, "books_update", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
 	// Check if book exists
-	_, err = c.bookService.GetByID(id)
+	existing, err := c.bookService.GetByID(id)
 	if err != nil {
 		return c.ResourceNotFoundResponse(ctx, "book", id)
 	}
 
-	// Check authorization using new permission format
-	if err := c.CheckPermission(ctx, "books_update", nil); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: "+err.Error())
+	if err := c.CheckPermission(ctx, "books_update", existing); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
 	}
 
 	// Validate update request using contract
 	data, err := c.ValidateUpdateRequest(ctx, id)
 	if err != nil {
-		return c.ValidationErrorResponse(ctx, map[string]interface{}{
-			"validation_error": err.Error(),
-		})
+		return c.ValidationFailedResponse(ctx, err)
 	}
 
 	// Update the book using validated data
-	updatedBook, err := c.bookService.Update(id, data)
+	updatedBook, err := c.audited(ctx).Update(id, data)
 	if err != nil {
+		// Service-level validation failures are returned by field like request validation
+		if _, ok := err.(*contracts.FieldValidationError); ok {
+			return c.ValidationFailedResponse(ctx, err)
+		}
+		if errors.Is(err, services.ErrBookOnLoan) || errors.Is(err, services.ErrStatusRequiresLoan) {
+			return c.ConflictResponse(ctx, err.Error(), nil)
+		}
 		return c.InternalErrorResponse(ctx, "Failed to update book: "+err.Error())
 	}
 
@@ -148,19 +232,37 @@ func (c *BookController) Delete(ctx http.Context) http.Response {
 		})
 	}
 
+	// ?force=true permanently removes the book, including an already soft-deleted one
+	if ctx.Request().QueryBool("force") {
+		if err := c.CheckPermission(ctx, "books.forceDelete", nil); err != nil {
+			return c.AccessDeniedResponse(ctx, err)
+		}
+		if err := c.audited(ctx).ForceDelete(id); err != nil {
+			if errors.Is(err, contracts.ErrRecordNotFound) {
+				return c.ResourceNotFoundResponse(ctx, "book", id)
+			}
+			return c.QueryFailedResponse(ctx, "Failed to delete book", err)
+		}
+		return c.ResourceDeletedResponse(ctx, "book", id)
+	}
+
+	// Check authorization before the lookup, then against the loaded record for ownership
+	if err := c.CheckPermission(ctx, "books_delete", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
 	// Check if book exists
-	_, err = c.bookService.GetByID(id)
+	existing, err := c.bookService.GetByID(id)
 	if err != nil {
 		return c.ResourceNotFoundResponse(ctx, "book", id)
 	}
 
-	// Check authorization using new permission format
-	if err := c.CheckPermission(ctx, "books_delete", nil); err != nil {
-		return c.ForbiddenResponse(ctx, "Access denied: "+err.Error())
+	if err := c.CheckPermission(ctx, "books_delete", existing); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
 	}
 
 	// Delete the book
-	err = c.bookService.Delete(id)
+	err = c.audited(ctx).Delete(id)
 	if err != nil {
 		return c.InternalErrorResponse(ctx, "Failed to delete book: "+err.Error())
 	}
@@ -168,6 +270,79 @@ func (c *BookController) Delete(ctx http.Context) http.Response {
 	return c.ResourceDeletedResponse(ctx, "book", id)
 }
 
+// Restore POST /books/{id}/restore - brings back a soft-deleted book
+func (c *BookController) Restore(ctx http.Context) http.Response {
+	// Validate ID parameter using contract
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid book ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	// Check authorization
+	if err := c.CheckPermission(ctx, "books.restore", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	if err := c.audited(ctx).Restore(id); err != nil {
+		return c.ResourceNotFoundResponse(ctx, "deleted book", id)
+	}
+
+	book, err := c.bookService.GetByID(id)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to load restored book: "+err.Error())
+	}
+
+	return c.SuccessResponse(ctx, c.TransformResource(book), "Book restored successfully")
+}
+
+// Trashed GET /books/trashed - soft-deleted books for the trash screen
+func (c *BookController) Trashed(ctx http.Context) http.Response {
+	return c.TrashedResponse(ctx, "books.viewAny", c, c.bookService)
+}
+
+// Exists GET /books/exists?field=isbn&value=... - duplicate check for the book form
+func (c *BookController) Exists(ctx http.Context) http.Response {
+	return c.ExistsResponse(ctx, "books.viewAny", c, c.bookService)
+}
+
+// Schema GET /books/schema - the fields book lists can sort, filter and search on
+func (c *BookController) Schema(ctx http.Context) http.Response {
+	return c.SchemaResponse(ctx, "books.viewAny", c, c.bookService)
+}
+
+// Related GET /books/{id}/related/{relation} - one page of a book's category or, for librarians, loans
+func (c *BookController) Related(ctx http.Context) http.Response {
+	return c.RelatedResponse(ctx, "books.view", c, c.bookService)
+}
+
+// Audit GET /books/{id}/audit - change history for one book, newest first
+func (c *BookController) Audit(ctx http.Context) http.Response {
+	id, err := c.ValidateID(ctx, "id")
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid book ID", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+
+	if err := c.CheckPermission(ctx, "books.audit", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	entries, err := c.auditService.GetTrail("books", id)
+	if err != nil {
+		return c.InternalErrorResponse(ctx, "Failed to retrieve audit trail: "+err.Error())
+	}
+
+	return c.SuccessResponse(ctx, entries, "Audit trail retrieved successfully")
+}
+
+// audited wraps the book service so changes are audit logged against the current user
+func (c *BookController) audited(ctx http.Context) *services.AuditedService {
+	return services.NewAuditedService(c.bookService, "books", services.AuditActor(c.GetCurrentUser(ctx)))
+}
+
 // GetByISBN GET /books/isbn/{isbn}
 func (c *BookController) GetByISBN(ctx http.Context) http.Response {
 	// Public endpoint - no authorization needed for viewing
@@ -203,7 +378,7 @@ func (c *BookController) GetByAuthor(ctx http.Context) http.Response {
 		req = helpers.ListRequest{} // Use defaults
 	}
 
-	result, err := c.bookService.GetByAuthor(author, req)
+	result, err := c.bookService.GetByAuthor(ctx.Context(), author, req)
 	if err != nil {
 		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to retrieve books by author",
@@ -221,7 +396,7 @@ func (c *BookController) GetAvailable(ctx http.Context) http.Response {
 		req = helpers.ListRequest{} // Use defaults
 	}
 
-	result, err := c.bookService.GetAvailable(req)
+	result, err := c.bookService.GetAvailable(ctx.Context(), req)
 	if err != nil {
 		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to retrieve available books",
@@ -242,25 +417,39 @@ func (c *BookController) Advanced(ctx http.Context) http.Response {
 	// Parse filters from query parameters
 	filters := make(map[string]interface{})
 
-	if status := ctx.Request().Query("status"); status != "" {
-		filters["status"] = status
-	}
-	if author := ctx.Request().Query("author"); author != "" {
-		filters["author"] = author
+	// Repeating status, author or category (?status=AVAILABLE&status=MAINTENANCE) matches any of
+	// the values; category takes slugs and category_id takes ids
+	for _, field := range []string{"status", "author", "category", "category_id"} {
+		if values := ctx.Request().QueryArray(field); len(values) > 1 {
+			filters[field] = values
+		} else if value := ctx.Request().Query(field); value != "" {
+			filters[field] = value
+		}
 	}
-	if minPrice := ctx.Request().Query("minPrice"); minPrice != "" {
-		if price, err := strconv.ParseFloat(minPrice, 64); err == nil {
-			filters["minPrice"] = price
+	// Price bounds are coerced to numbers by the service's filter types
+	for _, field := range []string{"minPrice", "maxPrice"} {
+		if value := ctx.Request().Query(field); value != "" {
+			filters[field] = value
 		}
 	}
-	if maxPrice := ctx.Request().Query("maxPrice"); maxPrice != "" {
-		if price, err := strconv.ParseFloat(maxPrice, 64); err == nil {
-			filters["maxPrice"] = price
+	if trashed := ctx.Request().Query("trashed"); trashed != "" {
+		// Listing deleted books is limited to those who can restore them
+		if err := c.CheckPermission(ctx, "books.restore", nil); err != nil {
+			return c.AccessDeniedResponse(ctx, err)
 		}
+		filters["trashed"] = trashed
 	}
+	// Range bounds such as created_at_from, published_at_to or price_gte
+	filters = c.RangeFilterParams(ctx, filters)
 
-	result, err := c.bookService.GetListAdvanced(req, filters)
+	result, err := c.bookService.GetListAdvanced(ctx.Context(), req, filters)
 	if err != nil {
+		if _, ok := err.(*contracts.RangeFilterError); ok {
+			return c.BadRequestResponse(ctx, err.Error(), nil)
+		}
+		if contracts.IsQueryTimeout(err) {
+			return c.QueryFailedResponse(ctx, "Failed to retrieve books", err)
+		}
 		return ctx.Response().Json(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
@@ -269,6 +458,29 @@ func (c *BookController) Advanced(ctx http.Context) http.Response {
 	return ctx.Response().Json(http.StatusOK, result)
 }
 
+// BulkStatus POST /books/bulk/status - moves a set of books to one status, e.g. a shelf to MAINTENANCE
+func (c *BookController) BulkStatus(ctx http.Context) http.Response {
+	if err := c.CheckPermission(ctx, "books.update", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	req, err := c.ValidateBulkRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid bulk request", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
+	if !services.IsBookStatus(req.Status) {
+		return c.ValidationErrorResponse(ctx, map[string]interface{}{
+			"status": []string{"status must be one of: " + strings.Join(services.BookStatuses, ", ")},
+		})
+	}
+
+	// Books on loan, or moved to BORROWED, fail individually and are reported per ID
+	err = c.bookService.BulkUpdateStatus(req.IDs, req.Status)
+	return c.BulkOperationResponse(ctx, "status update", req.IDs, err)
+}
+
 // Borrow POST /books/{id}/borrow
 func (c *BookController) Borrow(ctx http.Context) http.Response {
 	idStr := ctx.Request().Route("id")
@@ -280,16 +492,22 @@ func (c *BookController) Borrow(ctx http.Context) http.Response {
 	}
 
 	// Check authorization for borrowing
-	// TODO: Re-implement gate check
-	if false && false { // Disabled: response := facades.Gate().Inspect("borrow.books", ctx); response.Denied() {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Access denied",
+	if err := c.CheckPermission(ctx, "books.borrow", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	// The loan is recorded against the signed-in user
+	user, ok := c.GetCurrentUser(ctx).(*models.User)
+	if !ok || user == nil {
+		return ctx.Response().Json(http.StatusUnauthorized, map[string]string{
+			"error": "Authentication required",
 		})
 	}
 
-	err = c.bookService.BorrowBook(uint(id))
+	loan, err := c.bookService.BorrowBook(uint(id), user.ID)
 	if err != nil {
-		if err.Error() == "book is not available for borrowing" {
+		if errors.Is(err, services.ErrBookNotAvailable) || errors.Is(err, services.ErrBookAlreadyHeld) ||
+			errors.Is(err, services.ErrLoanLimitReached) {
 			return ctx.Response().Json(http.StatusConflict, map[string]string{
 				"error": err.Error(),
 			})
@@ -299,8 +517,9 @@ func (c *BookController) Borrow(ctx http.Context) http.Response {
 		})
 	}
 
-	return ctx.Response().Json(http.StatusOK, map[string]string{
+	return ctx.Response().Json(http.StatusOK, map[string]interface{}{
 		"message": "Book borrowed successfully",
+		"loan":    resources.NewBookLoanResource(loan),
 	})
 }
 
@@ -315,16 +534,27 @@ func (c *BookController) Return(ctx http.Context) http.Response {
 	}
 
 	// Check authorization for returning
-	// TODO: Re-implement gate check
-	if false && false { // Disabled: response := facades.Gate().Inspect("return.books", ctx); response.Denied() {
-		return ctx.Response().Json(http.StatusForbidden, map[string]string{
-			"error": "Access denied",
+	if err := c.CheckPermission(ctx, "books.return", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
+
+	user, ok := c.GetCurrentUser(ctx).(*models.User)
+	if !ok || user == nil {
+		return ctx.Response().Json(http.StatusUnauthorized, map[string]string{
+			"error": "Authentication required",
 		})
 	}
 
-	err = c.bookService.ReturnBook(uint(id))
+	// Borrowers return their own books; librarians and admins can take back any loan. Super admins
+	// pass books.manage unless they are calling with an API key not scoped for it.
+	anyBorrower := c.CheckPermission(ctx, "books.manage", nil) == nil
+
+	err = c.bookService.ReturnBook(uint(id), user.ID, anyBorrower)
 	if err != nil {
-		if err.Error() == "book is not currently borrowed" {
+		if errors.Is(err, services.ErrNotLoanHolder) {
+			return c.ForbiddenResponse(ctx, err.Error())
+		}
+		if errors.Is(err, services.ErrBookNotBorrowed) {
 			return ctx.Response().Json(http.StatusConflict, map[string]string{
 				"error": err.Error(),
 			})
@@ -339,47 +569,56 @@ func (c *BookController) Return(ctx http.Context) http.Response {
 	})
 }
 
-// CONTRACT IMPLEMENTATIONS - Required by ResourceControllerContract interface
+// Overdue GET /books/overdue - Open loans past their due date with the book and borrower,
+// longest overdue first
+func (c *BookController) Overdue(ctx http.Context) http.Response {
+	// Borrower details are limited to those who manage the collection
+	if err := c.CheckPermission(ctx, "books.manage", nil); err != nil {
+		return c.AccessDeniedResponse(ctx, err)
+	}
 
-// ValidationControllerContract implementation
-func (c *BookController) ValidateCreateRequest(ctx http.Context) (map[string]interface{}, error) {
-	var createRequest requests.BookCreateRequest
+	req, err := c.ValidatePaginationRequest(ctx)
+	if err != nil {
+		return c.BadRequestResponse(ctx, "Invalid pagination parameters", map[string]interface{}{
+			"validation_error": err.Error(),
+		})
+	}
 
-	// Bind the data to the struct
-	if err := ctx.Request().Bind(&createRequest); err != nil {
-		fmt.Printf("DEBUG: Bind error: %v\n", err)
-		return nil, fmt.Errorf("data binding failed: %w", err)
+	result, err := c.bookService.GetOverdueLoans(ctx.Context(), *req)
+	if err != nil {
+		return c.QueryFailedResponse(ctx, "Failed to retrieve overdue loans", err)
 	}
+	result.Data = resources.Collection(result.Data, resources.BookLoan)
 
-	fmt.Printf("DEBUG: Successfully bound data: %+v\n", createRequest)
+	return c.SuccessResponse(ctx, c.BuildPaginatedResponse(result, req), "Overdue loans retrieved successfully")
+}
 
-	// Manual validation for debugging - check field lengths
-	if len(createRequest.Title) > 255 {
-		return nil, fmt.Errorf("validation errors: title exceeds 255 characters (%d)", len(createRequest.Title))
-	}
-	if len(createRequest.Author) > 100 {
-		return nil, fmt.Errorf("validation errors: author exceeds 100 characters (%d)", len(createRequest.Author))
-	}
-	if len(createRequest.Description) > 1000 {
-		return nil, fmt.Errorf("validation errors: description exceeds 1000 characters (%d)", len(createRequest.Description))
-	}
+// CONTRACT IMPLEMENTATIONS - Required by ResourceControllerContract interface
 
-	// Check required fields
-	if createRequest.Title == "" {
-		return nil, fmt.Errorf("validation errors: title is required")
+// ValidationControllerContract implementation
+func (c *BookController) ValidateCreateRequest(ctx http.Context) (map[string]interface{}, error) {
+	if err := c.RejectUnknownFields(ctx, c.bookService); err != nil {
+		return nil, err
 	}
-	if createRequest.Author == "" {
-		return nil, fmt.Errorf("validation errors: author is required")
+
+	var createRequest requests.BookCreateRequest
+
+	errors, err := ctx.Request().ValidateRequest(&createRequest)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
 	}
-	if createRequest.ISBN == "" {
-		return nil, fmt.Errorf("validation errors: isbn is required")
+	if errors != nil {
+		return nil, contracts.FieldValidationErrorFromValidator(errors.All())
 	}
 
-	fmt.Printf("DEBUG: Manual validation passed\n")
 	return createRequest.ToCreateData(), nil
 }
 
 func (c *BookController) ValidateUpdateRequest(ctx http.Context, id uint) (map[string]interface{}, error) {
+	if err := c.RejectUnknownFields(ctx, c.bookService); err != nil {
+		return nil, err
+	}
+
 	var updateRequest requests.BookUpdateRequest
 	updateRequest.ID = id // Set the ID for validation context
 
@@ -388,7 +627,7 @@ func (c *BookController) ValidateUpdateRequest(ctx http.Context, id uint) (map[s
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 	if errors != nil {
-		return nil, fmt.Errorf("validation errors: %v", errors.All())
+		return nil, contracts.FieldValidationErrorFromValidator(errors.All())
 	}
 
 	return updateRequest.ToUpdateData(), nil
@@ -400,8 +639,9 @@ func (c *BookController) GetValidationRules() map[string]interface{} {
 
 // AuthorizationControllerContract implementation
 func (c *BookController) CheckPermission(ctx http.Context, permission string, resource interface{}) error {
+	// resource is the loaded model when there is one, so ownership-scoped permissions are enforced
 	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequirePermission(ctx, permission)
+	_, err := permHelper.RequirePermissionOn(ctx, permission, resource)
 	return err
 }
 
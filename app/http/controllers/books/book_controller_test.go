@@ -0,0 +1,68 @@
+package books
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	goravelgin "github.com/goravel/gin"
+
+	"players/app/auth"
+	"players/app/contracts"
+	"players/app/models"
+	"players/app/services"
+	"players/tests/testdb"
+)
+
+// TestRelatedOnlyReachesOfferedRelations requests /books/{id}/related/{relation} as a super admin's
+// API key, whose scopes decide what the request may see: loans need books.manage on top of
+// books.view, unknown relations are refused, and nothing is looked up without books.view.
+func TestRelatedOnlyReachesOfferedRelations(t *testing.T) {
+	db := testdb.Open(t, &models.Permission{}, &models.Role{}, &models.User{}, &models.UserRole{}, &models.Category{}, &models.Book{}, &models.BookLoan{})
+	owner := models.User{Name: "Librarian", Email: "librarian@example.com", Password: "x", IsActive: true, IsSuperAdmin: true}
+	if err := db.Create(&owner).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	book := models.Book{Title: "Book", Author: "Author", ISBN: "isbn-1", Status: "AVAILABLE"}
+	if err := db.Create(&book).Error; err != nil {
+		t.Fatalf("create book: %v", err)
+	}
+
+	bookService := services.NewBookService()
+	bookService.SetRecordCache(false)
+	controller := &BookController{BaseCrudController: contracts.NewBaseCrudController("book"), bookService: bookService}
+
+	tests := []struct {
+		name     string
+		bookID   string
+		relation string
+		scopes   string
+		want     int
+	}{
+		{"loans for a librarian", "1", "loans", `["books.view", "books.manage"]`, nethttp.StatusOK},
+		{"loans without books.manage", "1", "loans", `["books.view"]`, nethttp.StatusForbidden},
+		{"offered relation", "1", "category", `["books.view"]`, nethttp.StatusOK},
+		{"relation that isn't offered", "1", "borrower", `["books.view", "books.manage"]`, nethttp.StatusBadRequest},
+		{"missing book without books.view", "99", "category", `["books.manage"]`, nethttp.StatusForbidden},
+		{"missing book with books.view", "99", "category", `["books.view"]`, nethttp.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(recorder)
+			ginCtx.Request = httptest.NewRequest(nethttp.MethodGet, "/api/books/"+tt.bookID+"/related/"+tt.relation, nil)
+			ginCtx.Params = gin.Params{{Key: "id", Value: tt.bookID}, {Key: "relation", Value: tt.relation}}
+			ctx := goravelgin.NewContext(ginCtx)
+			ctx.WithValue(auth.ApiKeyContextKey, &models.ApiKey{UserID: owner.ID, Scopes: models.JSON(tt.scopes), User: &owner})
+
+			if err := controller.Related(ctx).Render(); err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if recorder.Code != tt.want {
+				t.Errorf("GET related/%s = %d, want %d: %s", tt.relation, recorder.Code, tt.want, recorder.Body)
+			}
+		})
+	}
+}
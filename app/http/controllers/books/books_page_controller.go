@@ -8,6 +8,7 @@ import (
 	"players/app/contracts"
 	"players/app/helpers"
 	"players/app/http/inertia"
+	"players/app/http/resources"
 	"players/app/services"
 )
 
@@ -62,12 +63,8 @@ func (c *BooksPageController) Index(ctx http.Context) http.Response {
 	// Build permissions map using contract - using service identifier
 	permissions := c.BuildPermissionsMap(ctx, string(c.GetServiceIdentifier()))
 
-	// Debug logging for permissions
-	fmt.Printf("DEBUG: User authentication status: %+v\n", c.GetCurrentUser(ctx) != nil)
-	fmt.Printf("DEBUG: Permissions for 'books' resource: %+v\n", permissions)
-
 	// Get books data
-	booksResult, err := c.bookService.GetList(*req)
+	booksResult, err := c.bookService.GetList(ctx.Context(), *req)
 	if err != nil {
 		// Handle error gracefully, provide empty result
 		booksResult = &contracts.PaginatedResult{
@@ -87,7 +84,7 @@ func (c *BooksPageController) Index(ctx http.Context) http.Response {
 
 	// Build standardized page props using contract
 	data := map[string]interface{}{
-		"data":        booksResult.Data,
+		"data":        resources.Collection(booksResult.Data, resources.Book),
 		"total":       booksResult.Total,
 		"currentPage": booksResult.CurrentPage,
 		"lastPage":    booksResult.LastPage,
@@ -163,23 +160,14 @@ func (c *BooksPageController) getBookStatistics() map[string]interface{} {
 
 // getBookCountByStatus is a helper function to get book count by status
 func (c *BooksPageController) getBookCountByStatus(status string) int {
-	// This is a simplified version. In a real implementation,
-	// you'd want to add a method to the service to get counts efficiently
-	req := contracts.ListRequest{
-		PageSize: 1,
-		Filters: map[string]interface{}{
-			"status": status,
-		},
-	}
-
-	result, err := c.bookService.GetListAdvanced(req, map[string]interface{}{
+	count, err := c.bookService.CountByFilter(map[string]interface{}{
 		"status": status,
 	})
 	if err != nil {
 		return 0
 	}
 
-	return int(result.Total)
+	return int(count)
 }
 
 // max helper function
@@ -194,8 +182,9 @@ func max(a, b int) int {
 
 // AuthorizationControllerContract implementation
 func (c *BooksPageController) CheckPermission(ctx http.Context, permission string, resource interface{}) error {
+	// resource is the loaded model when there is one, so ownership-scoped permissions are enforced
 	permHelper := auth.GetPermissionHelper()
-	_, err := permHelper.RequirePermission(ctx, permission)
+	_, err := permHelper.RequirePermissionOn(ctx, permission, resource)
 	return err
 }
 
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+// healthCheckTimeout bounds each readiness check so a hung dependency fails the probe instead of stalling it
+const healthCheckTimeout = 2 * time.Second
+
+// HealthController serves the unauthenticated liveness and readiness probes
+type HealthController struct {
+	// Dependencies can be injected here
+}
+
+func NewHealthController() *HealthController {
+	return &HealthController{}
+}
+
+// Liveness GET /healthz - the process is up and serving requests
+func (r *HealthController) Liveness(ctx http.Context) http.Response {
+	return ctx.Response().Json(http.StatusOK, http.Json{
+		"status": "ok",
+	})
+}
+
+// Readiness GET /readyz - the database and cache store are reachable. Responds 503 listing
+// the failed dependencies when any check fails.
+func (r *HealthController) Readiness(ctx http.Context) http.Response {
+	checks := map[string]string{}
+	failed := []string{}
+	for _, dependency := range []struct {
+		name  string
+		check func() error
+	}{
+		{"database", r.checkDatabase},
+		{"cache", r.checkCache},
+	} {
+		if err := dependency.check(); err != nil {
+			checks[dependency.name] = err.Error()
+			failed = append(failed, dependency.name)
+		} else {
+			checks[dependency.name] = "ok"
+		}
+	}
+
+	if len(failed) > 0 {
+		return ctx.Response().Json(http.StatusServiceUnavailable, http.Json{
+			"status": "unavailable",
+			"failed": failed,
+			"checks": checks,
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, http.Json{
+		"status": "ok",
+		"checks": checks,
+	})
+}
+
+// checkDatabase runs SELECT 1 on the default connection
+func (r *HealthController) checkDatabase() error {
+	timeout, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	var result int
+	if err := facades.Orm().WithContext(timeout).Query().Raw("SELECT 1").Scan(&result); err != nil {
+		return err
+	}
+	if result != 1 {
+		return fmt.Errorf("unexpected result %d from SELECT 1", result)
+	}
+	return nil
+}
+
+// checkCache writes and reads back a short-lived key on the configured cache store, e.g. redis
+func (r *HealthController) checkCache() error {
+	timeout, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	const key = "health:readyz"
+	store := facades.Cache().WithContext(timeout)
+	if err := store.Put(key, "ok", 10*time.Second); err != nil {
+		return err
+	}
+	if value := store.GetString(key); value != "ok" {
+		return fmt.Errorf("cache returned %q for the probe key", value)
+	}
+	return nil
+}
@@ -92,13 +92,14 @@ func (c *SearchController) searchBooks(query string) []SearchResult {
 	// Build query for case-insensitive search
 	searchPattern := "%" + query + "%"
 	
-	// Search in title, author, isbn, and description
+	// Search in title, author, isbn, description and category name
 	// Using COLLATE NOCASE for SQLite compatibility
 	facades.Orm().Query().
 		Where("title COLLATE NOCASE LIKE ?", searchPattern).
 		OrWhere("author COLLATE NOCASE LIKE ?", searchPattern).
 		OrWhere("isbn COLLATE NOCASE LIKE ?", searchPattern).
 		OrWhere("description COLLATE NOCASE LIKE ?", searchPattern).
+		OrWhere("category_id IN (SELECT id FROM categories WHERE name COLLATE NOCASE LIKE ?)", searchPattern).
 		Order("title ASC").
 		Limit(10).
 		Find(&books)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 
+	"github.com/gin-gonic/gin"
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
 	"github.com/petaki/inertia-go"
@@ -25,6 +26,12 @@ func GetManager() *inertia.Inertia {
 
 // Render renders an Inertia page
 func Render(ctx http.Context, component string, props map[string]interface{}) http.Response {
+	return RenderStatus(ctx, http.StatusOK, component, props)
+}
+
+// RenderStatus renders an Inertia page with an HTTP status other than 200, e.g. a 503 page while
+// the application is down for maintenance
+func RenderStatus(ctx http.Context, status int, component string, props map[string]interface{}) http.Response {
 	// Prepare shared props, including auth user
 	sharedProps := make(map[string]interface{})
 
@@ -171,7 +178,7 @@ func Render(ctx http.Context, component string, props map[string]interface{}) ht
 		// For Inertia requests, return JSON
 		return ctx.Response().Header("X-Inertia", "true").
 			Header("Vary", "Accept").
-			Status(200).
+			Status(status).
 			Json(pageMap)
 	}
 
@@ -185,14 +192,32 @@ func Render(ctx http.Context, component string, props map[string]interface{}) ht
 		return ctx.Response().String(500, "Error preparing page data")
 	}
 
-	return ctx.Response().
-		Header("X-Inertia", "true").
-		Header("Vary", "Accept").
-		View().Make("app.tmpl", map[string]interface{}{
+	viewData := map[string]interface{}{
 		"page":    string(pageJSON),
 		"appName": facades.Config().GetString("app.name", "Goravel"),
 		"isDev":   facades.Config().GetString("app.env", "production") != "production",
-	})
+	}
+	response := ctx.Response().
+		Header("X-Inertia", "true").
+		Header("Vary", "Accept")
+
+	// The framework's views always answer 200, so other statuses render through gin directly
+	if instance, ok := ctx.(interface{ Instance() *gin.Context }); ok && status != http.StatusOK {
+		return &statusView{instance: instance.Instance(), status: status, data: viewData}
+	}
+	return response.View().Make("app.tmpl", viewData)
+}
+
+// statusView renders app.tmpl with its own HTTP status
+type statusView struct {
+	instance *gin.Context
+	status   int
+	data     map[string]interface{}
+}
+
+func (v *statusView) Render() error {
+	v.instance.HTML(v.status, "app.tmpl", v.data)
+	return nil
 }
 
 // getMapKeys returns the keys of a map for debugging
@@ -11,7 +11,12 @@ type Kernel struct {
 // The application's global HTTP middleware stack.
 // These middleware are run during every request to your application.
 func (kernel Kernel) Middleware() []http.Middleware {
-	return []http.Middleware{}
+	return []http.Middleware{
+		middleware.Cors(),
+		middleware.Locale(),
+		middleware.MaintenanceMode(),
+		middleware.BodyLimit(),
+	}
 }
 
 // The application's route middleware groups.
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strings"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+
+	"players/app/auth"
+	"players/app/services"
+)
+
+// ApiKeyAuth authenticates service-to-service calls that send "Authorization: Bearer ak_..." and
+// hands every other request to JwtAuth, so a route group accepts either. A key acts as its owner
+// limited to the key's scopes, which the permission checks further down apply.
+func ApiKeyAuth() contractshttp.Middleware {
+	jwtAuth := JwtAuth()
+	apiKeys := services.NewApiKeyService()
+
+	return func(ctx contractshttp.Context) {
+		scheme, token, _ := strings.Cut(ctx.Request().Header("Authorization", ""), " ")
+		if !strings.EqualFold(scheme, "bearer") || !strings.HasPrefix(token, services.ApiKeyPrefix) {
+			jwtAuth(ctx)
+			return
+		}
+
+		apiKey, err := apiKeys.Authenticate(token)
+		if err != nil {
+			ctx.Request().AbortWithStatusJson(contractshttp.StatusUnauthorized, contractshttp.Json{
+				"success": false,
+				"message": "Invalid API key",
+			})
+			return
+		}
+
+		ctx.WithValue(auth.ApiKeyContextKey, apiKey)
+		ctx.Request().Next()
+	}
+}
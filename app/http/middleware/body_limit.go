@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"players/app/contracts"
+)
+
+// BodyLimit rejects request bodies larger than http.max_request_body with 413 before any handler reads
+// them. Imports and bulk operations use the "import" and "bulk" limits and everything else
+// "default"; a limit of 0 turns the check off. A body that doesn't declare its length is read up
+// to the limit, so a chunked upload can't get past it either.
+func BodyLimit() contractshttp.Middleware {
+	limits := make(map[string]int64)
+	for _, name := range []string{"default", "import", "bulk"} {
+		limits[name] = int64(facades.Config().GetInt("http.max_request_body."+name, 0)) << 10
+	}
+
+	return func(ctx contractshttp.Context) {
+		limit := limits[bodyLimitName(ctx.Request().Path())]
+		request := ctx.Request().Origin()
+		if limit <= 0 || request.Body == nil || request.Body == http.NoBody {
+			ctx.Request().Next()
+			return
+		}
+
+		tooLarge := request.ContentLength > limit
+		if !tooLarge && request.ContentLength < 0 {
+			body, err := io.ReadAll(io.LimitReader(request.Body, limit+1))
+			if err != nil {
+				_ = ctx.Response().Json(http.StatusBadRequest, contracts.ResponseFormat{
+					Success: false,
+					Code:    contracts.ErrorCodeBadRequest,
+					Message: "Failed to read request body",
+				}).Abort()
+				return
+			}
+			tooLarge = int64(len(body)) > limit
+			request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		if tooLarge {
+			_ = ctx.Response().Json(http.StatusRequestEntityTooLarge, contracts.ResponseFormat{
+				Success: false,
+				Code:    contracts.ErrorCodePayloadTooLarge,
+				Message: contracts.Trans(ctx, "responses.payload_too_large", map[string]string{"limit": formatBodySize(limit)}),
+				Details: map[string]interface{}{"max_bytes": limit},
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// bodyLimitName picks the http.max_request_body entry for path. CRUD routes put imports at
+// /api/{resource}/import and bulk operations under /api/{resource}/bulk.
+func bodyLimitName(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) >= 3 && segments[0] == "api" {
+		switch segments[2] {
+		case "import", "bulk":
+			return segments[2]
+		}
+	}
+	return "default"
+}
+
+// formatBodySize writes a byte limit the way the config sets it, e.g. "10 MB" or "512 KB"
+func formatBodySize(size int64) string {
+	if size >= 1<<20 && size%(1<<20) == 0 {
+		return fmt.Sprintf("%d MB", size>>20)
+	}
+	return fmt.Sprintf("%d KB", size>>10)
+}
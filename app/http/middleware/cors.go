@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+	"github.com/rs/cors"
+)
+
+// corsAppliedKey marks a request the policy has already handled, so registering Cors on a route
+// group as well as globally doesn't answer it twice
+const corsAppliedKey = "cors_applied"
+
+var corsWildcardWarning sync.Once
+
+// Cors applies the cross-origin policy in config/cors.go to requests under cors.routes and answers
+// preflight requests with 204. It is registered globally, since preflights must be answered for
+// routes that have no OPTIONS handler, and route groups may add it again to state their policy.
+func Cors() contractshttp.Middleware {
+	return func(ctx contractshttp.Context) {
+		if ctx.Value(corsAppliedKey) != nil || !corsApplies(ctx.Request().Path()) {
+			ctx.Request().Next()
+			return
+		}
+		ctx.WithValue(corsAppliedKey, true)
+
+		config := facades.Config()
+		origins := corsOrigins()
+		options := cors.Options{
+			AllowedOrigins:   origins,
+			AllowedMethods:   configList("cors.allowed_methods"),
+			AllowedHeaders:   configList("cors.allowed_headers"),
+			ExposedHeaders:   configList("cors.exposed_headers"),
+			MaxAge:           config.GetInt("cors.max_age", 0),
+			AllowCredentials: config.GetBool("cors.supports_credentials", false),
+		}
+		if len(origins) == 0 {
+			// An empty list means "*" to rs/cors, so no allowed origins has to be spelled out
+			options.AllowOriginFunc = func(string) bool { return false }
+		}
+		cors.New(options).HandlerFunc(ctx.Response().Writer(), ctx.Request().Origin())
+
+		if ctx.Request().Method() == http.MethodOptions && ctx.Request().Header("Access-Control-Request-Method", "") != "" {
+			ctx.Request().Abort(http.StatusNoContent)
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// corsApplies reports whether path matches one of cors.routes
+func corsApplies(path string) bool {
+	return pathMatches(path, configList("cors.routes"))
+}
+
+// pathMatches reports whether path matches one of patterns, where a trailing "*" matches a prefix.
+// Leading slashes are ignored on both.
+func pathMatches(path string, patterns []string) bool {
+	path = strings.TrimPrefix(path, "/")
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(pattern, "/")
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// corsOrigins is cors.allowed_origins without a bare "*" in production, unless
+// cors.allow_wildcard_in_production opts back in
+func corsOrigins() []string {
+	origins := configList("cors.allowed_origins")
+	config := facades.Config()
+	if config.GetString("app.env", "production") != "production" || config.GetBool("cors.allow_wildcard_in_production", false) {
+		return origins
+	}
+
+	allowed := origins[:0]
+	for _, origin := range origins {
+		if origin == "*" {
+			corsWildcardWarning.Do(func() {
+				facades.Log().Warning("CORS_ALLOWED_ORIGINS contains \"*\", which is ignored in production; list the frontend origins or set CORS_ALLOW_WILDCARD_IN_PRODUCTION=true")
+			})
+			continue
+		}
+		allowed = append(allowed, origin)
+	}
+	return allowed
+}
+
+// configList splits the comma separated config value at key
+func configList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(facades.Config().GetString(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
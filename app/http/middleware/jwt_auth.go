@@ -5,10 +5,16 @@ import (
 	contractshttp "github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
 	"strings"
+
+	"players/app/auth"
+	"players/app/services"
 )
 
-// JwtAuth returns a middleware function that handles JWT authentication.
+// JwtAuth returns a middleware function that handles JWT authentication. Tokens issued for an
+// impersonation are marked on the context so permission checks can limit the session.
 func JwtAuth() contractshttp.Middleware {
+	impersonations := services.NewImpersonationService()
+
 	return func(ctx contractshttp.Context) {
 		authHeader := ctx.Request().Header("Authorization", "")
 		xInertiaHeader := ctx.Request().Header("X-Inertia", "")
@@ -32,6 +38,13 @@ func JwtAuth() contractshttp.Middleware {
 		}
 
 		handleAuthFailure := func(logMessage string) {
+			// Plain XHR callers (axios) get a 401 they can answer by refreshing the session
+			if xInertiaHeader != "true" && ctx.Request().Header("X-Requested-With", "") == "XMLHttpRequest" {
+				ctx.Request().AbortWithStatusJson(contractshttp.StatusUnauthorized, contractshttp.Json{
+					"message": "Unauthenticated",
+				})
+				return
+			}
 			// Log the failure reason if needed, perhaps using facades.Log() once configured
 			if xInertiaHeader == "true" {
 				if ctx.Request().Url() == "/" {
@@ -59,6 +72,9 @@ func JwtAuth() contractshttp.Middleware {
 			handleAuthFailure("Invalid or expired token: " + err.Error())
 			return
 		}
+		if impersonation := impersonations.Active(tokenString); impersonation != nil {
+			ctx.WithValue(auth.ImpersonationContextKey, impersonation)
+		}
 
 		//check if the route is / and redirect to /dashboard
 		if ctx.Request().Url() == "/" {
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"strings"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+// Locale answers each request in the language it asks for: the ?lang= query parameter, or else
+// the first supported language in Accept-Language. Unsupported languages keep app.locale.
+func Locale() contractshttp.Middleware {
+	return func(ctx contractshttp.Context) {
+		supported := make(map[string]bool)
+		for _, locale := range strings.Split(facades.Config().GetString("app.locales", "en"), ",") {
+			if locale = strings.ToLower(strings.TrimSpace(locale)); locale != "" {
+				supported[locale] = true
+			}
+		}
+
+		for _, candidate := range requestedLocales(ctx) {
+			if supported[candidate] {
+				facades.App().SetLocale(ctx, candidate)
+				break
+			}
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// requestedLocales lists the languages the request asks for, most preferred first, reduced to
+// their primary subtag so "en-GB" matches "en". Accept-Language is taken in the order given.
+func requestedLocales(ctx contractshttp.Context) []string {
+	var locales []string
+	if lang := ctx.Request().Query("lang", ""); lang != "" {
+		locales = append(locales, lang)
+	}
+	for _, part := range strings.Split(ctx.Request().Header("Accept-Language", ""), ",") {
+		locales = append(locales, strings.SplitN(part, ";", 2)[0])
+	}
+
+	for i, locale := range locales {
+		locales[i] = strings.ToLower(strings.SplitN(strings.TrimSpace(locale), "-", 2)[0])
+	}
+	return locales
+}
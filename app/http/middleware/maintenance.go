@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"players/app/auth"
+	"players/app/contracts"
+	"players/app/http/inertia"
+	"players/app/services"
+)
+
+// MaintenanceMode answers 503 while the application is down for maintenance (see config/maintenance.go
+// and `artisan down`). API and XHR callers get JSON and page visits get the Maintenance page.
+// Super admins and allowlisted IPs pass through to check the application before it goes back up,
+// and the paths in maintenance.except, such as login, stay up for everyone.
+func MaintenanceMode() contractshttp.Middleware {
+	maintenance := services.NewMaintenanceService()
+
+	return func(ctx contractshttp.Context) {
+		state, down := maintenance.Current()
+		if !down || pathMatches(ctx.Request().Path(), configList("maintenance.except")) || maintenanceBypass(ctx, state) {
+			ctx.Request().Next()
+			return
+		}
+
+		message := state.Message
+		if message == "" {
+			message = contracts.Trans(ctx, "responses.maintenance")
+		}
+		if state.RetryAfter > 0 {
+			ctx.Response().Header("Retry-After", strconv.Itoa(state.RetryAfter))
+		}
+
+		if wantsJSON(ctx) {
+			_ = ctx.Response().Json(contractshttp.StatusServiceUnavailable, contracts.ResponseFormat{
+				Success: false,
+				Code:    contracts.ErrorCodeMaintenance,
+				Message: message,
+			}).Abort()
+			return
+		}
+
+		if err := inertia.RenderStatus(ctx, contractshttp.StatusServiceUnavailable, "Maintenance", map[string]interface{}{
+			"message":    message,
+			"retryAfter": state.RetryAfter,
+		}).Render(); err != nil {
+			facades.Log().Error("Failed to render maintenance page: " + err.Error())
+		}
+		ctx.Request().Abort(contractshttp.StatusServiceUnavailable)
+	}
+}
+
+// maintenanceBypass reports whether the request comes from an allowed IP or from a super admin.
+// It runs before the auth middleware, so the session token is parsed here.
+func maintenanceBypass(ctx contractshttp.Context, state *services.MaintenanceState) bool {
+	ip := clientIP(ctx.Request().Origin().RemoteAddr, ctx.Request().Header("X-Forwarded-For", ""), configList("maintenance.trusted_proxies"))
+	if ipAllowed(ip, append(configList("maintenance.allowed_ips"), state.AllowedIPs...)) {
+		return true
+	}
+
+	token := ctx.Request().Cookie("token")
+	if scheme, bearer, ok := strings.Cut(ctx.Request().Header("Authorization", ""), " "); ok && strings.EqualFold(scheme, "bearer") {
+		token = bearer
+	}
+	if token == "" || strings.HasPrefix(token, services.ApiKeyPrefix) {
+		return false
+	}
+	if _, err := facades.Auth(ctx).Parse(token); err != nil {
+		return false
+	}
+	user := auth.GetPermissionHelper().GetAuthenticatedUser(ctx)
+	return user != nil && user.IsActive && user.IsSuperAdminUser()
+}
+
+// clientIP is the address a request came from: the connection's remote address, or, when that is
+// one of trustedProxies, the rightmost X-Forwarded-For hop that isn't. gin's ClientIP trusts the
+// header from any peer, so an allowlist checked against it could be bypassed with a forged header.
+func clientIP(remoteAddr, forwardedFor string, trustedProxies []string) string {
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		ip = host
+	}
+	if forwardedFor == "" || !ipAllowed(ip, trustedProxies) {
+		return ip
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		ip = hop
+		if !ipAllowed(hop, trustedProxies) {
+			break
+		}
+	}
+	return ip
+}
+
+// ipAllowed reports whether ip is one of allowed, each an address or a CIDR range
+func ipAllowed(ip string, allowed []string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, entry := range allowed {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if network.Contains(addr) {
+				return true
+			}
+		} else if other := net.ParseIP(entry); other != nil && other.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// wantsJSON reports whether the caller is an API client or script rather than a page visit
+func wantsJSON(ctx contractshttp.Context) bool {
+	if ctx.Request().Header("X-Inertia", "") == "true" {
+		return false
+	}
+	return strings.HasPrefix(ctx.Request().Path(), "/api/") ||
+		ctx.Request().Header("X-Requested-With", "") == "XMLHttpRequest" ||
+		strings.Contains(ctx.Request().Header("Accept", ""), "application/json")
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import "testing"
+
+func TestClientIPIgnoresForwardedForFromUntrustedPeers(t *testing.T) {
+	proxies := []string{"10.0.0.0/8"}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		proxies      []string
+		want         string
+	}{
+		{"direct client", "198.51.100.4:5123", "", nil, "198.51.100.4"},
+		{"forged header without proxies", "198.51.100.4:5123", "203.0.113.7", nil, "198.51.100.4"},
+		{"forged header from an untrusted peer", "198.51.100.4:5123", "203.0.113.7", proxies, "198.51.100.4"},
+		{"client behind a trusted proxy", "10.0.0.2:80", "198.51.100.4", proxies, "198.51.100.4"},
+		{"forged hop prepended by the client", "10.0.0.2:80", "203.0.113.7, 198.51.100.4", proxies, "198.51.100.4"},
+		{"chain of trusted proxies", "10.0.0.2:80", "198.51.100.4, 10.0.0.9", proxies, "198.51.100.4"},
+		{"garbage hop", "10.0.0.2:80", "not-an-ip", proxies, "10.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clientIP(tt.remoteAddr, tt.forwardedFor, tt.proxies); got != tt.want {
+				t.Errorf("clientIP(%q, %q) = %q, want %q", tt.remoteAddr, tt.forwardedFor, got, tt.want)
+			}
+		})
+	}
+}
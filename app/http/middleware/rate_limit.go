@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+// RateLimit limits requests per client IP with a token bucket configured under
+// http.rate_limit.{name}: each IP may burst up to "requests" and regains that many
+// every "window" seconds. Requests over the limit get 429 with a Retry-After header.
+func RateLimit(name string) contractshttp.Middleware {
+	requests := facades.Config().GetInt("http.rate_limit."+name+".requests", 60)
+	window := facades.Config().GetInt("http.rate_limit."+name+".window", 60)
+	limiter := newRateLimiter(requests, time.Duration(window)*time.Second)
+	go limiter.run(time.NewTicker(limiter.window).C)
+
+	return func(ctx contractshttp.Context) {
+		allowed, remaining, retryAfter := limiter.take(ctx.Request().Ip(), time.Now())
+
+		ctx.Response().Header("X-RateLimit-Limit", strconv.Itoa(requests))
+		ctx.Response().Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			ctx.Response().Header("Retry-After", strconv.Itoa(seconds))
+			ctx.Request().AbortWithStatusJson(contractshttp.StatusTooManyRequests, contractshttp.Json{
+				"success": false,
+				"message": "Too many requests, retry in " + strconv.Itoa(seconds) + " seconds",
+			})
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// tokenBucket is one client's remaining tokens as of last
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps a token bucket per key, refilled continuously at capacity per window
+type rateLimiter struct {
+	mu        sync.Mutex
+	capacity  float64
+	perSecond float64
+	window    time.Duration
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(requests int, window time.Duration) *rateLimiter {
+	if requests <= 0 {
+		requests = 1
+	}
+	if window <= 0 {
+		window = time.Minute
+	}
+	return &rateLimiter{
+		capacity:  float64(requests),
+		perSecond: float64(requests) / window.Seconds(),
+		window:    window,
+		buckets:   make(map[string]*tokenBucket),
+	}
+}
+
+// take spends a token for key, returning whether the request is allowed, the whole tokens
+// left, and how long until the next token when it is not
+func (l *rateLimiter) take(key string, now time.Time) (bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.capacity, last: now}
+		l.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.last).Seconds()
+	bucket.tokens = math.Min(l.capacity, bucket.tokens+elapsed*l.perSecond)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.perSecond * float64(time.Second))
+		return false, 0, wait
+	}
+
+	bucket.tokens--
+	return true, int(bucket.tokens), 0
+}
+
+// run sweeps on every tick, so buckets are dropped once idle even when no request comes in to
+// trigger the sweep in take
+func (l *rateLimiter) run(tick <-chan time.Time) {
+	for now := range tick {
+		l.mu.Lock()
+		l.sweep(now)
+		l.mu.Unlock()
+	}
+}
+
+// sweep drops buckets idle for a full window, which would be full again anyway
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= l.window {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
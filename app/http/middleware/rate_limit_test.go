@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterRefusesAtTheLimitAndRefills(t *testing.T) {
+	limiter := newRateLimiter(3, time.Minute)
+	start := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	for want := 2; want >= 0; want-- {
+		allowed, remaining, _ := limiter.take("10.0.0.1", start)
+		if !allowed || remaining != want {
+			t.Fatalf("take() = %v, %d remaining, want allowed with %d remaining", allowed, remaining, want)
+		}
+	}
+
+	// One token comes back every 20s, so the fourth request waits exactly that long
+	allowed, remaining, retryAfter := limiter.take("10.0.0.1", start)
+	if allowed || remaining != 0 || retryAfter != 20*time.Second {
+		t.Fatalf("take() at the limit = %v, %d remaining, retry after %v, want refused for 20s", allowed, remaining, retryAfter)
+	}
+
+	// Other clients have their own bucket
+	if allowed, _, _ := limiter.take("10.0.0.2", start); !allowed {
+		t.Errorf("take() for another client was refused")
+	}
+
+	if allowed, _, _ := limiter.take("10.0.0.1", start.Add(19*time.Second)); allowed {
+		t.Errorf("take() before a token refilled was allowed")
+	}
+	if allowed, remaining, _ := limiter.take("10.0.0.1", start.Add(20*time.Second)); !allowed || remaining != 0 {
+		t.Errorf("take() after one token refilled = %v, %d remaining, want allowed with 0 remaining", allowed, remaining)
+	}
+
+	// A bucket idle for the whole window is dropped and starts full again
+	later := start.Add(20*time.Second + time.Minute)
+	if allowed, remaining, _ := limiter.take("10.0.0.1", later); !allowed || remaining != 2 {
+		t.Errorf("take() after the window = %v, %d remaining, want allowed with 2 remaining", allowed, remaining)
+	}
+	if _, ok := limiter.buckets["10.0.0.2"]; ok {
+		t.Errorf("idle bucket for 10.0.0.2 was not swept after the window")
+	}
+}
+
+func TestRateLimiterEvictsIdleBucketsWithoutTraffic(t *testing.T) {
+	limiter := newRateLimiter(3, time.Minute)
+	start := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	limiter.take("10.0.0.1", start)
+	limiter.take("10.0.0.2", start.Add(30*time.Second))
+
+	tick := make(chan time.Time)
+	done := make(chan struct{})
+	go func() {
+		limiter.run(tick)
+		close(done)
+	}()
+	tick <- start.Add(time.Minute)
+	close(tick)
+	<-done
+
+	if _, ok := limiter.buckets["10.0.0.1"]; ok {
+		t.Errorf("bucket idle for a window was not evicted")
+	}
+	if _, ok := limiter.buckets["10.0.0.2"]; !ok {
+		t.Errorf("bucket used within the window was evicted")
+	}
+}
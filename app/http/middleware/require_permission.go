@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	contractshttp "github.com/goravel/framework/contracts/http"
+
+	"players/app/auth"
+)
+
+// RequirePermission lets a request through only when the authenticated user holds permission,
+// written in either slug style. Routes declare it instead of checking at the top of each handler:
+//
+//	router.Middleware(middleware.RequirePermission("roles.assign")).Post("/roles/{id}/users", ...)
+//
+// Missing or deactivated users get 401 and users without the permission get 403.
+func RequirePermission(permission string) contractshttp.Middleware {
+	return func(ctx contractshttp.Context) {
+		user, err := auth.GetPermissionHelper().RequireAuthentication(ctx)
+		if err != nil {
+			ctx.Request().AbortWithStatusJson(contractshttp.StatusUnauthorized, contractshttp.Json{
+				"success": false,
+				"message": "Authentication required",
+			})
+			return
+		}
+
+		if !auth.GetPermissionService().HasPermission(user, permission) {
+			ctx.Request().AbortWithStatusJson(contractshttp.StatusForbidden, contractshttp.Json{
+				"success": false,
+				"message": "Insufficient permissions: " + permission + " required",
+			})
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
@@ -3,21 +3,24 @@ package requests
 import (
 	"fmt"
 	"players/app/contracts"
-	"strings"
+	"players/app/models"
 
 	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
 )
 
 // BookCreateRequest handles book creation validation
 type BookCreateRequest struct {
-	Title       string   `form:"title" json:"title"`
-	Author      string   `form:"author" json:"author"`
-	ISBN        string   `form:"isbn" json:"isbn"`
-	Description string   `form:"description" json:"description"`
-	Price       float64  `form:"price" json:"price"`
-	Status      string   `form:"status" json:"status"`
-	PublishedAt string   `form:"publishedAt" json:"publishedAt"`
-	Tags        []string `form:"tags" json:"tags"`
+	Title       string                 `form:"title" json:"title"`
+	Author      string                 `form:"author" json:"author"`
+	ISBN        string                 `form:"isbn" json:"isbn"`
+	Description string                 `form:"description" json:"description"`
+	Price       float64                `form:"price" json:"price"`
+	Status      string                 `form:"status" json:"status"`
+	PublishedAt string                 `form:"publishedAt" json:"publishedAt"`
+	Tags        []string               `form:"tags" json:"tags"`
+	CategoryID  *uint                  `form:"categoryId" json:"categoryId"`
+	Metadata    map[string]interface{} `form:"metadata" json:"metadata"`
 }
 
 // Rules defines validation rules for book creation
@@ -25,46 +28,40 @@ func (r *BookCreateRequest) Rules(ctx http.Context) map[string]string {
 	rules := map[string]string{
 		"title":       fmt.Sprintf("%s|%s", contracts.Required, fmt.Sprintf(contracts.MaxLength, 255)),
 		"author":      fmt.Sprintf("%s|%s", contracts.Required, fmt.Sprintf(contracts.MaxLength, 100)),
-		"isbn":        fmt.Sprintf("%s|%s", contracts.Required, fmt.Sprintf(contracts.Regex, "^[0-9-]{10,17}$")),
+		"isbn":        fmt.Sprintf("%s|%s", contracts.Required, contracts.ISBN),
 		"description": fmt.Sprintf(contracts.MaxLength, 1000),
 		"price":       fmt.Sprintf("%s|%s|%s", contracts.Required, contracts.Numeric, fmt.Sprintf(contracts.MinValue, 0)),
 		"status":      fmt.Sprintf("in:%s", "AVAILABLE,BORROWED,MAINTENANCE"),
 		"publishedAt": contracts.Date,
 		"tags":        fmt.Sprintf("%s|%s", contracts.Array, fmt.Sprintf(contracts.ArrayMax, 10)),
 		"tags.*":      fmt.Sprintf(contracts.MaxLength, 50),
+		"metadata":    contracts.Map,
+	}
+	if r.CategoryID != nil && *r.CategoryID != 0 {
+		rules["categoryId"] = fmt.Sprintf(contracts.Exists, "categories", "id")
 	}
 	
 	return rules
 }
 
-// Messages defines custom validation messages
+// Messages defines custom validation messages, translated from lang/<locale>/validation.json
 func (r *BookCreateRequest) Messages(ctx http.Context) map[string]string {
-	return map[string]string{
-		"title.required":      "Book title is required",
-		"title.max":           "Book title cannot exceed 255 characters",
-		"author.required":     "Author name is required",
-		"author.max":          "Author name cannot exceed 100 characters",
-		"isbn.required":       "ISBN is required",
-		"isbn.regex":          "ISBN must be 10-13 digits",
-		"isbn.unique":         "This ISBN already exists",
-		"price.required":      "Price is required",
-		"price.numeric":       "Price must be a valid number",
-		"price.min":           "Price must be greater than or equal to 0",
-		"status.in":           "Status must be one of: AVAILABLE, BORROWED, MAINTENANCE",
-		"publishedAt.date":    "Published date must be a valid date",
-		"publishedAt.before":  "Published date cannot be in the future",
-		"tags.array":          "Tags must be an array",
-		"tags.max":            "Maximum 10 tags allowed",
-		"tags.*.max":          "Each tag cannot exceed 50 characters",
-	}
+	return contracts.ValidationMessages(ctx, "books",
+		"title.required", "title.max",
+		"author.required", "author.max",
+		"isbn.required", "isbn.isbn", "isbn.unique",
+		"price.required", "price.numeric", "price.min",
+		"status.in",
+		"publishedAt.date", "publishedAt.before",
+		"tags.array", "tags.max", "tags.*.max",
+		"categoryId.exists",
+		"metadata.map",
+	)
 }
 
-// Attributes defines custom attribute names
+// Attributes defines custom attribute names, translated from lang/<locale>/attributes.json
 func (r *BookCreateRequest) Attributes(ctx http.Context) map[string]string {
-	return map[string]string{
-		"publishedAt": "publication date",
-		"isbn":        "ISBN number",
-	}
+	return contracts.ValidationAttributes(ctx, "books", "publishedAt", "isbn")
 }
 
 // Authorize determines if the user is authorized to make this request
@@ -75,17 +72,17 @@ func (r *BookCreateRequest) Authorize(ctx http.Context) error {
 }
 
 // PrepareForValidation allows modification of input before validation
-func (r *BookCreateRequest) PrepareForValidation(ctx http.Context) error {
+func (r *BookCreateRequest) PrepareForValidation(ctx http.Context, data validation.Data) error {
 	// Example: Normalize ISBN by removing hyphens
-	if r.ISBN != "" {
-		// Remove common ISBN separators
-		r.ISBN = strings.ReplaceAll(r.ISBN, "-", "")
-		r.ISBN = strings.ReplaceAll(r.ISBN, " ", "")
+	if isbn, exists := data.Get("isbn"); exists {
+		if isbnStr, ok := isbn.(string); ok {
+			data.Set("isbn", models.NormalizeISBN(isbnStr))
+		}
 	}
 
 	// Set default status if not provided
-	if r.Status == "" {
-		r.Status = "AVAILABLE"
+	if status, exists := data.Get("status"); !exists || status == "" {
+		data.Set("status", "AVAILABLE")
 	}
 
 	return nil
@@ -117,20 +114,34 @@ func (r *BookCreateRequest) ToCreateData() map[string]interface{} {
 		data["tags"] = r.Tags
 	}
 
+	if r.CategoryID != nil && *r.CategoryID != 0 {
+		data["categoryId"] = *r.CategoryID
+	}
+
+	if r.Metadata != nil {
+		data["metadata"] = r.Metadata
+	}
+
 	return data
 }
 
 // BookUpdateRequest handles book update validation
 type BookUpdateRequest struct {
-	Title       *string   `form:"title" json:"title"`
-	Author      *string   `form:"author" json:"author"`
-	ISBN        *string   `form:"isbn" json:"isbn"`
-	Description *string   `form:"description" json:"description"`
-	Price       *float64  `form:"price" json:"price"`
-	Status      *string   `form:"status" json:"status"`
-	PublishedAt *string   `form:"publishedAt" json:"publishedAt"`
-	Tags        *[]string `form:"tags" json:"tags"`
-	ID          uint      `form:"-" json:"-"` // Set by controller
+	Title       *string                `form:"title" json:"title"`
+	Author      *string                `form:"author" json:"author"`
+	ISBN        *string                `form:"isbn" json:"isbn"`
+	Description *string                `form:"description" json:"description"`
+	Price       *float64               `form:"price" json:"price"`
+	Status      *string                `form:"status" json:"status"`
+	PublishedAt *string                `form:"publishedAt" json:"publishedAt"`
+	Tags        *[]string              `form:"tags" json:"tags"`
+	CategoryID  *uint                  `form:"categoryId" json:"categoryId"` // 0 removes the category
+	Metadata    map[string]interface{} `form:"metadata" json:"metadata"` // null clears the document
+	ID          uint                   `form:"-" json:"-"` // Set by controller
+
+	// metadataSent records whether the body had a metadata key at all, since Metadata is nil both
+	// when it was left out and when it was sent as null
+	metadataSent bool
 }
 
 // Rules defines validation rules for book updates
@@ -149,7 +160,7 @@ func (r *BookUpdateRequest) Rules(ctx http.Context) map[string]string {
 	}
 	if r.ISBN != nil {
 		// Fix unique validation to exclude current record
-		rules["isbn"] = fmt.Sprintf("%s|unique:books,isbn,%s", fmt.Sprintf(contracts.Regex, "^[0-9]{10,13}$"), bookID)
+		rules["isbn"] = fmt.Sprintf("%s|unique:books,isbn,%s", contracts.ISBN, bookID)
 	}
 	if r.Description != nil {
 		rules["description"] = fmt.Sprintf(contracts.MaxLength, 1000)
@@ -167,6 +178,12 @@ func (r *BookUpdateRequest) Rules(ctx http.Context) map[string]string {
 		rules["tags"] = fmt.Sprintf("%s|%s", contracts.Array, fmt.Sprintf(contracts.ArrayMax, 10))
 		rules["tags.*"] = fmt.Sprintf(contracts.MaxLength, 50)
 	}
+	if r.CategoryID != nil && *r.CategoryID != 0 {
+		rules["categoryId"] = fmt.Sprintf(contracts.Exists, "categories", "id")
+	}
+	if r.Metadata != nil {
+		rules["metadata"] = contracts.Map
+	}
 
 	// If no rules were added, add a dummy rule to prevent empty rules error
 	if len(rules) == 0 {
@@ -178,28 +195,22 @@ func (r *BookUpdateRequest) Rules(ctx http.Context) map[string]string {
 
 // Messages defines custom validation messages for updates
 func (r *BookUpdateRequest) Messages(ctx http.Context) map[string]string {
-	return map[string]string{
-		"title.max":           "Book title cannot exceed 255 characters",
-		"author.max":          "Author name cannot exceed 100 characters",
-		"isbn.regex":          "ISBN must be 10-13 digits",
-		"isbn.unique":         "This ISBN already exists",
-		"price.numeric":       "Price must be a valid number",
-		"price.min":           "Price must be greater than or equal to 0",
-		"status.in":           "Status must be one of: AVAILABLE, BORROWED, MAINTENANCE",
-		"publishedAt.date":    "Published date must be a valid date",
-		"publishedAt.before":  "Published date cannot be in the future",
-		"tags.array":          "Tags must be an array",
-		"tags.max":            "Maximum 10 tags allowed",
-		"tags.*.max":          "Each tag cannot exceed 50 characters",
-	}
+	return contracts.ValidationMessages(ctx, "books",
+		"title.max",
+		"author.max",
+		"isbn.isbn", "isbn.unique",
+		"price.numeric", "price.min",
+		"status.in",
+		"publishedAt.date", "publishedAt.before",
+		"tags.array", "tags.max", "tags.*.max",
+		"categoryId.exists",
+		"metadata.map",
+	)
 }
 
 // Attributes defines custom attribute names for updates
 func (r *BookUpdateRequest) Attributes(ctx http.Context) map[string]string {
-	return map[string]string{
-		"publishedAt": "publication date",
-		"isbn":        "ISBN number",
-	}
+	return contracts.ValidationAttributes(ctx, "books", "publishedAt", "isbn")
 }
 
 // Authorize determines if the user is authorized to update this book
@@ -210,12 +221,14 @@ func (r *BookUpdateRequest) Authorize(ctx http.Context) error {
 }
 
 // PrepareForValidation allows modification of input before validation
-func (r *BookUpdateRequest) PrepareForValidation(ctx http.Context) error {
+func (r *BookUpdateRequest) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	_, r.metadataSent = data.Get("metadata")
+
 	// Normalize ISBN if provided
-	if r.ISBN != nil && *r.ISBN != "" {
-		normalized := strings.ReplaceAll(*r.ISBN, "-", "")
-		normalized = strings.ReplaceAll(normalized, " ", "")
-		r.ISBN = &normalized
+	if isbn, exists := data.Get("isbn"); exists {
+		if isbnStr, ok := isbn.(string); ok && isbnStr != "" {
+			data.Set("isbn", models.NormalizeISBN(isbnStr))
+		}
 	}
 
 	return nil
@@ -255,6 +268,16 @@ func (r *BookUpdateRequest) ToUpdateData() map[string]interface{} {
 	if r.Tags != nil {
 		data["tags"] = *r.Tags
 	}
+	if r.CategoryID != nil {
+		data["categoryId"] = *r.CategoryID
+	}
+	if r.metadataSent {
+		if r.Metadata != nil {
+			data["metadata"] = r.Metadata
+		} else {
+			data["metadata"] = nil
+		}
+	}
 
 	return data
 }
@@ -262,4 +285,5 @@ func (r *BookUpdateRequest) ToUpdateData() map[string]interface{} {
 // GetResourceID returns the resource ID for update
 func (r *BookUpdateRequest) GetResourceID() interface{} {
 	return r.ID
-}
\ No newline at end of file
+}
+
@@ -0,0 +1,51 @@
+package requests
+
+import (
+	"reflect"
+	"testing"
+)
+
+// bodyData is the request body as PrepareForValidation sees it
+type bodyData map[string]any
+
+func (d bodyData) Get(key string) (any, bool) {
+	value, ok := d[key]
+	return value, ok
+}
+
+func (d bodyData) Set(key string, value any) error {
+	d[key] = value
+	return nil
+}
+
+// TestBookUpdateMetadataTellsNullFromAbsent binds bodies the way ValidateRequest does: metadata
+// sent as null must clear the document, while leaving it out must not touch it
+func TestBookUpdateMetadataTellsNullFromAbsent(t *testing.T) {
+	edition := map[string]interface{}{"edition": "2nd"}
+
+	tests := []struct {
+		name     string
+		body     bodyData
+		metadata map[string]interface{} // what binding leaves in Metadata
+		want     map[string]interface{}
+	}{
+		{"absent", bodyData{"title": "Dune"}, nil, map[string]interface{}{}},
+		{"null", bodyData{"metadata": nil}, nil, map[string]interface{}{"metadata": nil}},
+		{"document", bodyData{"metadata": edition}, edition, map[string]interface{}{"metadata": edition}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &BookUpdateRequest{}
+			if err := request.PrepareForValidation(nil, tt.body); err != nil {
+				t.Fatalf("PrepareForValidation: %v", err)
+			}
+			request.Metadata = tt.metadata
+
+			data := request.ToUpdateData()
+			if !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("ToUpdateData() = %#v, want %#v", data, tt.want)
+			}
+		})
+	}
+}
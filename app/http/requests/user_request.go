@@ -1,10 +1,17 @@
 package requests
 
 import (
+	"fmt"
+
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/contracts/validation"
+
+	"players/app/contracts"
 )
 
+// userAttributes are the fields with display names in lang/<locale>/attributes.json
+var userAttributes = []string{"name", "email", "password", "is_active", "is_super_admin", "role_id"}
+
 // UserCreateRequest handles validation for creating users
 type UserCreateRequest struct {
 	Name         string `form:"name" json:"name"`
@@ -13,6 +20,9 @@ type UserCreateRequest struct {
 	IsActive     bool   `form:"is_active" json:"is_active"`
 	IsSuperAdmin bool   `form:"is_super_admin" json:"is_super_admin"`
 	RoleID       uint   `form:"role_id" json:"role_id"`
+
+	// SendVerification asks the new user to confirm their email instead of trusting the admin
+	SendVerification bool `form:"send_verification" json:"send_verification"`
 }
 
 // Authorize determines if the user can make this request
@@ -24,40 +34,29 @@ func (r *UserCreateRequest) Authorize(ctx http.Context) error {
 // Rules returns the validation rules for the request
 func (r *UserCreateRequest) Rules(ctx http.Context) map[string]string {
 	return map[string]string{
-		"name":           "required|string|max:255|min:2",
-		"email":          "required|email|max:255",
-		"password":       "required|string|min:8",
-		"is_active":      "boolean",
-		"is_super_admin": "boolean",
-		"role_id":        "numeric",
+		"name":              "required|string|" + fmt.Sprintf(contracts.MinLen, 2) + "|" + fmt.Sprintf(contracts.MaxLen, 255),
+		"email":             "required|email|" + fmt.Sprintf(contracts.MaxLen, 255) + "|" + fmt.Sprintf(contracts.Unique, "users", "email"),
+		"password":          "required|string",
+		"is_active":         "boolean",
+		"is_super_admin":    "boolean",
+		"role_id":           "numeric",
+		"send_verification": "boolean",
 	}
 }
 
-// Messages returns custom validation messages
+// Messages returns custom validation messages, translated from lang/<locale>/validation.json
 func (r *UserCreateRequest) Messages(ctx http.Context) map[string]string {
-	return map[string]string{
-		"name.required":     "User name is required",
-		"name.min":          "User name must be at least 2 characters",
-		"name.max":          "User name cannot exceed 255 characters",
-		"email.required":    "Email address is required",
-		"email.email":       "Invalid email format",
-		"email.max":         "Email cannot exceed 255 characters",
-		"password.required": "Password is required",
-		"password.min":      "Password must be at least 8 characters",
-		"role_id.numeric":   "Invalid role ID",
-	}
+	return contracts.ValidationMessages(ctx, "users",
+		"name.required", "name.min_len", "name.max_len",
+		"email.required", "email.email", "email.max_len", "email.unique",
+		"password.required",
+		"role_id.numeric",
+	)
 }
 
-// Attributes returns custom attribute names for validation
+// Attributes returns custom attribute names, translated from lang/<locale>/attributes.json
 func (r *UserCreateRequest) Attributes(ctx http.Context) map[string]string {
-	return map[string]string{
-		"name":           "Full Name",
-		"email":          "Email Address",
-		"password":       "Password",
-		"is_active":      "Active Status",
-		"is_super_admin": "Super Admin Status",
-		"role_id":        "Role",
-	}
+	return contracts.ValidationAttributes(ctx, "users", userAttributes...)
 }
 
 // PrepareForValidation allows you to modify the data before validation
@@ -80,6 +79,8 @@ func (r *UserCreateRequest) ToCreateData() map[string]interface{} {
 		"password":       r.Password,
 		"is_active":      r.IsActive,
 		"is_super_admin": r.IsSuperAdmin,
+		// Only admins create users through this request, so the email counts as verified
+		"email_verified": !r.SendVerification,
 	}
 	
 	if r.RoleID > 0 {
@@ -95,8 +96,8 @@ type UserUpdateRequest struct {
 	Name         string `form:"name" json:"name"`
 	Email        string `form:"email" json:"email"`
 	Password     string `form:"password" json:"password"`
-	IsActive     bool   `form:"is_active" json:"is_active"`
-	IsSuperAdmin bool   `form:"is_super_admin" json:"is_super_admin"`
+	IsActive     *bool  `form:"is_active" json:"is_active"`
+	IsSuperAdmin *bool  `form:"is_super_admin" json:"is_super_admin"`
 	RoleID       uint   `form:"role_id" json:"role_id"`
 }
 
@@ -109,9 +110,9 @@ func (r *UserUpdateRequest) Authorize(ctx http.Context) error {
 // Rules returns the validation rules for the request
 func (r *UserUpdateRequest) Rules(ctx http.Context) map[string]string {
 	return map[string]string{
-		"name":           "string|max:255|min:2",
-		"email":          "email|max:255",
-		"password":       "string|min:8",
+		"name":           "string|" + fmt.Sprintf(contracts.MinLen, 2) + "|" + fmt.Sprintf(contracts.MaxLen, 255),
+		"email":          "email|" + fmt.Sprintf(contracts.MaxLen, 255) + "|" + fmt.Sprintf(contracts.UniqueExcept, "users", "email", ctx.Request().Route("id")),
+		"password":       "string",
 		"is_active":      "boolean",
 		"is_super_admin": "boolean",
 		"role_id":        "numeric",
@@ -120,26 +121,16 @@ func (r *UserUpdateRequest) Rules(ctx http.Context) map[string]string {
 
 // Messages returns custom validation messages
 func (r *UserUpdateRequest) Messages(ctx http.Context) map[string]string {
-	return map[string]string{
-		"name.min":        "User name must be at least 2 characters",
-		"name.max":        "User name cannot exceed 255 characters",
-		"email.email":     "Invalid email format",
-		"email.max":       "Email cannot exceed 255 characters",
-		"password.min":    "Password must be at least 8 characters",
-		"role_id.numeric": "Invalid role ID",
-	}
+	return contracts.ValidationMessages(ctx, "users",
+		"name.min_len", "name.max_len",
+		"email.email", "email.max_len", "email.unique",
+		"role_id.numeric",
+	)
 }
 
 // Attributes returns custom attribute names for validation
 func (r *UserUpdateRequest) Attributes(ctx http.Context) map[string]string {
-	return map[string]string{
-		"name":           "Full Name",
-		"email":          "Email Address",
-		"password":       "Password",
-		"is_active":      "Active Status",
-		"is_super_admin": "Super Admin Status",
-		"role_id":        "Role",
-	}
+	return contracts.ValidationAttributes(ctx, "users", userAttributes...)
 }
 
 // PrepareForValidation allows you to modify the data before validation
@@ -161,9 +152,13 @@ func (r *UserUpdateRequest) ToUpdateData() map[string]interface{} {
 	if r.Password != "" {
 		data["password"] = r.Password
 	}
-	// Always include boolean fields for updates
-	data["is_active"] = r.IsActive
-	data["is_super_admin"] = r.IsSuperAdmin
+	// Booleans are only included when sent, so an omitted flag keeps its current value
+	if r.IsActive != nil {
+		data["is_active"] = *r.IsActive
+	}
+	if r.IsSuperAdmin != nil {
+		data["is_super_admin"] = *r.IsSuperAdmin
+	}
 	
 	if r.RoleID > 0 {
 		data["role_id"] = float64(r.RoleID)
@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"time"
+
+	"players/app/models"
+)
+
+// BookLoanResource is the API representation of a loan, with the book and a summary of the borrower
+type BookLoanResource struct {
+	ID          uint              `json:"id"`
+	BookID      uint              `json:"book_id"`
+	UserID      uint              `json:"user_id"`
+	BorrowedAt  time.Time         `json:"borrowed_at"`
+	DueAt       time.Time         `json:"due_at"`
+	ReturnedAt  *time.Time        `json:"returned_at,omitempty"`
+	DaysOverdue int               `json:"days_overdue"`
+	Book        *BookResource     `json:"book,omitempty"`
+	Borrower    *BorrowerResource `json:"borrower,omitempty"`
+}
+
+// BorrowerResource is the part of a user a loan exposes
+type BorrowerResource struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// NewBookLoanResource builds the API representation of loan; DaysOverdue counts whole days past DueAt
+func NewBookLoanResource(loan *models.BookLoan) *BookLoanResource {
+	resource := &BookLoanResource{
+		ID:         loan.ID,
+		BookID:     loan.BookID,
+		UserID:     loan.UserID,
+		BorrowedAt: loan.BorrowedAt,
+		DueAt:      loan.DueAt,
+		ReturnedAt: loan.ReturnedAt,
+	}
+	if loan.IsOverdue(time.Now()) {
+		resource.DaysOverdue = int(time.Since(loan.DueAt).Hours() / 24)
+	}
+	if loan.Book != nil {
+		resource.Book = NewBookResource(loan.Book)
+	}
+	if loan.User != nil {
+		resource.Borrower = &BorrowerResource{ID: loan.User.ID, Name: loan.User.Name, Email: loan.User.Email}
+	}
+	return resource
+}
+
+// BookLoan is the Transformer for loans returned by BookService; other values pass through unchanged
+func BookLoan(record interface{}) interface{} {
+	switch loan := record.(type) {
+	case *models.BookLoan:
+		if loan != nil {
+			return NewBookLoanResource(loan)
+		}
+	case models.BookLoan:
+		return NewBookLoanResource(&loan)
+	}
+	return record
+}
@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"time"
+
+	"players/app/models"
+)
+
+// BookResource is the API representation of a book
+type BookResource struct {
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	Author      string            `json:"author"`
+	ISBN        string            `json:"isbn"`
+	Description string            `json:"description"`
+	Price       float64           `json:"price"`
+	Status      string            `json:"status"`
+	PublishedAt string            `json:"publishedAt"`
+	Tags        string            `json:"tags"`
+	CategoryID  *uint             `json:"categoryId"`
+	Category    *CategoryResource `json:"category,omitempty"`
+	Metadata    models.JSON       `json:"metadata"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	DeletedAt   *time.Time        `json:"deletedAt,omitempty"`
+}
+
+// CategoryResource is the part of a category a book exposes
+type CategoryResource struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// NewBookResource builds the API representation of book; Category is set when it was eager loaded
+func NewBookResource(book *models.Book) *BookResource {
+	resource := &BookResource{
+		ID:          book.ID,
+		Title:       book.Title,
+		Author:      book.Author,
+		ISBN:        book.ISBN,
+		Description: book.Description,
+		Price:       book.Price,
+		Status:      book.Status,
+		PublishedAt: book.PublishedAt,
+		Tags:        book.Tags,
+		CategoryID:  book.CategoryID,
+		Metadata:    book.Metadata,
+		CreatedAt:   book.CreatedAt,
+		UpdatedAt:   book.UpdatedAt,
+		DeletedAt:   book.DeletedAt,
+	}
+	if book.Category != nil {
+		resource.Category = &CategoryResource{ID: book.Category.ID, Name: book.Category.Name, Slug: book.Category.Slug}
+	}
+	return resource
+}
+
+// Book is the Transformer for records returned by BookService; other values pass through unchanged
+func Book(record interface{}) interface{} {
+	switch book := record.(type) {
+	case *models.Book:
+		if book != nil {
+			return NewBookResource(book)
+		}
+	case models.Book:
+		return NewBookResource(&book)
+	}
+	return record
+}
@@ -0,0 +1,15 @@
+// Package resources shapes models for API output. Each resource whitelists the fields
+// an endpoint may expose, so new model columns stay private until they are added here.
+package resources
+
+// Transformer turns a record returned by a service into its API representation
+type Transformer func(record interface{}) interface{}
+
+// Collection applies transform to every record of a list, e.g. PaginatedResult.Data
+func Collection(records []interface{}, transform Transformer) []interface{} {
+	transformed := make([]interface{}, len(records))
+	for i, record := range records {
+		transformed[i] = transform(record)
+	}
+	return transformed
+}
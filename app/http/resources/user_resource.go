@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"time"
+
+	"github.com/goravel/framework/support/carbon"
+
+	"players/app/models"
+)
+
+// UserResource is the API representation of a user. The password hash and the
+// legacy role column are never serialized.
+type UserResource struct {
+	ID            uint            `json:"id"`
+	Name          string          `json:"name"`
+	Email         string          `json:"email"`
+	IsActive      bool            `json:"is_active"`
+	IsSuperAdmin  bool            `json:"is_super_admin"`
+	EmailVerified bool            `json:"email_verified"`
+	VerifiedAt    *time.Time      `json:"email_verified_at,omitempty"`
+	LastLoginAt   *time.Time      `json:"last_login_at,omitempty"`
+	FailedLogins  int             `json:"failed_login_attempts"`
+	LockedUntil   *time.Time      `json:"locked_until,omitempty"`
+	Roles         []RoleResource  `json:"roles,omitempty"`
+	CreatedAt     carbon.DateTime `json:"created_at"`
+	UpdatedAt     carbon.DateTime `json:"updated_at"`
+	DeletedAt     *time.Time      `json:"deleted_at,omitempty"`
+}
+
+// RoleResource is the summary of a role embedded in other resources
+type RoleResource struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	Level       int    `json:"level"`
+}
+
+// AuthorResource is the summary of the user who created or last updated a record
+type AuthorResource struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// NewAuthorResource summarizes user, or returns nil when the relation is empty or wasn't loaded
+func NewAuthorResource(user *models.User) *AuthorResource {
+	if user == nil || user.ID == 0 {
+		return nil
+	}
+	return &AuthorResource{ID: user.ID, Name: user.Name, Email: user.Email}
+}
+
+// NewUserResource builds the API representation of user
+func NewUserResource(user *models.User) *UserResource {
+	resource := &UserResource{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		IsActive:      user.IsActive,
+		IsSuperAdmin:  user.IsSuperAdmin,
+		EmailVerified: user.EmailVerified,
+		VerifiedAt:    user.EmailVerifiedAt,
+		LastLoginAt:   user.LastLoginAt,
+		FailedLogins:  user.FailedLoginAttempts,
+		LockedUntil:   user.LockedUntil,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+	if user.DeletedAt.Valid {
+		deletedAt := user.DeletedAt.Time
+		resource.DeletedAt = &deletedAt
+	}
+	for _, role := range user.Roles {
+		resource.Roles = append(resource.Roles, RoleResource{
+			ID:          role.ID,
+			Name:        role.Name,
+			Slug:        role.Slug,
+			Description: role.Description,
+			Level:       role.Level,
+		})
+	}
+	return resource
+}
+
+// User is the Transformer for records returned by UserService; other values pass through unchanged
+func User(record interface{}) interface{} {
+	switch user := record.(type) {
+	case *models.User:
+		if user != nil {
+			return NewUserResource(user)
+		}
+	case models.User:
+		return NewUserResource(&user)
+	}
+	return record
+}
@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"players/app/contracts"
+	"players/app/models"
+)
+
+const testPasswordHash = "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+
+// TestUserResourceNeverSerializesPasswordHash marshals users the way the API and
+// Inertia pages do and checks the bcrypt hash and legacy columns never appear.
+func TestUserResourceNeverSerializesPasswordHash(t *testing.T) {
+	user := models.User{
+		Name:     "Ada",
+		Email:    "ada@example.com",
+		Password: testPasswordHash,
+		Role:     "ADMIN",
+		IsActive: true,
+		Roles:    []models.Role{{Name: "Admin", Slug: "admin", Level: 80}},
+	}
+	user.ID = 7
+
+	controller := contracts.NewBaseCrudController("user")
+	controller.SetResourceTransformer(User)
+	paginated := controller.BuildPaginatedResponse(&contracts.PaginatedResult{
+		Data: []interface{}{user, &user},
+	}, &contracts.ListRequest{})
+
+	outputs := map[string]interface{}{
+		"pointer":    User(&user),
+		"value":      User(user),
+		"collection": Collection([]interface{}{user, &user}, User),
+		"paginated":  paginated,
+	}
+
+	for name, output := range outputs {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := json.Marshal(output)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			body := string(encoded)
+
+			for _, secret := range []string{testPasswordHash, `"password"`, `"legacy_role"`} {
+				if strings.Contains(body, secret) {
+					t.Errorf("serialized user contains %s: %s", secret, body)
+				}
+			}
+			if !strings.Contains(body, `"email":"ada@example.com"`) || !strings.Contains(body, `"slug":"admin"`) {
+				t.Errorf("serialized user is missing whitelisted fields: %s", body)
+			}
+		})
+	}
+}
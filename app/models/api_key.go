@@ -0,0 +1,44 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ApiKey lets a service call the API without an interactive login. Only the SHA-256 hash of the
+// key is stored; Prefix keeps its first characters so admins can tell keys apart. A request made
+// with the key acts as the owning user, limited to the permissions listed in Scopes.
+type ApiKey struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	Name       string     `gorm:"not null" json:"name"`
+	Prefix     string     `gorm:"not null" json:"prefix"`
+	KeyHash    string     `gorm:"uniqueIndex;not null" json:"-"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"`
+	Scopes     JSON       `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName returns the table name for ApiKey model
+func (ApiKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList returns the permission slugs the key is limited to; a malformed document grants none
+func (k *ApiKey) ScopeList() []string {
+	scopes := []string{}
+	if len(k.Scopes) > 0 {
+		_ = json.Unmarshal(k.Scopes, &scopes)
+	}
+	return scopes
+}
+
+// IsRevoked reports whether the key has been revoked
+func (k *ApiKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
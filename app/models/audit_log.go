@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditLog records one change made to a resource and who made it.
+// Rows are append-only, so there is no UpdatedAt or soft delete.
+type AuditLog struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       *uint     `gorm:"index" json:"user_id,omitempty"`
+	ResourceType string    `gorm:"not null" json:"resource_type"` // e.g., "books", "users"
+	ResourceID   uint      `gorm:"not null" json:"resource_id"`
+	Action       string    `gorm:"not null" json:"action"`   // create, update, delete, restore, forceDelete
+	Changes      string    `gorm:"type:text" json:"changes"` // JSON object of field -> {old, new}
+	CreatedAt    time.Time `json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName returns the table name for AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/goravel/framework/database/orm"
@@ -8,17 +9,21 @@ import (
 
 // Book entity - just a regular struct
 type Book struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Title       string    `json:"title" gorm:"not null"`
-	Author      string    `json:"author" gorm:"not null"`
-	ISBN        string    `json:"isbn" gorm:"unique;not null"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price" gorm:"default:0"`
-	Status      string    `json:"status" gorm:"default:'AVAILABLE'"` // AVAILABLE, BORROWED, MAINTENANCE
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Title       string     `json:"title" gorm:"not null"`
+	Author      string     `json:"author" gorm:"not null"`
+	ISBN        string     `json:"isbn" gorm:"unique;not null"`
+	Description string     `json:"description"`
+	Price       float64    `json:"price" gorm:"default:0"`
+	Status      string     `json:"status" gorm:"default:'AVAILABLE'"` // AVAILABLE, BORROWED, MAINTENANCE
 	PublishedAt string     `json:"publishedAt" gorm:"column:published_at"`
-	Tags        string    `json:"tags" gorm:"-"` // Ignore this field in database operations for now
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	Tags        string     `json:"tags" gorm:"-"` // Ignore this field in database operations for now
+	CategoryID  *uint      `json:"categoryId" gorm:"column:category_id;index"`
+	Metadata    JSON       `json:"metadata"` // free-form JSON document, e.g. edition details
+	Category    *Category  `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Loans       []BookLoan `json:"loans,omitempty" gorm:"foreignKey:BookID"` // loaded only by /books/{id}/related/loans, for books.manage
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
 	DeletedAt   *time.Time `json:"deletedAt,omitempty" gorm:"index"`
 	orm.SoftDeletes
 }
@@ -31,4 +36,48 @@ func (b Book) SearchFields() []string {
 // TableName returns the table name for this model
 func (b Book) TableName() string {
 	return "books"
-}
\ No newline at end of file
+}
+
+// NormalizeISBN reduces an ISBN to the canonical form books store: hyphens and spaces removed and
+// an ISBN-10 check digit x upper-cased, so 978-0-13-235088-4 and 9780132350884 are the same book
+func NormalizeISBN(isbn string) string {
+	isbn = strings.ReplaceAll(isbn, "-", "")
+	isbn = strings.ReplaceAll(isbn, " ", "")
+	return strings.ToUpper(strings.TrimSpace(isbn))
+}
+
+// ValidISBN reports whether a normalized ISBN is a well-formed ISBN-10 or ISBN-13 with a
+// correct check digit
+func ValidISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		// Weights 10..1; the check digit may be X for 10, and the sum must divide by 11
+		sum := 0
+		for i, r := range isbn {
+			digit := int(r - '0')
+			if r == 'X' && i == 9 {
+				digit = 10
+			} else if r < '0' || r > '9' {
+				return false
+			}
+			sum += (10 - i) * digit
+		}
+		return sum%11 == 0
+	case 13:
+		// Alternating weights 1 and 3; the sum must divide by 10
+		sum := 0
+		for i, r := range isbn {
+			if r < '0' || r > '9' {
+				return false
+			}
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += weight * int(r-'0')
+		}
+		return sum%10 == 0
+	default:
+		return false
+	}
+}
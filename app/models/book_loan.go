@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// BookLoan records who borrowed a book, when it is due back and when it was returned.
+// A loan is open while ReturnedAt is nil; a book has at most one open loan.
+type BookLoan struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	BookID     uint       `gorm:"index;not null" json:"book_id"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"`
+	BorrowedAt time.Time  `json:"borrowed_at"`
+	DueAt      time.Time  `json:"due_at"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Relationships
+	Book *Book `gorm:"foreignKey:BookID" json:"book,omitempty"`
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName returns the table name for BookLoan model
+func (BookLoan) TableName() string {
+	return "book_loans"
+}
+
+// IsOverdue reports whether the loan is still open past its due date at now
+func (l *BookLoan) IsOverdue(now time.Time) bool {
+	return l.ReturnedAt == nil && now.After(l.DueAt)
+}
@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestValidISBN(t *testing.T) {
+	tests := []struct {
+		isbn string
+		want bool
+	}{
+		{"0306406152", true},
+		{"0306406153", false}, // wrong check digit
+		{"080442957X", true},  // check digit 10 written as X
+		{"080442957x", true},  // normalized to X
+		{"0804429570", false}, // X is the only valid check digit here
+		{"08044X9570", false}, // X only allowed as the check digit
+		{"030640615", false},  // too short
+		{"9780306406157", true},
+		{"9780306406158", false}, // wrong check digit
+		{"978030640615X", false}, // ISBN-13 has no X check digit
+		{"978-0-13-235088-4", true},
+		{"0-306-40615-2", true},
+		{"978 0 306 40615 7", true},
+		{"978-0-13-235088-5", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidISBN(NormalizeISBN(tt.isbn)); got != tt.want {
+			t.Errorf("ValidISBN(NormalizeISBN(%q)) = %v, want %v", tt.isbn, got, tt.want)
+		}
+	}
+}
+
+func TestValidISBNExpectsNormalizedInput(t *testing.T) {
+	if ValidISBN("0-306-40615-2") {
+		t.Errorf("ValidISBN accepted a hyphenated ISBN; callers must NormalizeISBN first")
+	}
+}
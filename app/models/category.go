@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Category groups books by genre for catalog browsing
+type Category struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"unique;not null"`
+	Slug        string    `json:"slug" gorm:"unique;not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// TableName returns the table name for this model
+func (c Category) TableName() string {
+	return "categories"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Impersonation records an admin acting as another user. The session is the access token issued
+// for the impersonated user, identified by its SHA-256 hash; it is active until EndedAt is set or
+// ExpiresAt passes, whichever comes first. The record is what marks the token as an impersonation,
+// and auth.ImpersonationDeniedPermissions are withheld from it.
+type Impersonation struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	ImpersonatorID uint       `gorm:"index;not null" json:"impersonator_id"`
+	ImpersonatedID uint       `gorm:"index;not null" json:"impersonated_id"`
+	TokenHash      string     `gorm:"uniqueIndex;not null" json:"-"`
+	StartedAt      time.Time  `json:"started_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Relationships
+	Impersonator *User `gorm:"foreignKey:ImpersonatorID" json:"impersonator,omitempty"`
+	Impersonated *User `gorm:"foreignKey:ImpersonatedID" json:"impersonated,omitempty"`
+}
+
+// TableName returns the table name for Impersonation model
+func (Impersonation) TableName() string {
+	return "impersonations"
+}
+
+// IsActive reports whether the impersonation has neither ended nor expired at now
+func (i *Impersonation) IsActive(now time.Time) bool {
+	return i.EndedAt == nil && now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON is the raw document of a JSON column. It is written as text, which json, jsonb and SQLite's
+// text-backed JSON columns all accept, and is serialized to clients as the document itself rather
+// than as a string or base64. An empty JSON is NULL in the database and null in responses.
+type JSON json.RawMessage
+
+// ToJSON encodes value for a JSON column. Decoded request values such as map[string]interface{}
+// are marshalled; JSON and json.RawMessage are used as they are, and nil becomes NULL.
+func ToJSON(value interface{}) (JSON, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case JSON:
+		return v, nil
+	case json.RawMessage:
+		return JSON(v), nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON value: %w", err)
+	}
+	if string(encoded) == "null" {
+		return nil, nil
+	}
+	return JSON(encoded), nil
+}
+
+// EncodeJSONColumns replaces the values of columns present in data with their JSON encoding, so an
+// update map can carry objects straight from the request body
+func EncodeJSONColumns(data map[string]interface{}, columns ...string) error {
+	for _, column := range columns {
+		value, ok := data[column]
+		if !ok {
+			continue
+		}
+		encoded, err := ToJSON(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", column, err)
+		}
+		if encoded == nil {
+			data[column] = nil
+		} else {
+			data[column] = encoded
+		}
+	}
+	return nil
+}
+
+// Value writes the document as text, or NULL when empty
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+// Scan reads a JSON column returned as text or bytes
+func (j *JSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append(JSON(nil), v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", value)
+	}
+	return nil
+}
+
+// MarshalJSON embeds the document as is
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+// UnmarshalJSON keeps a copy of the raw document; null leaves it empty
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*j = nil
+		return nil
+	}
+	*j = append(JSON(nil), data...)
+	return nil
+}
+
+// GormDataType lets AutoMigrate and the gorm schema treat the field as a json column
+func (JSON) GormDataType() string {
+	return "json"
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONRoundTripsThroughTheDatabase(t *testing.T) {
+	document, err := ToJSON(map[string]interface{}{"tags": []string{"a", "b"}, "pages": 12})
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+
+	value, err := document.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	// Drivers hand text columns back as either []byte or string
+	for _, stored := range []interface{}{[]byte(value.(string)), value} {
+		var scanned JSON
+		if err := scanned.Scan(stored); err != nil {
+			t.Fatalf("Scan(%T): %v", stored, err)
+		}
+		if string(scanned) != `{"pages":12,"tags":["a","b"]}` {
+			t.Errorf("Scan(%T) = %s, want the stored document", stored, scanned)
+		}
+	}
+}
+
+func TestJSONRoundTripsThroughAPIs(t *testing.T) {
+	var record struct {
+		Metadata JSON `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(`{"metadata":{"edition":2}}`), &record); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(encoded) != `{"metadata":{"edition":2}}` {
+		t.Errorf("Marshal = %s, want the document embedded as is", encoded)
+	}
+}
+
+func TestEmptyJSONIsNull(t *testing.T) {
+	var empty JSON
+	if value, err := empty.Value(); err != nil || value != nil {
+		t.Errorf("Value() = %v, %v, want NULL", value, err)
+	}
+	if err := empty.Scan(nil); err != nil || empty != nil {
+		t.Errorf("Scan(nil) = %s, %v, want an empty document", empty, err)
+	}
+
+	encoded, err := json.Marshal(struct {
+		Metadata JSON `json:"metadata"`
+	}{})
+	if err != nil || string(encoded) != `{"metadata":null}` {
+		t.Errorf("Marshal = %s, %v, want metadata null", encoded, err)
+	}
+
+	if document, err := ToJSON(nil); err != nil || document != nil {
+		t.Errorf("ToJSON(nil) = %s, %v, want an empty document", document, err)
+	}
+}
+
+func TestEncodeJSONColumnsOnlyTouchesGivenColumns(t *testing.T) {
+	data := map[string]interface{}{
+		"title":    "Dune",
+		"metadata": map[string]interface{}{"edition": 2},
+		"extra":    nil,
+	}
+	if err := EncodeJSONColumns(data, "metadata", "extra", "missing"); err != nil {
+		t.Fatalf("EncodeJSONColumns: %v", err)
+	}
+
+	if document, ok := data["metadata"].(JSON); !ok || string(document) != `{"edition":2}` {
+		t.Errorf("metadata = %#v, want the encoded document", data["metadata"])
+	}
+	if data["extra"] != nil {
+		t.Errorf("extra = %#v, want nil to clear the column", data["extra"])
+	}
+	if _, ok := data["missing"]; ok {
+		t.Error("EncodeJSONColumns added a column that wasn't in data")
+	}
+	if data["title"] != "Dune" {
+		t.Errorf("title = %#v, want it left alone", data["title"])
+	}
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"strings"
+
 	"github.com/goravel/framework/database/orm"
 )
 
@@ -40,7 +42,46 @@ func (p *Permission) GetFullName() string {
 
 // Matches checks if this permission matches a given permission string
 func (p *Permission) Matches(permissionString string) bool {
-	return p.Slug == permissionString || p.GetFullName() == permissionString
+	return PermissionKey(p.Slug) == PermissionKey(permissionString) || p.GetFullName() == permissionString
+}
+
+// PermissionKey reduces a slug to the form permission checks compare. Slugs exist in both the
+// service_action (books_create) and service.action (books.create) styles; both share one key.
+func PermissionKey(slug string) string {
+	return strings.ReplaceAll(slug, ".", "_")
+}
+
+// compoundPermissionActions are the actions whose own name holds an underscore
+var compoundPermissionActions = []string{"bulk_update", "bulk_delete"}
+
+// DottedPermissionSlug returns slug in the service.action style. A service_action slug is split
+// before its action, which is one of compoundPermissionActions or else the part after the last
+// underscore, so service names may hold underscores too (books_bulk_update becomes
+// books.bulk_update and book_loans_view becomes book_loans.view).
+func DottedPermissionSlug(slug string) string {
+	if strings.Contains(slug, ".") {
+		return slug
+	}
+	for _, action := range compoundPermissionActions {
+		if service, ok := strings.CutSuffix(slug, "_"+action); ok && service != "" {
+			return service + "." + action
+		}
+	}
+	if i := strings.LastIndex(slug, "_"); i >= 0 {
+		return slug[:i] + "." + slug[i+1:]
+	}
+	return slug
+}
+
+// PermissionSlugVariants returns every spelling of slug, for looking permissions up by slug
+func PermissionSlugVariants(slug string) []string {
+	variants := []string{slug}
+	for _, variant := range []string{DottedPermissionSlug(slug), PermissionKey(slug)} {
+		if variant != slug {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
 }
 
 // PermissionCategory represents permission categories
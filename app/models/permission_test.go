@@ -0,0 +1,21 @@
+package models
+
+import "testing"
+
+func TestDottedPermissionSlug(t *testing.T) {
+	tests := map[string]string{
+		"books_create":           "books.create",
+		"books_bulk_update":      "books.bulk_update",
+		"books_bulk_delete":      "books.bulk_delete",
+		"book_loans_view":        "book_loans.view",
+		"book_loans_bulk_delete": "book_loans.bulk_delete",
+		"books.viewAny":          "books.viewAny",
+		"dashboard":              "dashboard",
+	}
+
+	for slug, want := range tests {
+		if got := DottedPermissionSlug(slug); got != want {
+			t.Errorf("DottedPermissionSlug(%q) = %q, want %q", slug, got, want)
+		}
+	}
+}
@@ -33,7 +33,7 @@ func (Role) TableName() string {
 // HasPermission checks if role has a specific permission
 func (r *Role) HasPermission(permission string) bool {
 	for _, perm := range r.Permissions {
-		if perm.Slug == permission {
+		if PermissionKey(perm.Slug) == PermissionKey(permission) {
 			return true
 		}
 	}
@@ -18,10 +18,23 @@ type User struct {
 	IsActive     bool   `gorm:"default:true" json:"is_active"`
 	IsSuperAdmin bool   `gorm:"default:false;index" json:"is_super_admin"`
 	EmailVerified bool  `gorm:"default:false" json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 	LastLoginAt  *time.Time `json:"last_login_at,omitempty"`
+
+	// Account lockout after repeated failed logins
+	FailedLoginAttempts int        `gorm:"default:0" json:"failed_login_attempts"`
+	LockedUntil         *time.Time `json:"locked_until,omitempty"`
 	
 	// Many-to-many relationships
 	Roles []Role `gorm:"many2many:user_roles" json:"roles,omitempty"`
+
+	// ApiKeyScopes limits the user's permissions to these slugs when the request was authenticated
+	// with an API key; nil for every other session
+	ApiKeyScopes []string `gorm:"-" json:"-"`
+
+	// ImpersonatedBy is the admin acting as this user when the request's session is an
+	// impersonation; 0 for every other session
+	ImpersonatedBy uint `gorm:"-" json:"-"`
 	
 	orm.SoftDeletes
 }
@@ -31,6 +44,11 @@ func (User) TableName() string {
 	return "users"
 }
 
+// IsLocked reports whether too many failed logins have locked the account at now
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
 // HasRole checks if user has a specific role
 func (u *User) HasRole(roleSlug string) bool {
 	for _, role := range u.Roles {
@@ -50,7 +68,7 @@ func (u *User) HasPermission(permission string) bool {
 		
 		allPerms := role.GetAllPermissions()
 		for _, perm := range allPerms {
-			if perm == permission {
+			if PermissionKey(perm) == PermissionKey(permission) {
 				return true
 			}
 		}
@@ -2,6 +2,9 @@ package providers
 
 import (
 	"github.com/goravel/framework/contracts/foundation"
+
+	"players/app/contracts"
+	"players/app/services"
 )
 
 type AppServiceProvider struct {
@@ -12,5 +15,6 @@ func (receiver *AppServiceProvider) Register(app foundation.Application) {
 }
 
 func (receiver *AppServiceProvider) Boot(app foundation.Application) {
-
+	// Resource lifecycle events go out as webhooks to the URLs in config/webhooks.go
+	contracts.OnLifecycleEvent(services.NewWebhookService().Dispatch)
 }
@@ -2,10 +2,14 @@ package providers
 
 import (
 	"fmt"
+	"regexp"
+	"sync"
 
 	"github.com/goravel/framework/contracts/foundation"
 	"github.com/goravel/framework/contracts/validation"
 	"github.com/goravel/framework/facades"
+
+	"players/app/models"
 )
 
 type ValidationServiceProvider struct {
@@ -27,6 +31,8 @@ func (receiver *ValidationServiceProvider) Boot(app foundation.Application) {
 func (receiver *ValidationServiceProvider) rules() []validation.Rule {
 	return []validation.Rule{
 		&UniqueRule{},
+		&ExistsRule{},
+		&ISBNRule{},
 		&BeforeRule{},
 	}
 }
@@ -35,8 +41,16 @@ func (receiver *ValidationServiceProvider) filters() []validation.Filter {
 	return []validation.Filter{}
 }
 
-// UniqueRule validates that a field value is unique in the database
+// UniqueRule validates that a field value is not taken by another live row:
+//
+//	unique:users,email          no row has the email
+//	unique:users,email,5        no row other than id 5 has it, for updates
+//	unique:books,isbn,5,book_id the ignored row is matched on book_id instead of id
+//
+// Soft-deleted rows don't count on tables with a deleted_at column; the services report those
+// separately, since restoring the row is usually the fix.
 type UniqueRule struct {
+	softDeletes sync.Map // table => whether it has deleted_at
 }
 
 func (r *UniqueRule) Signature() string {
@@ -48,32 +62,39 @@ func (r *UniqueRule) Passes(data validation.Data, val any, options ...any) bool
 		return false
 	}
 
-	table := options[0].(string)
-	column := options[1].(string)
-	
-	var ignoreID string
-	if len(options) > 2 {
-		ignoreID = fmt.Sprint(options[2])
+	table, column := fmt.Sprint(options[0]), fmt.Sprint(options[1])
+	idColumn := "id"
+	if len(options) > 3 {
+		idColumn = fmt.Sprint(options[3])
+	}
+	if !sqlIdentifier.MatchString(table) || !sqlIdentifier.MatchString(column) || !sqlIdentifier.MatchString(idColumn) {
+		facades.Log().Errorf("unique rule: invalid table or column in unique:%s,%s", table, column)
+		return false
 	}
 
-	// Convert value to string for comparison
 	value := fmt.Sprint(val)
-	if value == "" {
+	if val == nil || value == "" {
 		return true // Empty values are handled by required rule
 	}
 
-	// Build query
-	query := facades.Orm().Query().Table(table).Where(column, value)
-	
-	// If we have an ID to ignore (for updates), exclude it
-	if ignoreID != "" && ignoreID != "0" {
-		query = query.Where("id", "!=", ignoreID)
+	query := facades.Orm().Query().Table(table).Where(column+" = ?", value)
+
+	// Updates ignore the record being edited
+	if len(options) > 2 {
+		if ignoreID := fmt.Sprint(options[2]); ignoreID != "" && ignoreID != "0" {
+			query = query.Where(idColumn+" <> ?", ignoreID)
+		}
+	}
+	if r.hasSoftDeletes(table) {
+		query = query.Where("deleted_at IS NULL")
 	}
 
-	// Check if record exists
 	var count int64
-	query.Count(&count)
-	
+	if err := query.Count(&count); err != nil {
+		facades.Log().Errorf("unique rule: failed to check %s.%s: %v", table, column, err)
+		return false
+	}
+
 	return count == 0
 }
 
@@ -81,6 +102,80 @@ func (r *UniqueRule) Message() string {
 	return "The :attribute has already been taken."
 }
 
+// hasSoftDeletes reports whether table has a deleted_at column, looked up once per table
+func (r *UniqueRule) hasSoftDeletes(table string) bool {
+	if known, ok := r.softDeletes.Load(table); ok {
+		return known.(bool)
+	}
+	has := facades.Schema().HasColumn(table, "deleted_at")
+	r.softDeletes.Store(table, has)
+	return has
+}
+
+// sqlIdentifier matches the table and column names database rules may interpolate into a query
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ExistsRule validates that a field value names an existing row, e.g. exists:categories,id
+type ExistsRule struct {
+}
+
+func (r *ExistsRule) Signature() string {
+	return "exists"
+}
+
+func (r *ExistsRule) Passes(data validation.Data, val any, options ...any) bool {
+	if len(options) < 2 {
+		return false
+	}
+
+	table, column := fmt.Sprint(options[0]), fmt.Sprint(options[1])
+	if !sqlIdentifier.MatchString(table) || !sqlIdentifier.MatchString(column) {
+		facades.Log().Errorf("exists rule: invalid table or column in exists:%s,%s", table, column)
+		return false
+	}
+
+	value := fmt.Sprint(val)
+	if val == nil || value == "" {
+		return true // Empty values are handled by required rule
+	}
+
+	var count int64
+	if err := facades.Orm().Query().Table(table).Where(column+" = ?", value).Count(&count); err != nil {
+		facades.Log().Errorf("exists rule: failed to check %s.%s: %v", table, column, err)
+		return false
+	}
+
+	return count > 0
+}
+
+func (r *ExistsRule) Message() string {
+	return "The selected :attribute is invalid."
+}
+
+// ISBNRule validates an ISBN-10 or ISBN-13 check digit; hyphens and spaces are ignored
+type ISBNRule struct {
+}
+
+func (r *ISBNRule) Signature() string {
+	return "isbn"
+}
+
+func (r *ISBNRule) Passes(data validation.Data, val any, options ...any) bool {
+	isbn, ok := val.(string)
+	if !ok {
+		return false
+	}
+	if isbn == "" {
+		return true // Empty values are handled by required rule
+	}
+
+	return models.ValidISBN(models.NormalizeISBN(isbn))
+}
+
+func (r *ISBNRule) Message() string {
+	return "The :attribute must be a valid ISBN-10 or ISBN-13."
+}
+
 // BeforeRule validates that a date field is before a specified date
 type BeforeRule struct {
 }
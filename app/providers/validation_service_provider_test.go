@@ -0,0 +1,37 @@
+package providers
+
+import (
+	"testing"
+
+	"players/app/models"
+	"players/tests/testdb"
+)
+
+func TestExistsRuleRefusesUnsafeIdentifiers(t *testing.T) {
+	db := testdb.Open(t, &models.Role{})
+	if err := db.Create(&models.Role{Name: "Member", Slug: "member", IsActive: true}).Error; err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		options []any
+		value   any
+		want    bool
+	}{
+		{"existing row", []any{"roles", "slug"}, "member", true},
+		{"missing row", []any{"roles", "slug"}, "nobody", false},
+		{"empty value", []any{"roles", "slug"}, "", true},
+		{"injected table", []any{"roles WHERE 1=1 --", "slug"}, "nobody", false},
+		{"injected column", []any{"roles", "slug = slug OR 1"}, "nobody", false},
+	}
+
+	rule := &ExistsRule{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.Passes(nil, tt.value, tt.options...); got != tt.want {
+				t.Errorf("exists:%v Passes(%v) = %v, want %v", tt.options, tt.value, got, tt.want)
+			}
+		})
+	}
+}
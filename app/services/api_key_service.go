@@ -0,0 +1,137 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"players/app/auth"
+	"players/app/models"
+)
+
+// ApiKeyPrefix starts every API key, which is how a bearer token is told apart from a JWT
+const ApiKeyPrefix = "ak_"
+
+// apiKeyTouchInterval limits how often a key's last_used_at is written
+const apiKeyTouchInterval = time.Minute
+
+var (
+	// ErrApiKeyInvalid is returned for a key that doesn't exist, was revoked or whose owner can no
+	// longer log in
+	ErrApiKeyInvalid = errors.New("invalid API key")
+	// ErrApiKeyNotFound is returned when revoking a key that doesn't exist
+	ErrApiKeyNotFound = errors.New("API key not found")
+	// ErrApiKeyScopeNotHeld is returned when a key is minted with a permission its owner lacks
+	ErrApiKeyScopeNotHeld = errors.New("API key scopes must be permissions the owner holds")
+)
+
+// ApiKeyService mints, authenticates and revokes API keys. Minting and revoking are written to the
+// audit trail under api_keys.
+type ApiKeyService struct {
+	audit *AuditService
+}
+
+// NewApiKeyService creates a new API key service
+func NewApiKeyService() *ApiKeyService {
+	return &ApiKeyService{audit: NewAuditService()}
+}
+
+// Create mints a key owned by owner and limited to scopes, which owner must all hold. The plain
+// key is only returned here; it can't be recovered later.
+func (s *ApiKeyService) Create(owner *models.User, name string, scopes []string) (string, *models.ApiKey, error) {
+	permissions := auth.GetPermissionService()
+	for _, scope := range scopes {
+		if !permissions.HasPermission(owner, scope) {
+			return "", nil, fmt.Errorf("%w: %s", ErrApiKeyScopeNotHeld, scope)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := ApiKeyPrefix + hex.EncodeToString(secret)
+
+	encodedScopes, err := models.ToJSON(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+	apiKey := models.ApiKey{
+		Name:    strings.TrimSpace(name),
+		Prefix:  key[:len(ApiKeyPrefix)+8],
+		KeyHash: hashAccessToken(key),
+		UserID:  owner.ID,
+		Scopes:  encodedScopes,
+	}
+	if err := facades.Orm().Query().Create(&apiKey); err != nil {
+		return "", nil, fmt.Errorf("failed to store API key: %w", err)
+	}
+
+	if err := s.audit.Record(&owner.ID, "api_keys", apiKey.ID, AuditActionCreate, nil, &apiKey); err != nil {
+		facades.Log().Error("Failed to audit API key creation: " + err.Error())
+	}
+	return key, &apiKey, nil
+}
+
+// List returns every key, newest first, with its owner loaded
+func (s *ApiKeyService) List() ([]models.ApiKey, error) {
+	var keys []models.ApiKey
+	if err := facades.Orm().Query().With("User").Order("id DESC").Find(&keys); err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke stops the key id from authenticating. Revoking a revoked key is a no-op.
+func (s *ApiKeyService) Revoke(actorID uint, id uint) (*models.ApiKey, error) {
+	var apiKey models.ApiKey
+	if err := facades.Orm().Query().Where("id = ?", id).First(&apiKey); err != nil || apiKey.ID == 0 {
+		return nil, ErrApiKeyNotFound
+	}
+	if apiKey.IsRevoked() {
+		return &apiKey, nil
+	}
+
+	before := apiKey
+	now := time.Now()
+	if _, err := facades.Orm().Query().Model(&models.ApiKey{}).Where("id = ?", id).Update("revoked_at", now); err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	apiKey.RevokedAt = &now
+
+	if err := s.audit.Record(&actorID, "api_keys", apiKey.ID, AuditActionRevoke, &before, &apiKey); err != nil {
+		facades.Log().Error("Failed to audit API key revocation: " + err.Error())
+	}
+	return &apiKey, nil
+}
+
+// Authenticate returns the live key matching key with its owner and the owner's roles loaded, and
+// records when it was used
+func (s *ApiKeyService) Authenticate(key string) (*models.ApiKey, error) {
+	if !strings.HasPrefix(key, ApiKeyPrefix) {
+		return nil, ErrApiKeyInvalid
+	}
+
+	var apiKey models.ApiKey
+	if err := facades.Orm().Query().With("User.Roles").
+		Where("key_hash = ?", hashAccessToken(key)).
+		First(&apiKey); err != nil || apiKey.ID == 0 {
+		return nil, ErrApiKeyInvalid
+	}
+	if apiKey.IsRevoked() || apiKey.User == nil || !apiKey.User.IsActive {
+		return nil, ErrApiKeyInvalid
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if _, err := facades.Orm().Query().Model(&models.ApiKey{}).Where("id = ?", apiKey.ID).Update("last_used_at", now); err != nil {
+			facades.Log().Warning("Failed to record API key use: " + err.Error())
+		}
+		apiKey.LastUsedAt = &now
+	}
+	return &apiKey, nil
+}
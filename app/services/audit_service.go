@@ -0,0 +1,307 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"players/app/contracts"
+	"players/app/models"
+)
+
+// Audit actions recorded in audit_logs
+const (
+	AuditActionCreate            = "create"
+	AuditActionUpdate            = "update"
+	AuditActionDelete            = "delete"
+	AuditActionRestore           = "restore"
+	AuditActionForceDelete       = "forceDelete"
+	AuditActionImpersonate       = "impersonate"
+	AuditActionStopImpersonating = "stopImpersonating"
+	AuditActionRevoke            = "revoke"
+)
+
+// auditIgnoredFields change on every write and would make each update look noisy
+var auditIgnoredFields = map[string]bool{
+	"updatedAt":  true,
+	"updated_at": true,
+}
+
+// AuditChange is the before and after value of one field
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditEntry is an audit_logs row with its changes decoded for API responses
+type AuditEntry struct {
+	models.AuditLog
+	Changes map[string]AuditChange `json:"changes"`
+}
+
+// AuditService reads and writes the audit trail kept in audit_logs
+type AuditService struct{}
+
+// NewAuditService creates a new audit service
+func NewAuditService() *AuditService {
+	return &AuditService{}
+}
+
+// Record writes one audit_logs row. before and after are the record as it was and as it is now;
+// pass nil for before on create and nil for after on delete. Only changed fields are stored.
+func (s *AuditService) Record(actorID *uint, resourceType string, resourceID uint, action string, before, after interface{}) error {
+	changes, err := diffAuditRecords(before, after)
+	if err != nil {
+		return err
+	}
+	if action == AuditActionUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+
+	if _, err := facades.Orm().Query().Exec(
+		"INSERT INTO audit_logs (user_id, resource_type, resource_id, action, changes, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		actorID, resourceType, resourceID, action, string(encoded), time.Now(),
+	); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// GetTrail returns the audit entries for one record, newest first
+func (s *AuditService) GetTrail(resourceType string, resourceID uint) ([]AuditEntry, error) {
+	var logs []models.AuditLog
+	if err := facades.Orm().Query().
+		With("User").
+		Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&logs); err != nil {
+		return nil, fmt.Errorf("failed to load audit trail: %w", err)
+	}
+
+	entries := make([]AuditEntry, len(logs))
+	for i, log := range logs {
+		entries[i] = AuditEntry{AuditLog: log, Changes: map[string]AuditChange{}}
+		if log.Changes != "" {
+			if err := json.Unmarshal([]byte(log.Changes), &entries[i].Changes); err != nil {
+				return nil, fmt.Errorf("failed to decode audit log %d: %w", log.ID, err)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// diffAuditRecords compares two records by their JSON fields and returns the ones that differ
+func diffAuditRecords(before, after interface{}) (map[string]AuditChange, error) {
+	oldFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]AuditChange)
+	for field, oldValue := range oldFields {
+		if auditIgnoredFields[field] {
+			continue
+		}
+		newValue, exists := newFields[field]
+		if !exists || !reflect.DeepEqual(oldValue, newValue) {
+			changes[field] = AuditChange{Old: oldValue, New: newValue}
+		}
+	}
+	for field, newValue := range newFields {
+		if _, exists := oldFields[field]; !exists && !auditIgnoredFields[field] {
+			changes[field] = AuditChange{New: newValue}
+		}
+	}
+	return changes, nil
+}
+
+// auditFields flattens a record into its JSON fields; secrets are already hidden by json:"-" tags
+func auditFields(record interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if value := reflect.ValueOf(record); record == nil || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return fields, nil
+	}
+
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode record for audit: %w", err)
+	}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode record for audit: %w", err)
+	}
+	for field := range fields {
+		if strings.Contains(strings.ToLower(field), "password") {
+			delete(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// AuditActor returns the ID of the authenticated user changes are attributed to, or nil
+func AuditActor(user interface{}) *uint {
+	if u, ok := user.(*models.User); ok && u != nil {
+		id := u.ID
+		return &id
+	}
+	return nil
+}
+
+// AuditedService wraps a CRUD service and records an audit_logs row for every change made through it.
+// Audit failures are logged rather than returned so they never undo a completed change.
+type AuditedService struct {
+	service      contracts.CrudServiceContract
+	audit        *AuditService
+	resourceType string
+	actorID      *uint
+}
+
+// NewAuditedService wraps service so changes are attributed to actorID (nil for system changes)
+func NewAuditedService(service contracts.CrudServiceContract, resourceType string, actorID *uint) *AuditedService {
+	return &AuditedService{
+		service:      service,
+		audit:        NewAuditService(),
+		resourceType: resourceType,
+		actorID:      actorID,
+	}
+}
+
+// Create creates the record as the actor and audits all of its fields as new
+func (a *AuditedService) Create(data map[string]interface{}) (interface{}, error) {
+	contracts.SetActor(data, a.actorID)
+	record, err := a.service.Create(data)
+	if err != nil {
+		return nil, err
+	}
+	a.record(auditRecordID(record), AuditActionCreate, nil, record)
+	return record, nil
+}
+
+// CreateUsing runs create in place of the wrapped service's Create and audits the record it
+// returns, for creates that take more than a data map, such as a user created with a role
+func (a *AuditedService) CreateUsing(create func() (interface{}, error)) (interface{}, error) {
+	record, err := create()
+	if err != nil {
+		return nil, err
+	}
+	a.record(auditRecordID(record), AuditActionCreate, nil, record)
+	return record, nil
+}
+
+// Update updates the record as the actor and audits the fields that changed
+func (a *AuditedService) Update(id uint, data map[string]interface{}) (interface{}, error) {
+	before, _ := a.service.GetByID(id)
+	contracts.SetActor(data, a.actorID)
+	record, err := a.service.Update(id, data)
+	if err != nil {
+		return nil, err
+	}
+	a.record(id, AuditActionUpdate, before, record)
+	return record, nil
+}
+
+// UpdateUsing runs update in place of the wrapped service's Update and audits the fields that
+// changed, for updates that take more than a data map, such as a user's super admin flag
+func (a *AuditedService) UpdateUsing(id uint, update func() (interface{}, error)) (interface{}, error) {
+	before, _ := a.service.GetByID(id)
+	record, err := update()
+	if err != nil {
+		return nil, err
+	}
+	a.record(id, AuditActionUpdate, before, record)
+	return record, nil
+}
+
+// Delete soft-deletes the record and audits its last state
+func (a *AuditedService) Delete(id uint) error {
+	before, _ := a.service.GetByID(id)
+	if err := a.service.Delete(id); err != nil {
+		return err
+	}
+	a.record(id, AuditActionDelete, before, nil)
+	return nil
+}
+
+// Restore brings back a soft-deleted record and audits its restored state
+func (a *AuditedService) Restore(id uint) error {
+	softDeletes, ok := a.service.(contracts.SoftDeleteServiceContract)
+	if !ok {
+		return fmt.Errorf("%s does not support restore", a.resourceType)
+	}
+	if err := softDeletes.Restore(id); err != nil {
+		return err
+	}
+	after, _ := a.service.GetByID(id)
+	a.record(id, AuditActionRestore, nil, after)
+	return nil
+}
+
+// ForceDelete permanently removes the record and audits the action
+func (a *AuditedService) ForceDelete(id uint) error {
+	softDeletes, ok := a.service.(contracts.SoftDeleteServiceContract)
+	if !ok {
+		return fmt.Errorf("%s does not support permanent deletion", a.resourceType)
+	}
+	before, _ := a.service.GetByID(id)
+	if err := softDeletes.ForceDelete(id); err != nil {
+		return err
+	}
+	a.record(id, AuditActionForceDelete, before, nil)
+	return nil
+}
+
+// SetActive toggles is_active and audits the change
+func (a *AuditedService) SetActive(id uint, active bool) error {
+	activatable, ok := a.service.(contracts.ActivatableServiceContract)
+	if !ok {
+		return fmt.Errorf("%s does not support activation", a.resourceType)
+	}
+	before, _ := a.service.GetByID(id)
+	if err := activatable.SetActive(id, active); err != nil {
+		return err
+	}
+	after, _ := a.service.GetByID(id)
+	a.record(id, AuditActionUpdate, before, after)
+	return nil
+}
+
+// GetByID passes through to the wrapped service
+func (a *AuditedService) GetByID(id uint) (interface{}, error) {
+	return a.service.GetByID(id)
+}
+
+func (a *AuditedService) record(resourceID uint, action string, before, after interface{}) {
+	if err := a.audit.Record(a.actorID, a.resourceType, resourceID, action, before, after); err != nil {
+		facades.Log().Error("Failed to record audit log", map[string]interface{}{
+			"resource_type": a.resourceType,
+			"resource_id":   resourceID,
+			"action":        action,
+			"error":         err.Error(),
+		})
+	}
+}
+
+// auditRecordID reads the ID field of a created model
+func auditRecordID(record interface{}) uint {
+	value := reflect.Indirect(reflect.ValueOf(record))
+	if value.Kind() != reflect.Struct {
+		return 0
+	}
+	id := value.FieldByName("ID")
+	if !id.IsValid() || !id.CanUint() {
+		return 0
+	}
+	return uint(id.Uint())
+}
@@ -1,13 +1,17 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"players/app/contracts"
 	"players/app/helpers"
 	"players/app/models"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/goravel/framework/contracts/database/orm"
 	"github.com/goravel/framework/facades"
 )
 
@@ -24,6 +28,15 @@ func NewBookService() *BookService {
 		authHelper:      helpers.NewAuthHelper().(*helpers.AuthHelper),
 	}
 
+	// ISBN is unique, so it can drive keyset pagination alongside id
+	service.SetCursorColumns("isbn")
+
+	// Catalog search is ranked by relevance on SQLite (books_fts) and Postgres, LIKE elsewhere
+	service.SetSearchMode(contracts.SearchModeFullText, "books_fts")
+
+	// Book details are fetched repeatedly by modals; every book write dispatches an event that busts the cache
+	service.SetRecordCache(true)
+
 	// Register service with validation
 	contracts.MustRegisterCrudService("books", service)
 
@@ -32,107 +45,71 @@ func NewBookService() *BookService {
 
 // GetList with built-in pagination, sorting, filtering using GORM directly
 // Implements CrudServiceContract interface
-func (s *BookService) GetList(req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+func (s *BookService) GetList(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	db, release, err := s.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Use base service validation
 	if err := s.ValidateListRequest(&req); err != nil {
 		return nil, err
 	}
 	s.SanitizeListRequest(&req)
 
-	// Build query with sorting
-	query := facades.Orm().Query().Model(&models.Book{})
-
-	// Apply search if provided using searchable fields
 	if req.Search != "" {
 		if err := s.ValidateSearchQuery(req.Search); err != nil {
 			return nil, err
 		}
-		searchFields := s.GetSearchableFields()
-		if len(searchFields) > 0 {
-			conditions := make([]string, len(searchFields))
-			values := make([]interface{}, len(searchFields))
-			for i, field := range searchFields {
-				conditions[i] = field + " LIKE ?"
-				values[i] = "%" + req.Search + "%"
-			}
-			query = query.Where(strings.Join(conditions, " OR "), values...)
-		}
 	}
 
-	// Apply sorting with field validation and mapping
-	if req.Sort != "" && req.Direction != "" {
-		if s.ValidateSortField(req.Sort) && s.ValidateSortDirection(req.Direction) {
-			dbColumn, valid := s.MapSortField(req.Sort)
-			if valid {
-				orderClause := dbColumn + " " + strings.ToUpper(req.Direction)
-				query = query.Order(orderClause)
-				fmt.Printf("BookService.GetList - Applied sorting: %s\n", orderClause)
-			} else {
-				// Use default sort
-				defaultField, defaultDir := s.GetDefaultSort()
-				query = query.Order(defaultField + " " + defaultDir)
-				fmt.Printf("BookService.GetList - Invalid sort mapping, using default\n")
-			}
-		} else {
-			// Use default sort
-			defaultField, defaultDir := s.GetDefaultSort()
-			query = query.Order(defaultField + " " + defaultDir)
-			fmt.Printf("BookService.GetList - Invalid sort field '%s', using default\n", req.Sort)
+	// Build query with search conditions; called separately for count and data
+	newQuery := func() orm.Query {
+		query := db.Model(&models.Book{})
+
+		// Apply search if provided using searchable fields (full-text when available, LIKE otherwise)
+		if req.Search != "" {
+			query = s.ApplySearch(query, req.Search, s.GetSearchableFields(), s.GetSearchFieldModes())
 		}
-	} else {
-		// Default sorting
-		defaultField, defaultDir := s.GetDefaultSort()
-		query = query.Order(defaultField + " " + defaultDir)
-		fmt.Printf("BookService.GetList - Using default sorting\n")
-	}
 
-	// Get all books with applied filters and sorting
-	var allBooks []models.Book
-	if err := query.Find(&allBooks); err != nil {
-		return nil, err
+		return query
 	}
 
-	// Manual pagination
-	total := int64(len(allBooks))
-	offset := (req.Page - 1) * req.PageSize
-	end := offset + req.PageSize
-
-	if offset > len(allBooks) {
-		offset = len(allBooks)
-	}
-	if end > len(allBooks) {
-		end = len(allBooks)
+	// Resolve sorting with field validation and mapping; full-text matches rank by relevance first
+	orderClause := s.buildOrderClause(req)
+	if relevance := s.SearchRelevanceOrder(req.Search, s.GetSearchableFields(), s.GetSearchFieldModes()); relevance != "" {
+		orderClause = relevance + ", " + orderClause
 	}
 
+	// Count and fetch only the requested page at the database level
 	var pageBooks []models.Book
-	if offset < len(allBooks) {
-		pageBooks = allBooks[offset:end]
+	total, err := s.PaginateQuery(newQuery, orderClause, req, &pageBooks, s.GetRelations()...)
+	if err != nil {
+		return nil, err
 	}
 
-	lastPage := int((total + int64(req.PageSize) - 1) / int64(req.PageSize))
-
 	// Convert to interface slice
 	data := make([]interface{}, len(pageBooks))
 	for i, book := range pageBooks {
 		data[i] = book
 	}
 
-	return &contracts.PaginatedResult{
-		Data:        data,
-		Total:       total,
-		PerPage:     req.PageSize,
-		CurrentPage: req.Page,
-		LastPage:    lastPage,
-		From:        offset + 1,
-		To:          offset + len(pageBooks),
-		HasNext:     req.Page < lastPage,
-		HasPrev:     req.Page > 1,
-	}, nil
+	// Pages sorted on a cursor column also carry a nextCursor for keyset pagination
+	result := s.BuildPaginatedResult(data, total, req)
+	s.ApplyNextCursor(result, req, orderClause, pageBooks)
+	return result, nil
 }
 
 // GetListAdvanced with additional filters using GORM directly
 // Implements CrudServiceContract interface
-func (s *BookService) GetListAdvanced(req contracts.ListRequest, filters map[string]interface{}) (*contracts.PaginatedResult, error) {
+func (s *BookService) GetListAdvanced(ctx context.Context, req contracts.ListRequest, filters map[string]interface{}) (*contracts.PaginatedResult, error) {
+	db, release, err := s.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Validate and sanitize request
 	if err := s.ValidateListRequest(&req); err != nil {
 		return nil, err
@@ -144,25 +121,42 @@ func (s *BookService) GetListAdvanced(req contracts.ListRequest, filters map[str
 	if err != nil {
 		return nil, err
 	}
+	rangeFilters, err := s.BuildRangeFilters(filters, s.ValidateFilterField)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create separate queries for count and data
-	countQuery := facades.Orm().Query().Model(&models.Book{})
-	dataQuery := facades.Orm().Query().Model(&models.Book{})
+	countQuery := db.Model(&models.Book{})
+	dataQuery := s.WithRelations(db.Model(&models.Book{}), s.GetRelations())
+
+	// Soft-deleted rows stay hidden unless trashed=with|only is requested
+	trashed, _ := filters["trashed"].(string)
+	if countQuery, err = s.ApplyTrashedFilter(countQuery, trashed); err != nil {
+		return nil, err
+	}
+	if dataQuery, err = s.ApplyTrashedFilter(dataQuery, trashed); err != nil {
+		return nil, err
+	}
 
-	// Apply search to both queries if provided
+	// Apply title search to both queries if provided
+	searchFields := []string{"title"}
 	if req.Search != "" {
-		searchCondition := "title LIKE ?"
-		searchValue := "%" + req.Search + "%"
-		countQuery = countQuery.Where(searchCondition, searchValue)
-		dataQuery = dataQuery.Where(searchCondition, searchValue)
+		countQuery = s.ApplySearch(countQuery, req.Search, searchFields, s.GetSearchFieldModes())
+		dataQuery = s.ApplySearch(dataQuery, req.Search, searchFields, s.GetSearchFieldModes())
 	}
 
 	// Apply validated filters to both queries
 	for field, value := range validatedFilters {
 		var condition string
 		switch field {
-		case "status", "author":
-			condition = field + " = ?"
+		case "status", "author", "isbn", "price", "category_id":
+			// Slice values such as ["AVAILABLE", "MAINTENANCE"] match with IN (...)
+			condition, value = s.FilterCondition(field, value)
+		case "category":
+			// Category slugs resolve to ids through the categories table
+			slugCondition, slugs := s.FilterCondition("slug", value)
+			condition, value = "category_id IN (SELECT id FROM categories WHERE "+slugCondition+")", slugs
 		case "minPrice":
 			condition = "price >= ?"
 		case "maxPrice":
@@ -174,18 +168,21 @@ func (s *BookService) GetListAdvanced(req contracts.ListRequest, filters map[str
 		dataQuery = dataQuery.Where(condition, value)
 	}
 
+	// Apply {field}_from/_to/_gte/_lte bounds, e.g. created_at_from or price_lte
+	countQuery = s.ApplyRangeFilters(countQuery, rangeFilters)
+	dataQuery = s.ApplyRangeFilters(dataQuery, rangeFilters)
+
 	// Count total records
 	var total int64
 	if err := countQuery.Count(&total); err != nil {
 		return nil, err
 	}
 
-	// Add sorting to data query only
-	if req.Sort != "" {
-		dataQuery = dataQuery.Order("id DESC") // Should parse req.Sort properly
-	} else {
-		dataQuery = dataQuery.Order("id DESC")
+	// Add sorting to data query only; full-text matches rank by relevance first
+	if relevance := s.SearchRelevanceOrder(req.Search, searchFields, s.GetSearchFieldModes()); relevance != "" {
+		dataQuery = dataQuery.Order(relevance)
 	}
+	dataQuery = dataQuery.Order(s.StableOrder(s.buildOrderClause(req)))
 
 	// Calculate pagination
 	offset := (req.Page - 1) * req.PageSize
@@ -216,6 +213,42 @@ func (s *BookService) GetListAdvanced(req contracts.ListRequest, filters map[str
 	}, nil
 }
 
+// GetTrashed lists soft-deleted books for the trash screen
+// Implements TrashedServiceContract interface
+func (s *BookService) GetTrashed(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	if err := s.ValidateListRequest(&req); err != nil {
+		return nil, err
+	}
+	s.SanitizeListRequest(&req)
+
+	orderClause := s.buildOrderClause(req)
+	var pageBooks []models.Book
+	total, err := s.PaginateTrashed(ctx, &models.Book{}, orderClause, req, &pageBooks, s.GetRelations()...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]interface{}, len(pageBooks))
+	for i, record := range pageBooks {
+		data[i] = record
+	}
+
+	result := s.BuildPaginatedResult(data, total, req)
+	s.ApplyNextCursor(result, req, orderClause, pageBooks)
+	return result, nil
+}
+
+// GetRelations eager loads each book's category
+func (s *BookService) GetRelations() []string {
+	return []string{"Category"}
+}
+
+// GetRelatedRelations offers a book's loan history on /books/{id}/related/loans without eager
+// loading it with every book. Loans name their borrowers, so only librarians can list them.
+func (s *BookService) GetRelatedRelations() map[string]string {
+	return map[string]string{"Loans": "books.manage"}
+}
+
 // GetByID - using GORM directly
 // Implements CrudServiceContract interface
 func (s *BookService) GetByID(id uint) (interface{}, error) {
@@ -223,23 +256,28 @@ func (s *BookService) GetByID(id uint) (interface{}, error) {
 		return nil, fmt.Errorf("invalid ID: %d", id)
 	}
 
-	return s.getBookByID(id)
+	return s.CachedRecord(id, &models.Book{}, func() (interface{}, error) {
+		return s.getBookByID(id)
+	})
 }
 
 // getBookByID is a helper method that returns the actual model type
 func (s *BookService) getBookByID(id uint) (*models.Book, error) {
 	var book models.Book
-	if err := facades.Orm().Query().Model(&models.Book{}).Where("id = ?", id).First(&book); err != nil {
+	if err := s.WithRelations(facades.Orm().Query().Model(&models.Book{}), s.GetRelations()).Where("id = ?", id).First(&book); err != nil {
 		return nil, fmt.Errorf("book not found: %w", err)
 	}
+	if book.ID == 0 {
+		return nil, fmt.Errorf("book not found")
+	}
 
 	return &book, nil
 }
 
-// GetByISBN retrieves a book by ISBN using GORM directly
+// GetByISBN retrieves a book by ISBN using GORM directly; hyphens and spaces in isbn are ignored
 func (s *BookService) GetByISBN(isbn string) (*models.Book, error) {
 	var book models.Book
-	if err := facades.Orm().Query().Model(&models.Book{}).Where("isbn = ?", isbn).First(&book); err != nil {
+	if err := facades.Orm().Query().Model(&models.Book{}).Where("isbn = ?", models.NormalizeISBN(isbn)).First(&book); err != nil {
 		return nil, fmt.Errorf("book not found with ISBN %s: %w", isbn, err)
 	}
 
@@ -247,24 +285,26 @@ func (s *BookService) GetByISBN(isbn string) (*models.Book, error) {
 }
 
 // GetByAuthor retrieves books by author using repository
-func (s *BookService) GetByAuthor(author string, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+func (s *BookService) GetByAuthor(ctx context.Context, author string, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
 	filters := map[string]interface{}{
 		"author": author,
 	}
-	return s.GetListAdvanced(req, filters)
+	return s.GetListAdvanced(ctx, req, filters)
 }
 
 // GetAvailable retrieves available books using repository
-func (s *BookService) GetAvailable(req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+func (s *BookService) GetAvailable(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
 	filters := map[string]interface{}{
 		"status": "AVAILABLE",
 	}
-	return s.GetListAdvanced(req, filters)
+	return s.GetListAdvanced(ctx, req, filters)
 }
 
 // Create - using GORM directly with validation
 // Implements CrudServiceContract interface
 func (s *BookService) Create(data map[string]interface{}) (interface{}, error) {
+	normalizeBookISBN(data)
+
 	// Validate using validation rules
 	if err := s.validateWithRules(data, false); err != nil {
 		return nil, err
@@ -298,12 +338,21 @@ func (s *BookService) createBook(data map[string]interface{}) (*models.Book, err
 	if published, ok := data["publishedAt"].(string); ok {
 		book.PublishedAt = published
 	}
+	if categoryID, ok := bookCategoryID(data["categoryId"]); ok {
+		book.CategoryID = &categoryID
+	}
+	metadata, err := models.ToJSON(data["metadata"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid book metadata: %w", err)
+	}
+	book.Metadata = metadata
 
 	// Create using GORM
 	if err := facades.Orm().Query().Create(&book); err != nil {
 		return nil, fmt.Errorf("failed to create book: %w", err)
 	}
 
+	s.DispatchEvent(contracts.EventCreated, &book)
 	return &book, nil
 }
 
@@ -314,6 +363,8 @@ func (s *BookService) Update(id uint, data map[string]interface{}) (interface{},
 		return nil, fmt.Errorf("invalid ID: %d", id)
 	}
 
+	normalizeBookISBN(data)
+
 	// Validate using validation rules
 	if err := s.validateWithRules(data, true); err != nil {
 		return nil, err
@@ -325,11 +376,20 @@ func (s *BookService) Update(id uint, data map[string]interface{}) (interface{},
 // updateBook is a helper method that returns the actual model type
 func (s *BookService) updateBook(id uint, data map[string]interface{}) (*models.Book, error) {
 	// Check if book exists
-	_, err := s.getBookByID(id)
+	existing, err := s.getBookByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	// Loans own the BORROWED status, so edits follow the same rules as BulkUpdateStatus
+	status, changingStatus := data["status"].(string)
+	changingStatus = changingStatus && status != existing.Status
+	if changingStatus {
+		if err := checkBookStatusChange(existing.Status, status); err != nil {
+			return nil, err
+		}
+	}
+
 	// Apply column mapping to transform frontend field names to database column names
 	columnMapping := s.GetColumnMapping()
 	mappedData := make(map[string]interface{})
@@ -339,12 +399,22 @@ func (s *BookService) updateBook(id uint, data map[string]interface{}) (*models.
 		"tags": true, // Tags are not stored in the books table yet
 	}
 
-	for frontendField, value := range data {
+	// Only fillable fields are written, whatever else the caller passed
+	for frontendField, value := range contracts.OnlyFillable(data, s.GetFillableFields()) {
 		// Skip ignored fields
 		if ignoredFields[frontendField] {
 			continue
 		}
 
+		if frontendField == "categoryId" && value != nil {
+			// A category id of 0 leaves the book uncategorized
+			if categoryID, ok := bookCategoryID(value); ok {
+				value = categoryID
+			} else {
+				value = nil
+			}
+		}
+
 		if dbColumn, exists := columnMapping[frontendField]; exists {
 			mappedData[dbColumn] = value
 		} else {
@@ -353,14 +423,33 @@ func (s *BookService) updateBook(id uint, data map[string]interface{}) (*models.
 		}
 	}
 
-	// Update using GORM with properly mapped column names
+	// The metadata document is stored as sent; null clears it
+	if err := models.EncodeJSONColumns(mappedData, "metadata"); err != nil {
+		return nil, fmt.Errorf("invalid book metadata: %w", err)
+	}
+
+	// Update using GORM with properly mapped column names. A status change is guarded like
+	// changeStatus, so a borrow that lands after the read isn't overwritten.
 	var book models.Book
-	if _, err := facades.Orm().Query().Model(&book).Where("id = ?", id).Update(mappedData); err != nil {
+	query := facades.Orm().Query().Model(&book).Where("id = ?", id)
+	if changingStatus {
+		query = query.Where("status = ?", existing.Status)
+	}
+	result, err := query.Update(mappedData)
+	if err != nil {
 		return nil, fmt.Errorf("failed to update book: %w", err)
 	}
+	if changingStatus && result.RowsAffected == 0 {
+		return nil, ErrBookOnLoan
+	}
 
 	// Return updated book
-	return s.getBookByID(id)
+	updated, err := s.getBookByID(id)
+	if err != nil {
+		return nil, err
+	}
+	s.DispatchEvent(contracts.EventUpdated, updated)
+	return updated, nil
 }
 
 // Delete - using GORM directly
@@ -371,7 +460,7 @@ func (s *BookService) Delete(id uint) error {
 	}
 
 	// Check if book exists
-	_, err := s.getBookByID(id)
+	book, err := s.getBookByID(id)
 	if err != nil {
 		return err
 	}
@@ -381,84 +470,262 @@ func (s *BookService) Delete(id uint) error {
 		return fmt.Errorf("failed to delete book: %w", err)
 	}
 
+	s.DispatchEvent(contracts.EventDeleted, book)
 	return nil
 }
 
-// BorrowBook marks a book as borrowed using GORM directly
-func (s *BookService) BorrowBook(id uint) error {
-	bookData, err := s.getBookByID(id)
-	if err != nil {
+// Restore brings back a soft-deleted book and dispatches it as restored
+func (s *BookService) Restore(id uint) error {
+	if err := s.BaseCrudService.Restore(id); err != nil {
 		return err
 	}
 
-	if bookData.Status != "AVAILABLE" {
-		return fmt.Errorf("book is not available for borrowing")
+	book, err := s.getBookByID(id)
+	if err != nil {
+		return err
 	}
+	s.DispatchEvent(contracts.EventRestored, book)
+	return nil
+}
 
-	// Update status using GORM
-	if _, err := facades.Orm().Query().Model(&models.Book{}).Where("id = ?", id).Update("status", "BORROWED"); err != nil {
-		return fmt.Errorf("failed to update book status: %w", err)
+// ForceDelete permanently removes a book, soft-deleted or not, and dispatches its last state as
+// force deleted
+func (s *BookService) ForceDelete(id uint) error {
+	var book models.Book
+	if err := facades.Orm().Query().WithTrashed().Where("id = ?", id).Find(&book); err != nil {
+		return fmt.Errorf("failed to load book: %w", err)
+	}
+	if book.ID == 0 {
+		return fmt.Errorf("%w: no book with ID %d", contracts.ErrRecordNotFound, id)
 	}
 
+	if err := s.BaseCrudService.ForceDelete(id); err != nil {
+		return err
+	}
+	s.DispatchEvent(contracts.EventForceDeleted, &book)
 	return nil
 }
 
-// ReturnBook marks a book as available using GORM directly
-func (s *BookService) ReturnBook(id uint) error {
-	bookData, err := s.getBookByID(id)
+// ErrBookNotAvailable is returned when borrowing a book that is not AVAILABLE
+var ErrBookNotAvailable = errors.New("book is not available for borrowing")
+
+// ErrBookNotBorrowed is returned when returning a book that is not BORROWED
+var ErrBookNotBorrowed = errors.New("book is not currently borrowed")
+
+// ErrBookAlreadyHeld is returned when a user borrows a book they already have on loan
+var ErrBookAlreadyHeld = errors.New("you already have this book on loan")
+
+// ErrLoanLimitReached is returned when a user already holds MaxLoans books
+var ErrLoanLimitReached = errors.New("loan limit reached; return a book before borrowing another")
+
+// ErrBookOnLoan is returned when an update or bulk status change targets a book that is out on loan
+var ErrBookOnLoan = errors.New("book is on loan; return it before changing its status")
+
+// ErrStatusRequiresLoan is returned when an update or bulk status change would mark a book BORROWED
+var ErrStatusRequiresLoan = errors.New("books are marked BORROWED by borrowing them")
+
+// ErrNotLoanHolder is returned when someone other than the borrower returns a book
+var ErrNotLoanHolder = errors.New("only the borrower or a librarian can return this book")
+
+// LoanPeriod is how long a book may be borrowed, from app.books.loan_days (default 14)
+func (s *BookService) LoanPeriod() time.Duration {
+	days := facades.Config().GetInt("app.books.loan_days", 14)
+	if days <= 0 {
+		days = 14
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// MaxLoans is how many books a user may hold at once, from app.books.max_loans (default 5)
+func (s *BookService) MaxLoans() int {
+	limit := facades.Config().GetInt("app.books.max_loans", 5)
+	if limit <= 0 {
+		return 5
+	}
+	return limit
+}
+
+// BorrowBook marks a book as borrowed by userID and opens a loan due after LoanPeriod. A user
+// cannot borrow a book they already hold, nor more than MaxLoans books at once.
+func (s *BookService) BorrowBook(id uint, userID uint) (*models.BookLoan, error) {
+	if _, err := s.getBookByID(id); err != nil {
+		return nil, err
+	}
+
+	var loan models.BookLoan
+	err := s.WithTransaction(func(tx orm.Query) error {
+		// Lock the borrower so parallel borrows of different books count each other's loans
+		var borrower models.User
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).LockForUpdate().First(&borrower); err != nil {
+			return fmt.Errorf("failed to lock borrower: %w", err)
+		}
+
+		var held []models.BookLoan
+		if err := tx.Model(&models.BookLoan{}).Where("user_id = ? AND returned_at IS NULL", userID).Find(&held); err != nil {
+			return fmt.Errorf("failed to load loans: %w", err)
+		}
+		for _, loan := range held {
+			if loan.BookID == id {
+				return ErrBookAlreadyHeld
+			}
+		}
+		if len(held) >= s.MaxLoans() {
+			return ErrLoanLimitReached
+		}
+
+		// Only one concurrent borrow may flip the status; the loser sees the book already borrowed
+		result, err := tx.Model(&models.Book{}).Where("id = ? AND status = ?", id, "AVAILABLE").Update("status", "BORROWED")
+		if err != nil {
+			return fmt.Errorf("failed to update book status: %w", err)
+		}
+		if result.RowsAffected == 0 {
+			return ErrBookNotAvailable
+		}
+
+		now := time.Now()
+		loan = models.BookLoan{BookID: id, UserID: userID, BorrowedAt: now, DueAt: now.Add(s.LoanPeriod())}
+		if err := tx.Create(&loan); err != nil {
+			return fmt.Errorf("failed to record loan: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if bookData.Status != "BORROWED" {
-		return fmt.Errorf("book is not currently borrowed")
+	s.dispatchBookUpdated(id)
+	return &loan, nil
+}
+
+// dispatchBookUpdated sends an updated event with the book's saved state after a loan changes its status
+func (s *BookService) dispatchBookUpdated(id uint) {
+	if book, err := s.getBookByID(id); err == nil {
+		s.DispatchEvent(contracts.EventUpdated, book)
 	}
+}
 
-	// Update status using GORM
-	if _, err := facades.Orm().Query().Model(&models.Book{}).Where("id = ?", id).Update("status", "AVAILABLE"); err != nil {
-		return fmt.Errorf("failed to update book status: %w", err)
+// ReturnBook marks a book as available again and closes its open loan. Only the borrower may
+// return it unless anyBorrower is set (librarians at the desk). Books borrowed before loans
+// were recorded have no borrower, so only anyBorrower can return them.
+func (s *BookService) ReturnBook(id uint, userID uint, anyBorrower bool) error {
+	if _, err := s.getBookByID(id); err != nil {
+		return err
 	}
 
+	err := s.WithTransaction(func(tx orm.Query) error {
+		result, err := tx.Model(&models.Book{}).Where("id = ? AND status = ?", id, "BORROWED").Update("status", "AVAILABLE")
+		if err != nil {
+			return fmt.Errorf("failed to update book status: %w", err)
+		}
+		if result.RowsAffected == 0 {
+			return ErrBookNotBorrowed
+		}
+
+		if !anyBorrower {
+			var loan models.BookLoan
+			if err := tx.Where("book_id = ? AND returned_at IS NULL", id).First(&loan); err != nil {
+				return fmt.Errorf("failed to load loan: %w", err)
+			}
+			if loan.ID == 0 || loan.UserID != userID {
+				return ErrNotLoanHolder
+			}
+		}
+
+		if _, err := tx.Model(&models.BookLoan{}).Where("book_id = ? AND returned_at IS NULL", id).Update("returned_at", time.Now()); err != nil {
+			return fmt.Errorf("failed to close loan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.dispatchBookUpdated(id)
 	return nil
 }
 
-// validateBookData performs simple validation
+// GetOverdueLoans pages through open loans past their due date, longest overdue first,
+// with the book and the borrower loaded
+func (s *BookService) GetOverdueLoans(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	db, release, err := s.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := s.ValidateListRequest(&req); err != nil {
+		return nil, err
+	}
+	s.SanitizeListRequest(&req)
+
+	now := time.Now()
+	newQuery := func() orm.Query {
+		return db.Model(&models.BookLoan{}).Where("returned_at IS NULL AND due_at < ?", now)
+	}
+
+	var loans []models.BookLoan
+	total, err := s.PaginateQuery(newQuery, "due_at ASC, id ASC", req, &loans, "Book", "User")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]interface{}, len(loans))
+	for i, loan := range loans {
+		data[i] = loan
+	}
+	return s.BuildPaginatedResult(data, total, req), nil
+}
+
+// validateBookData performs simple validation, collecting every failure by field
 func (s *BookService) validateBookData(data map[string]interface{}, isUpdate bool) error {
+	errs := contracts.NewFieldValidationError()
+
 	// Required fields for creation
 	if !isUpdate {
 		requiredFields := []string{"title", "author", "isbn"}
 		for _, field := range requiredFields {
 			if value, exists := data[field]; !exists || value == "" {
-				return fmt.Errorf("%s is required", field)
+				errs.Add(field, fmt.Sprintf("%s is required", field))
 			}
 		}
 	}
 
-	// Validate ISBN format if provided
+	// Validate ISBN if provided; it arrives normalized, so only digits (and an ISBN-10 X) remain
 	if isbn, ok := data["isbn"].(string); ok && isbn != "" {
-		if len(isbn) < 10 || len(isbn) > 17 {
-			return fmt.Errorf("invalid ISBN format")
+		if len(isbn) != 10 && len(isbn) != 13 {
+			errs.Add("isbn", "ISBN must have 10 or 13 digits")
+		} else if !models.ValidISBN(isbn) {
+			errs.Add("isbn", "ISBN check digit is incorrect; this is not a valid ISBN-10 or ISBN-13")
 		}
 	}
 
 	// Validate status if provided
 	if status, exists := data["status"]; exists {
-		validStatuses := []string{"AVAILABLE", "BORROWED", "MAINTENANCE"}
-		statusStr, ok := status.(string)
-		if !ok {
-			return fmt.Errorf("status must be a string")
+		if statusStr, ok := status.(string); !ok {
+			errs.Add("status", "status must be a string")
+		} else if !IsBookStatus(statusStr) {
+			errs.Add("status", "status must be one of: "+strings.Join(BookStatuses, ", "))
 		}
+	}
 
-		valid := false
-		for _, validStatus := range validStatuses {
-			if statusStr == validStatus {
-				valid = true
-				break
+	// Validate category if provided; nil or 0 leaves the book uncategorized
+	if value, exists := data["categoryId"]; exists && value != nil {
+		if categoryID, ok := bookCategoryID(value); !ok {
+			if !isZeroCategoryID(value) {
+				errs.Add("categoryId", "categoryId must be a positive integer")
+			}
+		} else {
+			var count int64
+			if err := facades.Orm().Query().Model(&models.Category{}).Where("id = ?", categoryID).Count(&count); err != nil || count == 0 {
+				errs.Add("categoryId", "category does not exist")
 			}
 		}
+	}
 
-		if !valid {
-			return fmt.Errorf("status must be one of: AVAILABLE, BORROWED, MAINTENANCE")
+	// Validate metadata if provided; it is a free-form JSON object, and null clears it
+	if value, exists := data["metadata"]; exists && value != nil {
+		if _, ok := value.(map[string]interface{}); !ok {
+			errs.Add("metadata", "metadata must be a JSON object")
 		}
 	}
 
@@ -467,25 +734,52 @@ func (s *BookService) validateBookData(data map[string]interface{}, isUpdate boo
 		switch v := price.(type) {
 		case float64:
 			if v < 0 {
-				return fmt.Errorf("price cannot be negative")
+				errs.Add("price", "price cannot be negative")
 			}
 		case string:
 			if p, err := strconv.ParseFloat(v, 64); err != nil || p < 0 {
-				return fmt.Errorf("invalid price format")
+				errs.Add("price", "invalid price format")
 			}
 		default:
-			return fmt.Errorf("price must be a number")
+			errs.Add("price", "price must be a number")
 		}
 	}
 
-	return nil
+	return errs.Err()
+}
+
+// normalizeBookISBN stores the ISBN in data in canonical form before it is validated or saved
+func normalizeBookISBN(data map[string]interface{}) {
+	if isbn, ok := data["isbn"].(string); ok {
+		data["isbn"] = models.NormalizeISBN(isbn)
+	}
+}
+
+// bookCategoryID reads a positive category id from request data, where it arrives as an
+// integer from bound requests or a float64 from decoded JSON
+func bookCategoryID(value interface{}) (uint, bool) {
+	coerced, ok := contracts.CoerceFilterValue(value, contracts.FilterTypeInt)
+	if !ok {
+		return 0, false
+	}
+	id, ok := coerced.(int64)
+	if !ok || id <= 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// isZeroCategoryID reports whether value is the 0 that clears a book's category
+func isZeroCategoryID(value interface{}) bool {
+	coerced, ok := contracts.CoerceFilterValue(value, contracts.FilterTypeInt)
+	return ok && coerced == int64(0)
 }
 
 // CONTRACT IMPLEMENTATIONS - Required by CompleteCrudService interface
 
 // PaginationServiceContract implementation
-func (s *BookService) GetPaginatedList(req contracts.ListRequest) (*contracts.PaginatedResult, error) {
-	return s.GetList(req)
+func (s *BookService) GetPaginatedList(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	return s.GetList(ctx, req)
 }
 
 // SortableServiceContract implementation
@@ -511,9 +805,33 @@ func (s *BookService) MapSortField(frontendField string) (string, bool) {
 	return "", false
 }
 
+// GetDefaultSort lists books alphabetically by title, as a catalogue is browsed
+func (s *BookService) GetDefaultSort() (string, string) {
+	return "title", "ASC"
+}
+
+// buildOrderClause resolves the requested sort into an ORDER BY clause, falling back to the default sort
+func (s *BookService) buildOrderClause(req contracts.ListRequest) string {
+	// sort=status,title with direction=asc,desc sorts by several columns; invalid ones are dropped
+	if orderClause := s.BuildOrderClause(req, s.ValidateSortField, s.MapSortField); orderClause != "" {
+		return orderClause
+	}
+
+	defaultField, defaultDir := s.GetDefaultSort()
+	return defaultField + " " + defaultDir
+}
+
+// RecordExists normalizes ISBNs before the duplicate check, since they are stored without hyphens
+func (s *BookService) RecordExists(field string, value interface{}, exceptID uint) (bool, error) {
+	if isbn, ok := value.(string); ok && field == "isbn" {
+		value = models.NormalizeISBN(isbn)
+	}
+	return s.BaseCrudService.RecordExists(field, value, exceptID)
+}
+
 // FilterableServiceContract implementation
 func (s *BookService) GetFilterableFields() []string {
-	return []string{"status", "author", "minPrice", "maxPrice", "isbn"}
+	return []string{"status", "author", "minPrice", "maxPrice", "isbn", "price", "published_at", "created_at", "category", "category_id"}
 }
 
 func (s *BookService) ValidateFilterField(field string) bool {
@@ -530,14 +848,51 @@ func (s *BookService) GetSearchableFields() []string {
 	return []string{"title", "author", "description", "isbn"}
 }
 
+// GetFilterFieldTypes coerces price filters from query strings to numbers
+func (s *BookService) GetFilterFieldTypes() map[string]string {
+	return map[string]string{
+		"price":    contracts.FilterTypeFloat,
+		"minPrice": contracts.FilterTypeFloat,
+		"maxPrice": contracts.FilterTypeFloat,
+
+		"category_id": contracts.FilterTypeInt,
+	}
+}
+
+// BookStatuses are the statuses a book can have
+var BookStatuses = []string{"AVAILABLE", "BORROWED", "MAINTENANCE"}
+
+// IsBookStatus reports whether status is one of BookStatuses
+func IsBookStatus(status string) bool {
+	for _, valid := range BookStatuses {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// bookMultiValueFilters are the filters that accept a list of values, matched with IN (...)
+var bookMultiValueFilters = map[string]bool{"status": true, "author": true, "isbn": true, "category": true, "category_id": true}
+
 func (s *BookService) BuildFilterQuery(filters map[string]interface{}) (map[string]interface{}, error) {
 	validatedFilters := make(map[string]interface{})
+	fieldTypes := s.GetFilterFieldTypes()
 
 	for field, value := range filters {
 		if !s.ValidateFilterField(field) {
 			continue // Skip invalid fields
 		}
 
+		value, ok := contracts.CoerceFilterValue(value, fieldTypes[field])
+		if !ok {
+			continue // Skip values that don't convert to the field's type
+		}
+
+		if _, isList := contracts.FilterValueList(value); isList && !bookMultiValueFilters[field] {
+			continue // Skip lists for single-value filters
+		}
+
 		if !s.ValidateFilterValue(field, value) {
 			continue // Skip invalid values
 		}
@@ -549,24 +904,28 @@ func (s *BookService) BuildFilterQuery(filters map[string]interface{}) (map[stri
 }
 
 // SearchableServiceContract implementation
-func (s *BookService) Search(query string, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+func (s *BookService) Search(ctx context.Context, query string, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
 	if err := s.ValidateSearchQuery(query); err != nil {
 		return nil, err
 	}
 
 	req.Search = query
-	return s.GetList(req)
+	return s.GetList(ctx, req)
 }
 
 func (s *BookService) ValidateSearchQuery(query string) error {
-	query = strings.TrimSpace(query)
-	if len(query) < 2 {
-		return fmt.Errorf("search query must be at least 2 characters")
-	}
-	if len(query) > 100 {
-		return fmt.Errorf("search query cannot exceed 100 characters")
-	}
-	return nil
+	minLength, maxLength := s.GetSearchConstraints()
+	return s.ValidateSearchLength(query, minLength, maxLength)
+}
+
+// GetSearchConstraints allows single-character searches so ISBN prefixes can be looked up
+func (s *BookService) GetSearchConstraints() (minLength, maxLength int) {
+	return 1, contracts.DefaultSearchMaxLength
+}
+
+// GetSearchFieldModes matches ISBNs exactly so a partial number doesn't pull in unrelated books
+func (s *BookService) GetSearchFieldModes() map[string]string {
+	return map[string]string{"isbn": contracts.SearchMatchExact}
 }
 
 // BulkOperationsContract implementation
@@ -592,30 +951,82 @@ func (s *BookService) BulkUpdate(ids []uint, data map[string]interface{}) error
 		return err
 	}
 
-	for _, id := range ids {
+	// Continue past individual failures so callers get a per-ID summary
+	return s.RunBulkOperation("update", ids, func(id uint) error {
 		_, err := s.Update(id, data)
-		if err != nil {
-			return fmt.Errorf("bulk update failed for ID %d: %w", id, err)
-		}
+		return err
+	})
+}
+
+// BulkUpdateStatus moves each book to status, reporting per-ID failures. Loans own the BORROWED
+// status: a book on loan keeps it until it is returned, and only borrowing can set it.
+func (s *BookService) BulkUpdateStatus(ids []uint, status string) error {
+	if !IsBookStatus(status) {
+		return fmt.Errorf("status must be one of: %s", strings.Join(BookStatuses, ", "))
+	}
+	if err := s.ValidateBulkOperation(ids); err != nil {
+		return err
 	}
 
-	return nil
+	return s.RunBulkOperation("status update", ids, func(id uint) error {
+		return s.changeStatus(id, status)
+	})
 }
 
-func (s *BookService) BulkDelete(ids []uint) error {
-	if err := s.ValidateBulkOperation(ids); err != nil {
+// changeStatus sets one book's status for BulkUpdateStatus
+func (s *BookService) changeStatus(id uint, status string) error {
+	var book models.Book
+	if err := facades.Orm().Query().Where("id = ?", id).First(&book); err != nil {
+		return fmt.Errorf("failed to load book: %w", err)
+	}
+	if book.ID == 0 {
+		return fmt.Errorf("book not found")
+	}
+	if book.Status == status {
+		return nil
+	}
+	if err := checkBookStatusChange(book.Status, status); err != nil {
 		return err
 	}
 
-	for _, id := range ids {
-		if err := s.Delete(id); err != nil {
-			return fmt.Errorf("bulk delete failed for ID %d: %w", id, err)
-		}
+	// The status guard keeps a borrow that lands after the read from being overwritten
+	result, err := facades.Orm().Query().Model(&models.Book{}).Where("id = ? AND status = ?", id, book.Status).Update("status", status)
+	if err != nil {
+		return fmt.Errorf("failed to update book status: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return ErrBookOnLoan
 	}
 
+	book.Status = status
+	s.DispatchEvent(contracts.EventUpdated, &book)
 	return nil
 }
 
+// checkBookStatusChange reports whether a book may move from one status to another by hand.
+// Only borrowing sets BORROWED and only returning clears it.
+func checkBookStatusChange(from, to string) error {
+	if from == to {
+		return nil
+	}
+	if from == "BORROWED" {
+		return ErrBookOnLoan
+	}
+	if to == "BORROWED" {
+		return ErrStatusRequiresLoan
+	}
+	return nil
+}
+
+func (s *BookService) BulkDelete(ids []uint) error {
+	if err := s.ValidateBulkOperation(ids); err != nil {
+		return err
+	}
+
+	// Continue past individual failures so callers get a per-ID summary
+	return s.RunBulkOperation("delete", ids, s.Delete)
+}
+
 // CrudServiceConfiguration implementation
 func (s *BookService) GetModel() interface{} {
 	return &models.Book{}
@@ -625,14 +1036,21 @@ func (s *BookService) GetValidationRules() map[string]interface{} {
 	return map[string]interface{}{
 		"title":       "required|string|max:255",
 		"author":      "required|string|max:255",
-		"isbn":        "required|string|min:10|max:17",
+		"isbn":        "required|string|isbn",
 		"description": "string|max:1000",
 		"price":       "numeric|min:0",
 		"status":      "in:AVAILABLE,BORROWED,MAINTENANCE",
 		"publishedAt": "string",
+		"categoryId":  "integer",
+		"metadata":    "map",
 	}
 }
 
+// GetFillableFields lists the fields book create and update write
+func (s *BookService) GetFillableFields() []string {
+	return []string{"title", "author", "isbn", "description", "price", "status", "publishedAt", "tags", "categoryId", "metadata"}
+}
+
 func (s *BookService) GetColumnMapping() map[string]string {
 	return map[string]string{
 		"id":           "id",
@@ -648,6 +1066,9 @@ func (s *BookService) GetColumnMapping() map[string]string {
 		"created_at":   "created_at",
 		"updated_at":   "updated_at",
 		"published_at": "published_at",
+		"categoryId":   "category_id",
+		"category_id":  "category_id",
+		"metadata":     "metadata",
 	}
 }
 
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"players/app/contracts"
+	"players/app/models"
+	"players/tests/testdb"
+)
+
+// TestCheckBookStatusChange covers the rule updateBook applies when a PUT or PATCH carries a status,
+// and BulkUpdateStatus for each book: a borrowed book can't be edited back to AVAILABLE while its
+// loan is open, and no edit can mark a book BORROWED.
+func TestCheckBookStatusChange(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     error
+	}{
+		{"BORROWED", "AVAILABLE", ErrBookOnLoan},
+		{"BORROWED", "MAINTENANCE", ErrBookOnLoan},
+		{"BORROWED", "BORROWED", nil},
+		{"AVAILABLE", "BORROWED", ErrStatusRequiresLoan},
+		{"MAINTENANCE", "BORROWED", ErrStatusRequiresLoan},
+		{"AVAILABLE", "MAINTENANCE", nil},
+		{"MAINTENANCE", "AVAILABLE", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			if err := checkBookStatusChange(tt.from, tt.to); !errors.Is(err, tt.want) {
+				t.Errorf("checkBookStatusChange(%q, %q) = %v, want %v", tt.from, tt.to, err, tt.want)
+			}
+		})
+	}
+}
+
+// newLoanFixture opens a database with count AVAILABLE books and two readers, and a BookService
+// on it with loans limited to maxLoans
+func newLoanFixture(t *testing.T, count, maxLoans int) (*testdb.Database, *BookService, []models.Book, []models.User) {
+	db := testdb.Open(t, &models.Permission{}, &models.Role{}, &models.User{}, &models.UserRole{}, &models.Category{}, &models.Book{}, &models.BookLoan{})
+	db.Config["app.books.max_loans"] = maxLoans
+
+	books := make([]models.Book, count)
+	for i := range books {
+		books[i] = models.Book{Title: fmt.Sprintf("Book %d", i+1), Author: "Author", ISBN: fmt.Sprintf("isbn-%d", i+1), Status: "AVAILABLE"}
+		if err := db.Create(&books[i]).Error; err != nil {
+			t.Fatalf("create book: %v", err)
+		}
+	}
+	users := []models.User{
+		{Name: "Reader", Email: "reader@example.com", Password: "x", IsActive: true},
+		{Name: "Other", Email: "other@example.com", Password: "x", IsActive: true},
+	}
+	for i := range users {
+		if err := db.Create(&users[i]).Error; err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	service := NewBookService()
+	service.SetRecordCache(false)
+	return db, service, books, users
+}
+
+func TestBorrowBookLimits(t *testing.T) {
+	db, service, books, users := newLoanFixture(t, 3, 2)
+	reader := users[0].ID
+
+	loan, err := service.BorrowBook(books[0].ID, reader)
+	if err != nil {
+		t.Fatalf("BorrowBook: %v", err)
+	}
+	if loan.UserID != reader || loan.BookID != books[0].ID || !loan.DueAt.After(loan.BorrowedAt) {
+		t.Errorf("BorrowBook() = %+v, want an open loan of book 1 to the reader", loan)
+	}
+
+	// The book is now BORROWED, and holding it is reported before its status
+	if _, err := service.BorrowBook(books[0].ID, reader); !errors.Is(err, ErrBookAlreadyHeld) {
+		t.Errorf("borrowing a held book error = %v, want %v", err, ErrBookAlreadyHeld)
+	}
+	if _, err := service.BorrowBook(books[0].ID, users[1].ID); !errors.Is(err, ErrBookNotAvailable) {
+		t.Errorf("borrowing another reader's book error = %v, want %v", err, ErrBookNotAvailable)
+	}
+
+	if _, err := service.BorrowBook(books[1].ID, reader); err != nil {
+		t.Fatalf("BorrowBook: %v", err)
+	}
+	if _, err := service.BorrowBook(books[2].ID, reader); !errors.Is(err, ErrLoanLimitReached) {
+		t.Errorf("borrowing past the limit error = %v, want %v", err, ErrLoanLimitReached)
+	}
+
+	var book models.Book
+	db.First(&book, books[2].ID)
+	if book.Status != "AVAILABLE" {
+		t.Errorf("book refused at the limit has status %s, want AVAILABLE", book.Status)
+	}
+}
+
+func TestReturnBook(t *testing.T) {
+	db, service, books, users := newLoanFixture(t, 2, 5)
+	reader, other := users[0].ID, users[1].ID
+
+	if _, err := service.BorrowBook(books[0].ID, reader); err != nil {
+		t.Fatalf("BorrowBook: %v", err)
+	}
+
+	if err := service.ReturnBook(books[0].ID, other, false); !errors.Is(err, ErrNotLoanHolder) {
+		t.Errorf("return by another reader error = %v, want %v", err, ErrNotLoanHolder)
+	}
+	if err := service.ReturnBook(books[1].ID, reader, false); !errors.Is(err, ErrBookNotBorrowed) {
+		t.Errorf("return of an available book error = %v, want %v", err, ErrBookNotBorrowed)
+	}
+
+	if err := service.ReturnBook(books[0].ID, reader, false); err != nil {
+		t.Fatalf("ReturnBook: %v", err)
+	}
+	var book models.Book
+	db.First(&book, books[0].ID)
+	var open int64
+	db.Model(&models.BookLoan{}).Where("book_id = ? AND returned_at IS NULL", books[0].ID).Count(&open)
+	if book.Status != "AVAILABLE" || open != 0 {
+		t.Errorf("after return: status %s with %d open loans, want AVAILABLE with none", book.Status, open)
+	}
+
+	// A librarian can return a book for whoever holds it
+	if _, err := service.BorrowBook(books[0].ID, reader); err != nil {
+		t.Fatalf("BorrowBook: %v", err)
+	}
+	if err := service.ReturnBook(books[0].ID, other, true); err != nil {
+		t.Errorf("return for any borrower: %v", err)
+	}
+}
+
+func TestGetOverdueLoans(t *testing.T) {
+	db, service, books, users := newLoanFixture(t, 2, 5)
+	for _, book := range books {
+		if _, err := service.BorrowBook(book.ID, users[0].ID); err != nil {
+			t.Fatalf("BorrowBook: %v", err)
+		}
+	}
+	db.Model(&models.BookLoan{}).Where("book_id = ?", books[1].ID).Update("due_at", time.Now().Add(-time.Hour))
+
+	result, err := service.GetOverdueLoans(context.Background(), contracts.ListRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("GetOverdueLoans: %v", err)
+	}
+	if result.Total != 1 || len(result.Data) != 1 {
+		t.Fatalf("GetOverdueLoans() returned %d of %d loans, want only book 2's", len(result.Data), result.Total)
+	}
+}
+
+// TestRestoreAndForceDeleteDispatchEvents checks that bringing a book back and removing it for good
+// reach lifecycle listeners, and so its webhook, like the other changes to a book
+func TestRestoreAndForceDeleteDispatchEvents(t *testing.T) {
+	_, service, books, _ := newLoanFixture(t, 1, 5)
+
+	var events []string
+	for _, event := range []string{contracts.EventRestored, contracts.EventForceDeleted} {
+		event := event
+		contracts.RegisterObserver("books", event, func(record interface{}) {
+			if book, ok := record.(*models.Book); ok && book.ID == books[0].ID {
+				events = append(events, event)
+			}
+		})
+	}
+
+	if err := service.Delete(books[0].ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := service.Restore(books[0].ID); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if err := service.Delete(books[0].ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := service.ForceDelete(books[0].ID); err != nil {
+		t.Fatalf("ForceDelete: %v", err)
+	}
+	if err := service.ForceDelete(books[0].ID); !errors.Is(err, contracts.ErrRecordNotFound) {
+		t.Errorf("second ForceDelete() error = %v, want %v", err, contracts.ErrRecordNotFound)
+	}
+
+	want := []string{contracts.EventRestored, contracts.EventForceDeleted}
+	if fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("dispatched %v, want %v", events, want)
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"players/app/models"
+)
+
+// ErrInvalidVerificationToken is returned for malformed, tampered or expired verification tokens
+var ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+
+// EmailVerificationService issues and checks signed email verification tokens. Tokens are
+// "{user id}.{expiry unix}.{signature}" signed with APP_KEY over the user's current email,
+// so nothing is stored and changing the email invalidates outstanding links.
+type EmailVerificationService struct{}
+
+// NewEmailVerificationService creates a new email verification service
+func NewEmailVerificationService() *EmailVerificationService {
+	return &EmailVerificationService{}
+}
+
+// Required reports whether unverified users are refused at login (auth.verification.required)
+func (s *EmailVerificationService) Required() bool {
+	return facades.Config().GetBool("auth.verification.required", false)
+}
+
+// Token returns a signed verification token for user, valid for auth.verification.ttl minutes
+func (s *EmailVerificationService) Token(user *models.User) string {
+	ttl := time.Duration(facades.Config().GetInt("auth.verification.ttl", 1440)) * time.Minute
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	id := strconv.FormatUint(uint64(user.ID), 10)
+	return id + "." + expires + "." + signVerification(id, expires, user.Email)
+}
+
+// SendVerification delivers the verification link. There is no mailer yet, so the link is
+// logged when app.debug is on, the same way other development-only links are surfaced.
+func (s *EmailVerificationService) SendVerification(user *models.User) {
+	if !facades.Config().GetBool("app.debug", false) {
+		facades.Log().Info(fmt.Sprintf("Email verification pending for user %d", user.ID))
+		return
+	}
+
+	link := strings.TrimRight(facades.Config().GetString("http.url"), "/") + "/api/auth/verify/" + s.Token(user)
+	facades.Log().Info(fmt.Sprintf("Email verification link for %s: %s", user.Email, link))
+}
+
+// Verify checks token and marks the user it names as verified. Verifying twice is not an error.
+func (s *EmailVerificationService) Verify(token string) (*models.User, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidVerificationToken
+	}
+	id, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	var user models.User
+	if err := facades.Orm().Query().Where("id = ?", id).First(&user); err != nil || user.ID == 0 {
+		return nil, ErrInvalidVerificationToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(signVerification(parts[0], parts[1], user.Email))) {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return &user, nil
+	}
+
+	now := time.Now()
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", user.ID).Update(map[string]interface{}{
+		"email_verified":    true,
+		"email_verified_at": now,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &now
+	return &user, nil
+}
+
+func signVerification(id, expires, email string) string {
+	mac := hmac.New(sha256.New, []byte(facades.Config().GetString("app.key")))
+	mac.Write([]byte(id + "|" + expires + "|" + strings.ToLower(email)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
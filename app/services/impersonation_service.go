@@ -0,0 +1,156 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"players/app/auth"
+	"players/app/models"
+)
+
+var (
+	// ErrImpersonationTargetNotFound is returned when the user to impersonate doesn't exist
+	ErrImpersonationTargetNotFound = errors.New("user to impersonate not found")
+	// ErrImpersonationDenied is returned for targets the impersonator may not act as: themselves,
+	// super admins, inactive users and users whose highest role is not below the impersonator's
+	ErrImpersonationDenied = errors.New("impersonation of this user is not allowed")
+	// ErrAlreadyImpersonating is returned when the current session is itself an impersonation
+	ErrAlreadyImpersonating = errors.New("already impersonating a user")
+	// ErrNotImpersonating is returned when the current session is not an active impersonation
+	ErrNotImpersonating = errors.New("not impersonating a user")
+)
+
+// ImpersonationService starts and stops impersonation sessions kept in impersonations. Both
+// transitions are written to the audit trail against the impersonated user.
+type ImpersonationService struct {
+	audit *AuditService
+}
+
+// NewImpersonationService creates a new impersonation service
+func NewImpersonationService() *ImpersonationService {
+	return &ImpersonationService{audit: NewAuditService()}
+}
+
+// TTL is how long an impersonation lasts, the access token lifetime from jwt.ttl in minutes
+func (s *ImpersonationService) TTL() time.Duration {
+	return time.Duration(facades.Config().GetInt("jwt.ttl", 720)) * time.Minute
+}
+
+// Start makes impersonator act as the user targetID. currentToken is the impersonator's access
+// token; issueToken logs the target in and returns their access token, which becomes the session.
+func (s *ImpersonationService) Start(impersonator *models.User, currentToken string, targetID uint, issueToken func(userID uint) (string, error)) (string, *models.Impersonation, error) {
+	if s.Active(currentToken) != nil {
+		return "", nil, ErrAlreadyImpersonating
+	}
+
+	var target models.User
+	if err := facades.Orm().Query().With("Roles").Where("id = ?", targetID).First(&target); err != nil || target.ID == 0 {
+		return "", nil, ErrImpersonationTargetNotFound
+	}
+	if !s.canImpersonate(impersonator, &target) {
+		return "", nil, ErrImpersonationDenied
+	}
+
+	token, err := issueToken(target.ID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to issue impersonation token: %w", err)
+	}
+
+	now := time.Now()
+	impersonation := models.Impersonation{
+		ImpersonatorID: impersonator.ID,
+		ImpersonatedID: target.ID,
+		TokenHash:      hashAccessToken(token),
+		StartedAt:      now,
+		ExpiresAt:      now.Add(s.TTL()),
+	}
+	if err := facades.Orm().Query().Create(&impersonation); err != nil {
+		return "", nil, fmt.Errorf("failed to store impersonation: %w", err)
+	}
+	impersonation.Impersonated = &target
+
+	s.record(impersonator.ID, &impersonation, AuditActionImpersonate)
+	return token, &impersonation, nil
+}
+
+// Stop ends the impersonation whose session is token and returns it with the impersonator loaded
+func (s *ImpersonationService) Stop(token string) (*models.Impersonation, error) {
+	impersonation := s.Active(token)
+	if impersonation == nil {
+		return nil, ErrNotImpersonating
+	}
+
+	now := time.Now()
+	// Only one concurrent stop may end the session
+	result, err := facades.Orm().Query().Model(&models.Impersonation{}).
+		Where("id = ? AND ended_at IS NULL", impersonation.ID).
+		Update("ended_at", now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end impersonation: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrNotImpersonating
+	}
+	impersonation.EndedAt = &now
+
+	s.record(impersonation.ImpersonatorID, impersonation, AuditActionStopImpersonating)
+	return impersonation, nil
+}
+
+// Active returns the ongoing impersonation whose session is token, or nil
+func (s *ImpersonationService) Active(token string) *models.Impersonation {
+	if token == "" {
+		return nil
+	}
+
+	var impersonation models.Impersonation
+	if err := facades.Orm().Query().With("Impersonator").
+		Where("token_hash = ?", hashAccessToken(token)).
+		First(&impersonation); err != nil || impersonation.ID == 0 {
+		return nil
+	}
+	if !impersonation.IsActive(time.Now()) {
+		return nil
+	}
+	return &impersonation
+}
+
+// canImpersonate keeps impersonation from being a way up the hierarchy: nobody may act as a super
+// admin or as a user whose highest role they don't outrank
+func (s *ImpersonationService) canImpersonate(impersonator, target *models.User) bool {
+	if impersonator == nil || impersonator.ID == target.ID || !target.IsActive || target.IsSuperAdminUser() {
+		return false
+	}
+	highest := target.GetHighestRole()
+	if highest == nil {
+		return true
+	}
+	return auth.GetPermissionService().OutranksRole(impersonator, highest)
+}
+
+// record audits a transition against the impersonated user; a failure is logged, not returned,
+// since the session change has already happened
+func (s *ImpersonationService) record(actorID uint, impersonation *models.Impersonation, action string) {
+	session := map[string]interface{}{
+		"impersonation_id": impersonation.ID,
+		"impersonator_id":  impersonation.ImpersonatorID,
+	}
+
+	var before, after interface{} = nil, session
+	if action == AuditActionStopImpersonating {
+		before, after = session, nil
+	}
+	if err := s.audit.Record(&actorID, "users", impersonation.ImpersonatedID, action, before, after); err != nil {
+		facades.Log().Error("Failed to audit " + action + ": " + err.Error())
+	}
+}
+
+// hashAccessToken identifies an access token without storing it
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
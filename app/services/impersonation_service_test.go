@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"players/app/auth"
+	"players/app/models"
+	"players/tests/testdb"
+)
+
+// impersonationFixture is an admin, a user below them, a peer at their level and a super admin
+type impersonationFixture struct {
+	db                       *testdb.Database
+	admin, user, peer, super *models.User
+}
+
+func newImpersonationFixture(t *testing.T) *impersonationFixture {
+	db := testdb.Open(t, &models.Permission{}, &models.Role{}, &models.User{}, &models.UserRole{}, &models.Impersonation{}, &models.AuditLog{})
+
+	adminRole := models.Role{Name: "Admin", Slug: "admin", Level: 80, IsActive: true}
+	userRole := models.Role{Name: "Member", Slug: "member", Level: 10, IsActive: true}
+	for _, role := range []*models.Role{&adminRole, &userRole} {
+		if err := db.Create(role).Error; err != nil {
+			t.Fatalf("create role: %v", err)
+		}
+	}
+
+	create := func(email string, superAdmin bool, roles ...models.Role) *models.User {
+		user := &models.User{Name: email, Email: email, Password: "x", IsActive: true, IsSuperAdmin: superAdmin, Roles: roles}
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("create user %s: %v", email, err)
+		}
+		return user
+	}
+
+	return &impersonationFixture{
+		db:    db,
+		admin: create("admin@example.com", false, adminRole),
+		user:  create("user@example.com", false, userRole),
+		peer:  create("peer@example.com", false, adminRole),
+		super: create("super@example.com", true),
+	}
+}
+
+func issueTestToken(token string) func(uint) (string, error) {
+	return func(uint) (string, error) { return token, nil }
+}
+
+func TestImpersonationTargets(t *testing.T) {
+	fixture := newImpersonationFixture(t)
+	service := NewImpersonationService()
+
+	tests := []struct {
+		name    string
+		target  *models.User
+		wantErr error
+	}{
+		{"user below the impersonator", fixture.user, nil},
+		{"themselves", fixture.admin, ErrImpersonationDenied},
+		{"a super admin", fixture.super, ErrImpersonationDenied},
+		{"a user they don't outrank", fixture.peer, ErrImpersonationDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := service.Start(fixture.admin, "admin-token", tt.target.ID, issueTestToken("token-"+tt.target.Email))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Start() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if _, err := service.Stop("token-" + tt.target.Email); err != nil {
+					t.Fatalf("Stop: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestImpersonationStartStopRoundTrip(t *testing.T) {
+	fixture := newImpersonationFixture(t)
+	service := NewImpersonationService()
+
+	token, started, err := service.Start(fixture.admin, "admin-token", fixture.user.ID, issueTestToken("impersonation-token"))
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if token != "impersonation-token" || started.ImpersonatorID != fixture.admin.ID || started.ImpersonatedID != fixture.user.ID {
+		t.Fatalf("Start() = %q, %+v, want the issued token for admin acting as user", token, started)
+	}
+
+	active := service.Active(token)
+	if active == nil || active.ID != started.ID {
+		t.Fatalf("Active() = %+v, want the started impersonation", active)
+	}
+	if _, _, err := service.Start(fixture.user, token, fixture.peer.ID, issueTestToken("nested-token")); !errors.Is(err, ErrAlreadyImpersonating) {
+		t.Errorf("Start() from an impersonation session error = %v, want %v", err, ErrAlreadyImpersonating)
+	}
+
+	stopped, err := service.Stop(token)
+	if err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if stopped.EndedAt == nil || stopped.Impersonator == nil || stopped.Impersonator.ID != fixture.admin.ID {
+		t.Errorf("Stop() = %+v, want the ended impersonation with the admin loaded", stopped)
+	}
+	if service.Active(token) != nil {
+		t.Errorf("Active() after Stop is still active")
+	}
+	if _, err := service.Stop(token); !errors.Is(err, ErrNotImpersonating) {
+		t.Errorf("second Stop() error = %v, want %v", err, ErrNotImpersonating)
+	}
+
+	var actions []string
+	fixture.db.Model(&models.AuditLog{}).Where("resource_type = ? AND resource_id = ?", "users", fixture.user.ID).Order("id").Pluck("action", &actions)
+	if len(actions) != 2 || actions[0] != AuditActionImpersonate || actions[1] != AuditActionStopImpersonating {
+		t.Errorf("audit actions = %v, want [%s %s]", actions, AuditActionImpersonate, AuditActionStopImpersonating)
+	}
+}
+
+func TestImpersonationSessionIsLimited(t *testing.T) {
+	permissions := auth.GetPermissionService()
+	session := &models.User{IsActive: true, ImpersonatedBy: 1}
+
+	for _, permission := range auth.ImpersonationDeniedPermissions {
+		if permissions.ScopeAllows(session, permission) {
+			t.Errorf("ScopeAllows(%s) for an impersonation session = true, want false", permission)
+		}
+	}
+	if !permissions.ScopeAllows(session, "books.view") {
+		t.Errorf("ScopeAllows(books.view) for an impersonation session = false, want true")
+	}
+	if !permissions.ScopeAllows(&models.User{IsActive: true}, "api_keys.manage") {
+		t.Errorf("ScopeAllows(api_keys.manage) outside impersonation = false, want true")
+	}
+}
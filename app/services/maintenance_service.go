@@ -0,0 +1,81 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goravel/framework/facades"
+)
+
+// MaintenanceState is why the application is down and who may still use it, as recorded by
+// `artisan down`
+type MaintenanceState struct {
+	Message    string    `json:"message,omitempty"`
+	RetryAfter int       `json:"retry_after"`
+	AllowedIPs []string  `json:"allowed_ips,omitempty"`
+	Since      time.Time `json:"since"`
+}
+
+// MaintenanceService takes the application down and brings it back up through the file at
+// maintenance.file, which every server process sharing the storage directory reads
+type MaintenanceService struct {
+	file string
+}
+
+// NewMaintenanceService creates a new maintenance service
+func NewMaintenanceService() *MaintenanceService {
+	return &MaintenanceService{file: facades.Config().GetString("maintenance.file", "storage/framework/down")}
+}
+
+// Down puts the application into maintenance mode, replacing any earlier state
+func (s *MaintenanceService) Down(state MaintenanceState) error {
+	if state.Since.IsZero() {
+		state.Since = time.Now()
+	}
+	encoded, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode maintenance state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.file), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.file), err)
+	}
+	if err := os.WriteFile(s.file, encoded, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.file, err)
+	}
+	return nil
+}
+
+// Up takes the application out of maintenance mode. It reports false when `artisan down` hadn't
+// been run; MAINTENANCE_MODE=true still keeps the application down.
+func (s *MaintenanceService) Up() (bool, error) {
+	if err := os.Remove(s.file); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to remove %s: %w", s.file, err)
+	}
+	return true, nil
+}
+
+// Current returns the maintenance state and whether the application is down. A file that can't
+// be read still counts as down, so a half-written flag never lets traffic through early.
+func (s *MaintenanceService) Current() (*MaintenanceState, bool) {
+	state := &MaintenanceState{RetryAfter: facades.Config().GetInt("maintenance.retry_after", 60)}
+
+	encoded, err := os.ReadFile(s.file)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			facades.Log().Warning("Failed to read maintenance state: " + err.Error())
+			return state, true
+		}
+		return state, facades.Config().GetBool("maintenance.enabled", false)
+	}
+	if err := json.Unmarshal(encoded, state); err != nil {
+		facades.Log().Warning("Invalid maintenance state in " + s.file + ": " + err.Error())
+	}
+	return state, true
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/goravel/framework/facades"
+)
+
+// PasswordPolicy is the password complexity policy configured under auth.password
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// CurrentPasswordPolicy reads the password policy from config
+func CurrentPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:     facades.Config().GetInt("auth.password.min_length", 8),
+		RequireUpper:  facades.Config().GetBool("auth.password.require_upper", true),
+		RequireDigit:  facades.Config().GetBool("auth.password.require_digit", true),
+		RequireSymbol: facades.Config().GetBool("auth.password.require_symbol", true),
+	}
+}
+
+// Check returns one message per rule password fails, in a stable order, so the frontend can
+// show them as a checklist. An empty result means the password is acceptable.
+func (p PasswordPolicy) Check(password string) []string {
+	var hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	failures := make([]string, 0)
+	if len([]rune(password)) < p.MinLength {
+		failures = append(failures, fmt.Sprintf("password must be at least %d characters", p.MinLength))
+	}
+	if p.RequireUpper && !hasUpper {
+		failures = append(failures, "password must contain an uppercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		failures = append(failures, "password must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		failures = append(failures, "password must contain a symbol")
+	}
+	return failures
+}
+
+// ValidatePassword checks password against the configured policy. It is shared by every path
+// that sets a password: user create, user update (admin reset) and user:create-admin.
+func ValidatePassword(password string) []string {
+	return CurrentPasswordPolicy().Check(password)
+}
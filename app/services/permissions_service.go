@@ -2,10 +2,13 @@ package services
 
 import (
 	"fmt"
+	"time"
+	"players/app/auth"
 	"players/app/contracts"
 	"players/app/helpers"
 	"players/app/models"
 
+	"github.com/goravel/framework/contracts/database/orm"
 	"github.com/goravel/framework/facades"
 )
 
@@ -152,6 +155,7 @@ func (s *PermissionsService) AssignPermissionToRole(roleID, permissionID uint) e
 		return fmt.Errorf("failed to assign permission: %w", err)
 	}
 
+	auth.GetPermissionService().ClearCache()
 	return nil
 }
 
@@ -165,6 +169,7 @@ func (s *PermissionsService) RevokePermissionFromRole(roleID, permissionID uint)
 		return fmt.Errorf("failed to revoke permission: %w", err)
 	}
 
+	auth.GetPermissionService().ClearCache()
 	return nil
 }
 
@@ -191,44 +196,50 @@ func (s *PermissionsService) BulkAssignPermissions(request BulkAssignmentRequest
 
 // SyncRolePermissions completely replaces a role's permissions
 func (s *PermissionsService) SyncRolePermissions(roleID uint, permissionIDs []uint) error {
-	// Start transaction
-	tx, err := facades.Orm().Query().Begin()
+	return s.SyncPermissionMatrix(map[uint][]uint{roleID: permissionIDs})
+}
+
+// SyncPermissionMatrix replaces the permissions of every role in matrix (role ID -> permission
+// IDs) in a single transaction, so a failed role leaves all of them unchanged. Roles missing from
+// matrix keep their grants.
+func (s *PermissionsService) SyncPermissionMatrix(matrix map[uint][]uint) error {
+	err := s.WithTransaction(func(tx orm.Query) error {
+		for roleID, permissionIDs := range matrix {
+			if err := syncRolePermissions(tx, roleID, permissionIDs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to start transaction: %w", err)
+		return err
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
 
-	// Remove all existing permissions for the role
-	_, err = tx.Table("role_permissions").
-		Where("role_id = ?", roleID).
-		Delete()
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to clear existing permissions: %w", err)
+	auth.GetPermissionService().ClearCache()
+	return nil
+}
+
+// syncRolePermissions replaces one role's grants within tx
+func syncRolePermissions(tx orm.Query, roleID uint, permissionIDs []uint) error {
+	// Remove all existing permissions for the role. Revoked grants are hard-deleted so
+	// role_permissions only ever holds active rows.
+	if _, err := tx.Where("role_id = ?", roleID).ForceDelete(&models.RolePermission{}); err != nil {
+		return fmt.Errorf("failed to clear existing permissions of role %d: %w", roleID, err)
 	}
 
 	// Add new permissions
 	for _, permissionID := range permissionIDs {
-		rolePermission := struct {
-			RoleID       uint `gorm:"column:role_id"`
-			PermissionID uint `gorm:"column:permission_id"`
-		}{
+		rolePermission := models.RolePermission{
 			RoleID:       roleID,
 			PermissionID: permissionID,
+			GrantedAt:    time.Now(),
+			IsActive:     true,
 		}
-		
-		err = tx.Table("role_permissions").Create(&rolePermission)
-		if err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to assign permission %d: %w", permissionID, err)
+
+		if err := tx.Create(&rolePermission); err != nil {
+			return fmt.Errorf("failed to assign permission %d to role %d: %w", permissionID, roleID, err)
 		}
 	}
-
-	tx.Commit()
 	return nil
 }
 
@@ -334,17 +345,19 @@ func (s *PermissionsService) GetPermissionsByCategory() (map[string][]models.Per
 	return result, nil
 }
 
-// SyncPermissionsFromGates syncs the registered gates to the permissions table
-func (s *PermissionsService) SyncPermissionsFromGates() error {
-	// Define the permissions based on what's registered in the GateServiceProvider
-	gatePermissions := []struct {
-		Name        string
-		Slug        string
-		Category    string
-		Resource    string
-		Action      string
-		Description string
-	}{
+// GatePermission is a permission checked by name through a gate in GateServiceProvider
+type GatePermission struct {
+	Name        string
+	Slug        string
+	Category    string
+	Resource    string
+	Action      string
+	Description string
+}
+
+// GatePermissions lists the permissions behind the gates registered in GateServiceProvider
+func GatePermissions() []GatePermission {
+	return []GatePermission{
 		// Books permissions
 		{"View Any Books", "books.viewAny", "books", "books", "viewAny", "View any books in the system"},
 		{"View Books", "books.view", "books", "books", "view", "View specific books"},
@@ -355,6 +368,9 @@ func (s *PermissionsService) SyncPermissionsFromGates() error {
 		{"Return Books", "books.return", "books", "books", "return", "Return books"},
 		{"Manage Books", "books.manage", "books", "books", "manage", "Full book management"},
 		{"Export Books", "books.export", "books", "books", "export", "Export book data"},
+		{"Restore Books", "books.restore", "books", "books", "restore", "Restore deleted books"},
+		{"Force Delete Books", "books.forceDelete", "books", "books", "forceDelete", "Permanently delete books"},
+		{"Audit Books", "books.audit", "books", "books", "audit", "View the change history of books"},
 
 		// Users permissions
 		{"View Any Users", "users.viewAny", "users", "users", "viewAny", "View any users in the system"},
@@ -362,23 +378,35 @@ func (s *PermissionsService) SyncPermissionsFromGates() error {
 		{"Create Users", "users.create", "users", "users", "create", "Create new users"},
 		{"Update Users", "users.update", "users", "users", "update", "Update existing users"},
 		{"Delete Users", "users.delete", "users", "users", "delete", "Delete users"},
+		{"Restore Users", "users.restore", "users", "users", "restore", "Restore deleted users"},
+		{"Force Delete Users", "users.forceDelete", "users", "users", "forceDelete", "Permanently delete users"},
 		{"Impersonate Users", "users.impersonate", "users", "users", "impersonate", "Impersonate other users"},
 		{"Manage Users", "users.manage", "users", "users", "manage", "Full user management"},
 
+		// Roles permissions
+		{"Assign Roles", "roles.assign", "roles", "roles", "assign", "Assign roles to users"},
+
 		// System permissions
 		{"Manage System", "system.manage", "system", "system", "manage", "Full system management"},
 		{"Backup System", "system.backup", "system", "system", "backup", "Create system backups"},
 		{"Configure System", "system.configure", "system", "system", "configure", "Configure system settings"},
+		{"Manage API Keys", "api_keys.manage", "system", "api_keys", "manage", "Create and revoke API keys for service-to-service calls"},
 		{"View Reports", "reports.view", "reports", "reports", "view", "View reports and analytics"},
 		{"Export Reports", "reports.export", "reports", "reports", "export", "Export reports"},
 	}
+}
 
+// SyncPermissionsFromGates syncs the registered gates to the permissions table
+func (s *PermissionsService) SyncPermissionsFromGates() error {
 	// Insert or update each permission
-	for _, perm := range gatePermissions {
+	for _, perm := range GatePermissions() {
+		// First reports a missing row as a zero ID, not an error
 		var existing models.Permission
-		err := facades.Orm().Query().Where("slug = ?", perm.Slug).First(&existing)
+		if err := facades.Orm().Query().Where("slug = ?", perm.Slug).First(&existing); err != nil {
+			return fmt.Errorf("failed to look up permission %s: %w", perm.Slug, err)
+		}
 		
-		if err != nil {
+		if existing.ID == 0 {
 			// Permission doesn't exist, create it
 			permission := models.Permission{
 				Name:        perm.Name,
@@ -410,5 +438,6 @@ func (s *PermissionsService) SyncPermissionsFromGates() error {
 		}
 	}
 
+	auth.GetPermissionService().ClearCache()
 	return nil
 }
\ No newline at end of file
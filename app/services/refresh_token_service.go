@@ -0,0 +1,138 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"players/app/models"
+)
+
+// ErrInvalidRefreshToken is returned for unknown, expired, revoked or reused refresh tokens
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+// RefreshTokenService issues, rotates and revokes the refresh tokens kept in refresh_tokens
+type RefreshTokenService struct{}
+
+// NewRefreshTokenService creates a new refresh token service
+func NewRefreshTokenService() *RefreshTokenService {
+	return &RefreshTokenService{}
+}
+
+// TTL is how long a refresh token stays valid, from jwt.refresh_ttl in minutes
+func (s *RefreshTokenService) TTL() time.Duration {
+	return time.Duration(facades.Config().GetInt("jwt.refresh_ttl", 20160)) * time.Minute
+}
+
+// Issue creates a refresh token for the user and returns the plain token; only its hash is stored
+func (s *RefreshTokenService) Issue(userID uint) (string, error) {
+	plain, hash, err := newRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	token := models.RefreshToken{UserID: userID, TokenHash: hash, ExpiresAt: time.Now().Add(s.TTL())}
+	if err := facades.Orm().Query().Create(&token); err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return plain, nil
+}
+
+// Rotate exchanges a refresh token for a new one and returns the owning user's ID. The old token
+// is revoked; presenting an already revoked token revokes every token of that user, since it
+// means the token was copied and used by someone else.
+func (s *RefreshTokenService) Rotate(plain string) (uint, string, error) {
+	if plain == "" {
+		return 0, "", ErrInvalidRefreshToken
+	}
+
+	var current models.RefreshToken
+	if err := facades.Orm().Query().Where("token_hash = ?", hashRefreshToken(plain)).First(&current); err != nil || current.ID == 0 {
+		return 0, "", ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	if current.RevokedAt != nil {
+		if err := s.RevokeAll(current.UserID); err != nil {
+			facades.Log().Error("Failed to revoke refresh tokens after reuse: " + err.Error())
+		}
+		return 0, "", ErrInvalidRefreshToken
+	}
+	if !current.IsUsable(now) {
+		return 0, "", ErrInvalidRefreshToken
+	}
+
+	next, hash, err := newRefreshToken()
+	if err != nil {
+		return 0, "", err
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	// Only one concurrent refresh may revoke the token; the loser sees it already revoked
+	result, err := tx.Model(&models.RefreshToken{}).Where("id = ? AND revoked_at IS NULL", current.ID).Update("revoked_at", now)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, "", fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		_ = tx.Rollback()
+		return 0, "", ErrInvalidRefreshToken
+	}
+
+	replacement := models.RefreshToken{UserID: current.UserID, TokenHash: hash, ExpiresAt: now.Add(s.TTL())}
+	if err := tx.Create(&replacement); err != nil {
+		_ = tx.Rollback()
+		return 0, "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, "", fmt.Errorf("failed to commit refresh token rotation: %w", err)
+	}
+	return current.UserID, next, nil
+}
+
+// Revoke revokes a single refresh token; unknown tokens are ignored
+func (s *RefreshTokenService) Revoke(plain string) error {
+	if plain == "" {
+		return nil
+	}
+	if _, err := facades.Orm().Query().Model(&models.RefreshToken{}).
+		Where("token_hash = ? AND revoked_at IS NULL", hashRefreshToken(plain)).
+		Update("revoked_at", time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return nil
+}
+
+// RevokeAll revokes every active refresh token of the user, signing them out everywhere
+func (s *RefreshTokenService) RevokeAll(userID uint) error {
+	if _, err := facades.Orm().Query().Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// newRefreshToken returns a random token and the hash it is stored under
+func newRefreshToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	plain := hex.EncodeToString(buf)
+	return plain, hashRefreshToken(plain), nil
+}
+
+func hashRefreshToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"players/app/contracts"
+	"players/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+)
+
+// RoleService lists roles with the same pagination, search and sorting as the other resources
+type RoleService struct {
+	*contracts.BaseCrudService
+}
+
+// NewRoleService creates a new role service
+func NewRoleService() *RoleService {
+	return &RoleService{
+		BaseCrudService: contracts.NewBaseCrudService("roles", "id"),
+	}
+}
+
+// GetList returns a page of active roles with their permissions, searched on name and slug.
+// Deleted (inactive) roles are included when the include_inactive filter is true
+func (s *RoleService) GetList(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	db, release, err := s.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := s.ValidateListRequest(&req); err != nil {
+		return nil, err
+	}
+	s.SanitizeListRequest(&req)
+
+	if req.Search != "" {
+		if err := s.ValidateSearchQuery(req.Search); err != nil {
+			return nil, err
+		}
+	}
+
+	// Build query with search conditions; called separately for count and data
+	newQuery := func() orm.Query {
+		query := db.Model(&models.Role{})
+		if includeInactive, _ := req.Filters["include_inactive"].(bool); !includeInactive {
+			query = query.Where("is_active = ?", true)
+		}
+		if req.Search != "" {
+			condition, values := s.SearchCondition(req.Search, s.GetSearchableFields(), s.GetSearchFieldModes())
+			query = query.Where(condition, values...)
+		}
+		return query
+	}
+
+	orderClause := s.buildOrderClause(req)
+
+	var pageRoles []models.Role
+	total, err := s.PaginateQuery(newQuery, orderClause, req, &pageRoles, s.GetRelations()...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]interface{}, len(pageRoles))
+	for i, role := range pageRoles {
+		data[i] = role
+	}
+
+	result := s.BuildPaginatedResult(data, total, req)
+	s.ApplyNextCursor(result, req, orderClause, pageRoles)
+	return result, nil
+}
+
+func (s *RoleService) ValidateSearchQuery(query string) error {
+	minLength, maxLength := s.GetSearchConstraints()
+	return s.ValidateSearchLength(query, minLength, maxLength)
+}
+
+func (s *RoleService) GetSearchableFields() []string {
+	return []string{"name", "slug"}
+}
+
+func (s *RoleService) GetSortableFields() []string {
+	return []string{"id", "name", "slug", "level", "createdAt", "updatedAt"}
+}
+
+func (s *RoleService) ValidateSortField(field string) bool {
+	for _, validField := range s.GetSortableFields() {
+		if field == validField {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *RoleService) MapSortField(frontendField string) (string, bool) {
+	columnMapping := map[string]string{
+		"id":        "id",
+		"name":      "name",
+		"slug":      "slug",
+		"level":     "level",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	}
+	dbColumn, exists := columnMapping[frontendField]
+	return dbColumn, exists
+}
+
+// GetDefaultSort lists the most privileged roles first, as the permissions page does
+func (s *RoleService) GetDefaultSort() (string, string) {
+	return "level", "DESC"
+}
+
+// buildOrderClause resolves the requested sort into an ORDER BY clause, falling back to the default sort
+func (s *RoleService) buildOrderClause(req contracts.ListRequest) string {
+	if orderClause := s.BuildOrderClause(req, s.ValidateSortField, s.MapSortField); orderClause != "" {
+		return orderClause
+	}
+
+	defaultField, defaultDir := s.GetDefaultSort()
+	return defaultField + " " + defaultDir + ", name ASC"
+}
+
+func (s *RoleService) GetRelations() []string {
+	return []string{"Permissions"}
+}
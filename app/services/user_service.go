@@ -1,16 +1,34 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"regexp"
 	"time"
 
+	"github.com/goravel/framework/contracts/database/orm"
 	"github.com/goravel/framework/facades"
+	"gorm.io/gorm"
+	"players/app/auth"
 	"players/app/contracts"
 	"players/app/models"
 )
 
+// ErrEmailTaken is returned when another active user already has the email
+var ErrEmailTaken = errors.New("email already exists")
+
+// DeletedEmailOwnerError is returned when the email belongs to a soft-deleted user. The row still
+// holds the unique index, so the user has to be restored or permanently deleted before reuse.
+type DeletedEmailOwnerError struct {
+	UserID uint
+}
+
+func (e *DeletedEmailOwnerError) Error() string {
+	return "email already exists on a deleted user; restore or permanently delete that user first"
+}
+
 // UserService handles user business logic with contract enforcement
 type UserService struct {
 	*contracts.BaseCrudService
@@ -19,9 +37,15 @@ type UserService struct {
 // NewUserService creates a new user service that implements all contracts
 func NewUserService() *UserService {
 	service := &UserService{
-		BaseCrudService: contracts.NewBaseCrudService("user", "id"),
+		BaseCrudService: contracts.NewBaseCrudService("users", "id"),
 	}
 
+	// Email is unique, so it can drive keyset pagination alongside id
+	service.SetCursorColumns("email")
+
+	// Logins, lockouts and role changes write to users outside Update, so users are never cached
+	service.SetRecordCache(false)
+
 	// Register service with validation
 	contracts.MustRegisterCrudService("users", service)
 
@@ -30,103 +54,72 @@ func NewUserService() *UserService {
 
 // GetList with built-in pagination, sorting, filtering using GORM directly
 // Implements CrudServiceContract interface
-func (s *UserService) GetList(req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+func (s *UserService) GetList(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	db, release, err := s.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Use base service validation
 	if err := s.ValidateListRequest(&req); err != nil {
 		return nil, err
 	}
 	s.SanitizeListRequest(&req)
 
-	// Build query
-	query := facades.Orm().Query().Model(&models.User{}).With("Roles")
-
-	// Apply search if provided using searchable fields
 	if req.Search != "" {
 		if err := s.ValidateSearchQuery(req.Search); err != nil {
 			return nil, err
 		}
-		searchFields := s.GetSearchableFields()
-		if len(searchFields) > 0 {
-			conditions := make([]string, len(searchFields))
-			values := make([]interface{}, len(searchFields))
-			for i, field := range searchFields {
-				conditions[i] = field + " LIKE ?"
-				values[i] = "%" + req.Search + "%"
-			}
-			query = query.Where(strings.Join(conditions, " OR "), values...)
-		}
 	}
 
-	// Apply sorting with field validation and mapping
-	if req.Sort != "" && req.Direction != "" {
-		if s.ValidateSortField(req.Sort) && s.ValidateSortDirection(req.Direction) {
-			dbColumn, valid := s.MapSortField(req.Sort)
-			if valid {
-				orderClause := dbColumn + " " + strings.ToUpper(req.Direction)
-				query = query.Order(orderClause)
-			} else {
-				// Use default sort
-				defaultField, defaultDir := s.GetDefaultSort()
-				query = query.Order(defaultField + " " + defaultDir)
+	// Build query with search conditions; called separately for count and data
+	newQuery := func() orm.Query {
+		query := db.Model(&models.User{})
+
+		// Apply search if provided using searchable fields and their match modes
+		if req.Search != "" {
+			searchFields := s.GetSearchableFields()
+			if len(searchFields) > 0 {
+				condition, values := s.SearchCondition(req.Search, searchFields, s.GetSearchFieldModes())
+				query = query.Where(condition, values...)
 			}
-		} else {
-			// Use default sort
-			defaultField, defaultDir := s.GetDefaultSort()
-			query = query.Order(defaultField + " " + defaultDir)
 		}
-	} else {
-		// Default sorting
-		defaultField, defaultDir := s.GetDefaultSort()
-		query = query.Order(defaultField + " " + defaultDir)
-	}
 
-	// Get all users with applied filters and sorting
-	var allUsers []models.User
-	if err := query.Find(&allUsers); err != nil {
-		return nil, err
+		return query
 	}
 
-	// Manual pagination
-	total := int64(len(allUsers))
-	offset := (req.Page - 1) * req.PageSize
-	end := offset + req.PageSize
-
-	if offset > len(allUsers) {
-		offset = len(allUsers)
-	}
-	if end > len(allUsers) {
-		end = len(allUsers)
-	}
+	// Resolve sorting with field validation and mapping
+	orderClause := s.buildOrderClause(req)
 
+	// Count and fetch only the requested page at the database level
 	var pageUsers []models.User
-	if offset < len(allUsers) {
-		pageUsers = allUsers[offset:end]
+	total, err := s.PaginateQuery(newQuery, orderClause, req, &pageUsers, s.GetRelations()...)
+	if err != nil {
+		return nil, err
 	}
 
-	lastPage := int((total + int64(req.PageSize) - 1) / int64(req.PageSize))
-
 	// Convert to interface slice
 	data := make([]interface{}, len(pageUsers))
 	for i, user := range pageUsers {
 		data[i] = user
 	}
 
-	return &contracts.PaginatedResult{
-		Data:        data,
-		Total:       total,
-		PerPage:     req.PageSize,
-		CurrentPage: req.Page,
-		LastPage:    lastPage,
-		From:        offset + 1,
-		To:          offset + len(pageUsers),
-		HasNext:     req.Page < lastPage,
-		HasPrev:     req.Page > 1,
-	}, nil
+	// Pages sorted on a cursor column also carry a nextCursor for keyset pagination
+	result := s.BuildPaginatedResult(data, total, req)
+	s.ApplyNextCursor(result, req, orderClause, pageUsers)
+	return result, nil
 }
 
 // GetListAdvanced with additional filters using GORM directly
 // Implements CrudServiceContract interface
-func (s *UserService) GetListAdvanced(req contracts.ListRequest, filters map[string]interface{}) (*contracts.PaginatedResult, error) {
+func (s *UserService) GetListAdvanced(ctx context.Context, req contracts.ListRequest, filters map[string]interface{}) (*contracts.PaginatedResult, error) {
+	db, release, err := s.ListQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Validate and sanitize request
 	if err := s.ValidateListRequest(&req); err != nil {
 		return nil, err
@@ -138,10 +131,23 @@ func (s *UserService) GetListAdvanced(req contracts.ListRequest, filters map[str
 	if err != nil {
 		return nil, err
 	}
+	rangeFilters, err := s.BuildRangeFilters(filters, s.ValidateFilterField)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create separate queries for count and data
-	countQuery := facades.Orm().Query().Model(&models.User{})
-	dataQuery := facades.Orm().Query().Model(&models.User{}).With("Roles")
+	countQuery := db.Model(&models.User{})
+	dataQuery := s.WithRelations(db.Model(&models.User{}), s.GetRelations())
+
+	// Soft-deleted rows stay hidden unless trashed=with|only is requested
+	trashed, _ := filters["trashed"].(string)
+	if countQuery, err = s.ApplyTrashedFilter(countQuery, trashed); err != nil {
+		return nil, err
+	}
+	if dataQuery, err = s.ApplyTrashedFilter(dataQuery, trashed); err != nil {
+		return nil, err
+	}
 
 	// Apply search to both queries if provided
 	if req.Search != "" {
@@ -152,24 +158,8 @@ func (s *UserService) GetListAdvanced(req contracts.ListRequest, filters map[str
 	}
 
 	// Apply validated filters to both queries
-	for field, value := range validatedFilters {
-		var condition string
-		switch field {
-		case "is_active":
-			condition = "is_active = ?"
-		case "is_super_admin":
-			condition = "is_super_admin = ?"
-		case "role":
-			// Filter by role slug
-			countQuery = countQuery.Where("EXISTS (SELECT 1 FROM user_roles ur JOIN roles r ON ur.role_id = r.id WHERE ur.user_id = users.id AND r.slug = ?)", value)
-			dataQuery = dataQuery.Where("EXISTS (SELECT 1 FROM user_roles ur JOIN roles r ON ur.role_id = r.id WHERE ur.user_id = users.id AND r.slug = ?)", value)
-			continue
-		default:
-			continue
-		}
-		countQuery = countQuery.Where(condition, value)
-		dataQuery = dataQuery.Where(condition, value)
-	}
+	countQuery = s.ApplyRangeFilters(s.applyFilters(countQuery, validatedFilters), rangeFilters)
+	dataQuery = s.ApplyRangeFilters(s.applyFilters(dataQuery, validatedFilters), rangeFilters)
 
 	// Count total records
 	var total int64
@@ -178,11 +168,7 @@ func (s *UserService) GetListAdvanced(req contracts.ListRequest, filters map[str
 	}
 
 	// Add sorting to data query only
-	if req.Sort != "" {
-		dataQuery = dataQuery.Order("id DESC") // Should parse req.Sort properly
-	} else {
-		dataQuery = dataQuery.Order("id DESC")
-	}
+	dataQuery = dataQuery.Order(s.StableOrder(s.buildOrderClause(req)))
 
 	// Calculate pagination
 	offset := (req.Page - 1) * req.PageSize
@@ -213,6 +199,80 @@ func (s *UserService) GetListAdvanced(req contracts.ListRequest, filters map[str
 	}, nil
 }
 
+// userColumnFilters maps plain filter keys to the users column they match
+var userColumnFilters = map[string]string{
+	"name":           "users.name",
+	"email":          "users.email",
+	"is_active":      "users.is_active",
+	"is_super_admin": "users.is_super_admin",
+	"created_at":     "users.created_at",
+}
+
+// roleSlugPattern matches the slugs roles are stored with
+var roleSlugPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// applyFilters applies filters already checked by BuildFilterQuery to a users query
+func (s *UserService) applyFilters(query orm.Query, filters map[string]interface{}) orm.Query {
+	for field, value := range filters {
+		if column, ok := userColumnFilters[field]; ok {
+			query = query.Where(s.FilterCondition(column, value))
+			continue
+		}
+		if field == "role" {
+			query = s.filterByRole(query, value)
+		}
+	}
+	return query
+}
+
+// filterByRole limits a users query to users holding an active assignment of the given role,
+// or of any of the given roles when roleSlug is a slice
+func (s *UserService) filterByRole(query orm.Query, roleSlug interface{}) orm.Query {
+	slugCondition, slugValue := s.FilterCondition("r.slug", roleSlug)
+	return query.Where(
+		"EXISTS (SELECT 1 FROM user_roles ur JOIN roles r ON ur.role_id = r.id "+
+			"WHERE ur.user_id = users.id AND ur.deleted_at IS NULL AND ur.is_active = ? AND "+slugCondition+")",
+		true, slugValue,
+	)
+}
+
+// emailOwnerError maps the user already holding an email (found with trashed rows included) to
+// ErrEmailTaken or a *DeletedEmailOwnerError; nil when nobody holds it
+func emailOwnerError(existing *models.User) error {
+	if existing.ID == 0 {
+		return nil
+	}
+	if existing.DeletedAt.Valid {
+		return &DeletedEmailOwnerError{UserID: existing.ID}
+	}
+	return ErrEmailTaken
+}
+
+// GetTrashed lists soft-deleted users for the trash screen
+// Implements TrashedServiceContract interface
+func (s *UserService) GetTrashed(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	if err := s.ValidateListRequest(&req); err != nil {
+		return nil, err
+	}
+	s.SanitizeListRequest(&req)
+
+	orderClause := s.buildOrderClause(req)
+	var pageUsers []models.User
+	total, err := s.PaginateTrashed(ctx, &models.User{}, orderClause, req, &pageUsers, s.GetRelations()...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]interface{}, len(pageUsers))
+	for i, record := range pageUsers {
+		data[i] = record
+	}
+
+	result := s.BuildPaginatedResult(data, total, req)
+	s.ApplyNextCursor(result, req, orderClause, pageUsers)
+	return result, nil
+}
+
 // GetByID - Implements CrudServiceContract interface
 func (s *UserService) GetByID(id uint) (interface{}, error) {
 	if id == 0 {
@@ -225,7 +285,7 @@ func (s *UserService) GetByID(id uint) (interface{}, error) {
 // getUserByID is a helper method that returns the actual model type
 func (s *UserService) getUserByID(id uint) (*models.User, error) {
 	var user models.User
-	if err := facades.Orm().Query().Model(&models.User{}).With("Roles").Where("id = ?", id).First(&user); err != nil {
+	if err := s.WithRelations(facades.Orm().Query().Model(&models.User{}), s.GetRelations()).Where("id = ?", id).First(&user); err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
@@ -239,40 +299,69 @@ func (s *UserService) Create(data map[string]interface{}) (interface{}, error) {
 		return nil, err
 	}
 
-	return s.createUser(data)
+	return s.createUser(data, nil, nil, false)
+}
+
+// CreateWithRole creates a user holding roleID in one transaction, so a failed role assignment
+// leaves no roleless user behind. The role is checked against actor like SetRole; a roleID of 0
+// creates the user without a role. superAdmin is written with the user, so callers must have
+// checked the actor is a super admin before passing true.
+func (s *UserService) CreateWithRole(data map[string]interface{}, roleID uint, actor *models.User, superAdmin bool) (*models.User, error) {
+	if err := s.validateWithRules(data, false); err != nil {
+		return nil, err
+	}
+
+	var role *models.Role
+	if roleID > 0 {
+		assignable, err := s.assignableRole(roleID, actor)
+		if err != nil {
+			return nil, err
+		}
+		role = assignable
+	}
+	return s.createUser(data, role, actor, superAdmin)
 }
 
-// createUser is a helper method that returns the actual model type
-func (s *UserService) createUser(data map[string]interface{}) (*models.User, error) {
+// createUser is a helper method that returns the actual model type. When role is set the user
+// and the role assignment are written in the same transaction.
+func (s *UserService) createUser(data map[string]interface{}, role *models.Role, actor *models.User, superAdmin bool) (*models.User, error) {
+	data = contracts.OnlyFillable(data, s.GetFillableFields())
+
 	// Basic validation
 	if err := s.validateUserData(data, false); err != nil {
 		return nil, err
 	}
 
-	// Check if email already exists (GORM automatically excludes soft-deleted users)
-	var existingCount int64
-	err := facades.Orm().Query().Model(&models.User{}).Where("email = ?", data["email"].(string)).Count(&existingCount)
+	// Check if email already exists. Soft-deleted users still own their email,
+	// so they have to be restored or force-deleted before it can be reused.
+	var existing models.User
+	err := facades.Orm().Query().WithTrashed().Where("email = ?", data["email"].(string)).First(&existing)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check email uniqueness: %w", err)
 	}
-	if existingCount > 0 {
-		return nil, fmt.Errorf("email already exists")
+	if err := emailOwnerError(&existing); err != nil {
+		return nil, err
 	}
 
 	// Set default values if not provided
 	if _, exists := data["is_active"]; !exists {
 		data["is_active"] = true
 	}
-	if _, exists := data["is_super_admin"]; !exists {
-		data["is_super_admin"] = false
-	}
 
 	// Create user struct from data
 	user := models.User{
 		Name:         data["name"].(string),
 		Email:        data["email"].(string),
 		IsActive:     data["is_active"].(bool),
-		IsSuperAdmin: data["is_super_admin"].(bool),
+		IsSuperAdmin: superAdmin,
+	}
+
+	// New users confirm their email unless the caller vouches for it with email_verified (admins do)
+	verified, _ := data["email_verified"].(bool)
+	if verified {
+		verifiedAt := time.Now()
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &verifiedAt
 	}
 
 	// Hash password if provided
@@ -284,37 +373,40 @@ func (s *UserService) createUser(data map[string]interface{}) (*models.User, err
 		user.Password = hashedPassword
 	}
 
-	// Create using GORM
-	if err := facades.Orm().Query().Create(&user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
-
-	// Assign role if provided
-	if roleID, ok := data["role_id"].(float64); ok && roleID > 0 {
-		userRole := models.UserRole{
-			UserID:     user.ID,
-			RoleID:     uint(roleID),
-			AssignedAt: time.Now(),
-			IsActive:   true,
+	// Create using GORM, together with the role assignment if there is one
+	err = s.WithTransaction(func(tx orm.Query) error {
+		if err := tx.Create(&user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if role == nil {
+			return nil
 		}
-		if err := facades.Orm().Query().Create(&userRole); err != nil {
-			// Log error but don't fail user creation
-			facades.Log().Error("Failed to assign role to user", map[string]interface{}{
-				"user_id": user.ID,
-				"role_id": roleID,
-				"error":   err.Error(),
-			})
+		userRole := models.UserRole{UserID: user.ID, RoleID: role.ID, AssignedAt: time.Now(), IsActive: true}
+		if actor != nil {
+			userRole.AssignedByID = &actor.ID
 		}
+		if err := tx.Create(&userRole); err != nil {
+			return fmt.Errorf("failed to assign role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Reload user with roles
-	if err := facades.Orm().Query().Model(&models.User{}).With("Roles").Where("id = ?", user.ID).First(&user); err != nil {
+	if err := s.WithRelations(facades.Orm().Query().Model(&models.User{}), s.GetRelations()).Where("id = ?", user.ID).First(&user); err != nil {
 		facades.Log().Error("Failed to reload user with roles", map[string]interface{}{
 			"user_id": user.ID,
 			"error":   err.Error(),
 		})
 	}
 
+	if !verified {
+		NewEmailVerificationService().SendVerification(&user)
+	}
+
+	s.DispatchEvent(contracts.EventCreated, &user)
 	return &user, nil
 }
 
@@ -329,26 +421,47 @@ func (s *UserService) Update(id uint, data map[string]interface{}) (interface{},
 		return nil, err
 	}
 
-	return s.updateUser(id, data)
+	return s.updateUser(id, data, nil)
 }
 
-// updateUser is a helper method that returns the actual model type
-func (s *UserService) updateUser(id uint, data map[string]interface{}) (*models.User, error) {
+// UpdateWithSuperAdmin is Update that also grants or revokes super admin in the same write.
+// The flag is guarded against mass assignment, so callers must have checked the actor is a
+// super admin.
+func (s *UserService) UpdateWithSuperAdmin(id uint, data map[string]interface{}, superAdmin bool) (*models.User, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid ID: %d", id)
+	}
+
+	if err := s.validateWithRules(data, true); err != nil {
+		return nil, err
+	}
+
+	return s.updateUser(id, data, &superAdmin)
+}
+
+// updateUser is a helper method that returns the actual model type. A non-nil superAdmin is
+// saved in the same statement as data.
+func (s *UserService) updateUser(id uint, data map[string]interface{}, superAdmin *bool) (*models.User, error) {
+	data = contracts.OnlyFillable(data, s.GetFillableFields())
+	if superAdmin != nil {
+		data["is_super_admin"] = *superAdmin
+	}
+
 	// Check if user exists
 	user, err := s.getUserByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if email is being changed and already exists
+	// Check if email is being changed and already exists, including on deleted users
 	if email, ok := data["email"].(string); ok && email != user.Email {
-		var existingCount int64
-		err := facades.Orm().Query().Model(&models.User{}).Where("email = ? AND id != ?", email, id).Count(&existingCount)
+		var existing models.User
+		err := facades.Orm().Query().WithTrashed().Where("email = ? AND id != ?", email, id).First(&existing)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check email uniqueness: %w", err)
 		}
-		if existingCount > 0 {
-			return nil, fmt.Errorf("email already exists")
+		if err := emailOwnerError(&existing); err != nil {
+			return nil, err
 		}
 	}
 
@@ -365,34 +478,103 @@ func (s *UserService) updateUser(id uint, data map[string]interface{}) (*models.
 	}
 
 	// Update using GORM
-	if _, err := facades.Orm().Query().Model(&user).Where("id = ?", id).Update(data); err != nil {
+	if _, err := facades.Orm().Query().Model(user).Where("id = ?", id).Update(data); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	if superAdmin != nil {
+		auth.GetPermissionService().ClearUserCache(id)
+	}
+
+	// Return updated user
+	updated, err := s.getUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+	s.DispatchEvent(contracts.EventUpdated, updated)
+	return updated, nil
+}
+
+// SetRole makes roleID the user's only role on behalf of actor. Create and Update ignore role_id;
+// role changes go through PermissionService.AssignRole/RemoveRole instead, so the actor needs
+// roles.assign and must outrank the new role and every role taken away. A nil actor is a system
+// change and skips those checks.
+func (s *UserService) SetRole(userID, roleID uint, actor *models.User) error {
+	user, role, assignments, err := s.planRoleChange(userID, roleID, actor)
+	if err != nil {
+		return err
+	}
 
-	// Update role if provided
-	if roleID, ok := data["role_id"].(float64); ok {
-		// Remove existing roles
-		facades.Orm().Query().Where("user_id = ?", id).Delete(&models.UserRole{})
-		
-		// Assign new role
-		userRole := models.UserRole{
-			UserID:     id,
-			RoleID:     uint(roleID),
-			AssignedAt: time.Now(),
-			IsActive:   true,
+	permissionService := auth.GetPermissionService()
+	if !user.HasRole(role.Slug) {
+		if err := permissionService.AssignRole(user, role.Slug, actor); err != nil {
+			return err
 		}
-		if err := facades.Orm().Query().Create(&userRole); err != nil {
-			// Log error but don't fail user update
-			facades.Log().Error("Failed to update user role", map[string]interface{}{
-				"user_id": id,
-				"role_id": roleID,
-				"error":   err.Error(),
-			})
+	}
+	for _, assignment := range assignments {
+		if assignment.RoleID != role.ID {
+			if err := permissionService.RemoveRole(user, assignment.Role.Slug, actor); err != nil {
+				return err
+			}
 		}
 	}
 
-	// Return updated user
-	return s.getUserByID(id)
+	return nil
+}
+
+// CheckRoleChange applies SetRole's checks without changing anything, so a caller can refuse a
+// request before saving the rest of it
+func (s *UserService) CheckRoleChange(userID, roleID uint, actor *models.User) error {
+	_, _, _, err := s.planRoleChange(userID, roleID, actor)
+	return err
+}
+
+// planRoleChange loads the user with their active role assignments and checks that actor may
+// assign roleID and take away every other role
+func (s *UserService) planRoleChange(userID, roleID uint, actor *models.User) (*models.User, *models.Role, []models.UserRole, error) {
+	role, err := s.assignableRole(roleID, actor)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Active assignments, loaded directly so removed (soft-deleted) ones are not counted
+	var assignments []models.UserRole
+	if err := facades.Orm().Query().With("Role").Where("user_id = ? AND is_active = ?", userID, true).Find(&assignments); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load user roles: %w", err)
+	}
+
+	permissionService := auth.GetPermissionService()
+	user.Roles = make([]models.Role, 0, len(assignments))
+	for _, assignment := range assignments {
+		user.Roles = append(user.Roles, assignment.Role)
+		// Check every removal up front so a denied change leaves the user's roles untouched
+		if assignment.RoleID != role.ID && actor != nil && !permissionService.OutranksRole(actor, &assignment.Role) {
+			return nil, nil, nil, auth.ErrRoleAboveAssigner
+		}
+	}
+
+	return user, role, assignments, nil
+}
+
+// assignableRole loads an active role and applies AssignRole's permission and hierarchy checks
+func (s *UserService) assignableRole(roleID uint, actor *models.User) (*models.Role, error) {
+	var role models.Role
+	if err := facades.Orm().Query().Where("id = ? AND is_active = ?", roleID, true).First(&role); err != nil || role.ID == 0 {
+		return nil, auth.ErrRoleNotFound
+	}
+
+	permissionService := auth.GetPermissionService()
+	if actor != nil && !permissionService.HasPermission(actor, "roles.assign") {
+		return nil, auth.ErrRoleAssignDenied
+	}
+	if actor != nil && !permissionService.OutranksRole(actor, &role) {
+		return nil, auth.ErrRoleAboveAssigner
+	}
+	return &role, nil
 }
 
 // Delete - Implements CrudServiceContract interface
@@ -402,7 +584,7 @@ func (s *UserService) Delete(id uint) error {
 	}
 
 	// Check if user exists
-	_, err := s.getUserByID(id)
+	user, err := s.getUserByID(id)
 	if err != nil {
 		return err
 	}
@@ -412,6 +594,108 @@ func (s *UserService) Delete(id uint) error {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
+	auth.GetPermissionService().ClearUserCache(id)
+	s.DispatchEvent(contracts.EventDeleted, user)
+	return nil
+}
+
+// ForceDelete permanently removes a user, including a soft-deleted one, along with their role assignments
+func (s *UserService) ForceDelete(id uint) error {
+	if id == 0 {
+		return fmt.Errorf("invalid ID: %d", id)
+	}
+
+	if _, err := facades.Orm().Query().Exec("DELETE FROM user_roles WHERE user_id = ?", id); err != nil {
+		return fmt.Errorf("failed to remove user roles: %w", err)
+	}
+
+	if err := s.BaseCrudService.ForceDelete(id); err != nil {
+		return err
+	}
+
+	auth.GetPermissionService().ClearUserCache(id)
+	return nil
+}
+
+// SetActive sets is_active on a user and drops their cached permissions
+func (s *UserService) SetActive(id uint, active bool) error {
+	if err := s.BaseCrudService.SetActive(id, active); err != nil {
+		return err
+	}
+
+	auth.GetPermissionService().ClearUserCache(id)
+	return nil
+}
+
+// LoginLockout returns how many failed logins lock an account and for how long, from auth.lockout
+func (s *UserService) LoginLockout() (int, time.Duration) {
+	maxAttempts := facades.Config().GetInt("auth.lockout.max_attempts", 5)
+	minutes := facades.Config().GetInt("auth.lockout.minutes", 15)
+	return maxAttempts, time.Duration(minutes) * time.Minute
+}
+
+// RecordFailedLogin counts a failed login and locks the account once the count reaches
+// auth.lockout.max_attempts. After a lock has expired the count starts over. The count is
+// incremented in the database, so parallel attempts can't overwrite each other's; user is
+// updated in place from the stored row.
+func (s *UserService) RecordFailedLogin(user *models.User) error {
+	now := time.Now()
+	maxAttempts, lockFor := s.LoginLockout()
+
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", user.ID).Update(map[string]interface{}{
+		"failed_login_attempts": gorm.Expr("CASE WHEN locked_until IS NOT NULL AND locked_until <= ? THEN 1 ELSE failed_login_attempts + 1 END", now),
+		"locked_until":          gorm.Expr("CASE WHEN locked_until IS NOT NULL AND locked_until <= ? THEN NULL ELSE locked_until END", now),
+	}); err != nil {
+		return fmt.Errorf("failed to record failed login: %w", err)
+	}
+
+	var stored models.User
+	if err := facades.Orm().Query().Where("id = ?", user.ID).First(&stored); err != nil {
+		return fmt.Errorf("failed to record failed login: %w", err)
+	}
+
+	if maxAttempts > 0 && stored.FailedLoginAttempts >= maxAttempts && !stored.IsLocked(now) {
+		until := now.Add(lockFor)
+		if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", user.ID).Update("locked_until", until); err != nil {
+			return fmt.Errorf("failed to lock account: %w", err)
+		}
+		stored.LockedUntil = &until
+	}
+
+	user.FailedLoginAttempts = stored.FailedLoginAttempts
+	user.LockedUntil = stored.LockedUntil
+	return nil
+}
+
+// RecordSuccessfulLogin clears the failed login count and stamps last_login_at
+func (s *UserService) RecordSuccessfulLogin(user *models.User) error {
+	now := time.Now()
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", user.ID).Update(map[string]interface{}{
+		"failed_login_attempts": 0,
+		"locked_until":          nil,
+		"last_login_at":         now,
+	}); err != nil {
+		return fmt.Errorf("failed to record login: %w", err)
+	}
+
+	user.FailedLoginAttempts = 0
+	user.LockedUntil = nil
+	user.LastLoginAt = &now
+	return nil
+}
+
+// UnlockUser clears a lockout and the failed login count so the user can log in again
+func (s *UserService) UnlockUser(id uint) error {
+	if _, err := s.getUserByID(id); err != nil {
+		return err
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", id).Update(map[string]interface{}{
+		"failed_login_attempts": 0,
+		"locked_until":          nil,
+	}); err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
 	return nil
 }
 
@@ -427,8 +711,8 @@ func (s *UserService) GetAllRoles() ([]models.Role, error) {
 // CONTRACT IMPLEMENTATIONS - Required by CompleteCrudService interface
 
 // PaginationServiceContract implementation
-func (s *UserService) GetPaginatedList(req contracts.ListRequest) (*contracts.PaginatedResult, error) {
-	return s.GetList(req)
+func (s *UserService) GetPaginatedList(ctx context.Context, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+	return s.GetList(ctx, req)
 }
 
 // SortableServiceContract implementation
@@ -454,9 +738,25 @@ func (s *UserService) MapSortField(frontendField string) (string, bool) {
 	return "", false
 }
 
+// GetDefaultSort lists the most recently registered users first
+func (s *UserService) GetDefaultSort() (string, string) {
+	return "created_at", "DESC"
+}
+
+// buildOrderClause resolves the requested sort into an ORDER BY clause, falling back to the default sort
+func (s *UserService) buildOrderClause(req contracts.ListRequest) string {
+	// sort=is_active,name with direction=desc,asc sorts by several columns; invalid ones are dropped
+	if orderClause := s.BuildOrderClause(req, s.ValidateSortField, s.MapSortField); orderClause != "" {
+		return orderClause
+	}
+
+	defaultField, defaultDir := s.GetDefaultSort()
+	return defaultField + " " + defaultDir
+}
+
 // FilterableServiceContract implementation
 func (s *UserService) GetFilterableFields() []string {
-	return []string{"name", "email", "is_active", "is_super_admin", "role"}
+	return []string{"name", "email", "is_active", "is_super_admin", "role", "created_at"}
 }
 
 func (s *UserService) ValidateFilterField(field string) bool {
@@ -473,14 +773,75 @@ func (s *UserService) GetSearchableFields() []string {
 	return []string{"name", "email"}
 }
 
+// CountByFilter counts users with the same validated filters GetListAdvanced applies, including role and ranges
+func (s *UserService) CountByFilter(filters map[string]interface{}) (int64, error) {
+	validatedFilters, err := s.BuildFilterQuery(filters)
+	if err != nil {
+		return 0, err
+	}
+	rangeFilters, err := s.BuildRangeFilters(filters, s.ValidateFilterField)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	query := s.applyFilters(facades.Orm().Query().Model(&models.User{}), validatedFilters)
+	query = s.ApplyRangeFilters(query, rangeFilters)
+	if err := query.Count(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+// ValidateFilterValue checks filter values against the type each user filter expects
+func (s *UserService) ValidateFilterValue(field string, value interface{}) bool {
+	if values, ok := contracts.FilterValueList(value); ok {
+		return s.ValidateFilterValueList(values, func(v interface{}) bool {
+			return s.ValidateFilterValue(field, v)
+		})
+	}
+
+	switch field {
+	case "is_active", "is_super_admin":
+		_, ok := value.(bool)
+		return ok
+	case "role":
+		slug, ok := value.(string)
+		return ok && roleSlugPattern.MatchString(slug)
+	default:
+		return s.BaseCrudService.ValidateFilterValue(field, value)
+	}
+}
+
+// GetFilterFieldTypes coerces the boolean flags so is_active=true from a query string matches
+func (s *UserService) GetFilterFieldTypes() map[string]string {
+	return map[string]string{
+		"is_active":      contracts.FilterTypeBool,
+		"is_super_admin": contracts.FilterTypeBool,
+	}
+}
+
+// userMultiValueFilters are the filters that accept a list of values, matched with IN (...)
+var userMultiValueFilters = map[string]bool{"name": true, "email": true, "role": true}
+
 func (s *UserService) BuildFilterQuery(filters map[string]interface{}) (map[string]interface{}, error) {
 	validatedFilters := make(map[string]interface{})
+	fieldTypes := s.GetFilterFieldTypes()
 
 	for field, value := range filters {
 		if !s.ValidateFilterField(field) {
 			continue // Skip invalid fields
 		}
 
+		value, ok := contracts.CoerceFilterValue(value, fieldTypes[field])
+		if !ok {
+			continue // Skip values that don't convert to the field's type
+		}
+
+		if _, isList := contracts.FilterValueList(value); isList && !userMultiValueFilters[field] {
+			continue // Skip lists for single-value filters
+		}
+
 		if !s.ValidateFilterValue(field, value) {
 			continue // Skip invalid values
 		}
@@ -492,24 +853,18 @@ func (s *UserService) BuildFilterQuery(filters map[string]interface{}) (map[stri
 }
 
 // SearchableServiceContract implementation
-func (s *UserService) Search(query string, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
+func (s *UserService) Search(ctx context.Context, query string, req contracts.ListRequest) (*contracts.PaginatedResult, error) {
 	if err := s.ValidateSearchQuery(query); err != nil {
 		return nil, err
 	}
 
 	req.Search = query
-	return s.GetList(req)
+	return s.GetList(ctx, req)
 }
 
 func (s *UserService) ValidateSearchQuery(query string) error {
-	query = strings.TrimSpace(query)
-	if len(query) < 2 {
-		return fmt.Errorf("search query must be at least 2 characters")
-	}
-	if len(query) > 100 {
-		return fmt.Errorf("search query cannot exceed 100 characters")
-	}
-	return nil
+	minLength, maxLength := s.GetSearchConstraints()
+	return s.ValidateSearchLength(query, minLength, maxLength)
 }
 
 // BulkOperationsContract implementation
@@ -535,14 +890,11 @@ func (s *UserService) BulkUpdate(ids []uint, data map[string]interface{}) error
 		return err
 	}
 
-	for _, id := range ids {
+	// Continue past individual failures so callers get a per-ID summary
+	return s.RunBulkOperation("update", ids, func(id uint) error {
 		_, err := s.Update(id, data)
-		if err != nil {
-			return fmt.Errorf("bulk update failed for ID %d: %w", id, err)
-		}
-	}
-
-	return nil
+		return err
+	})
 }
 
 func (s *UserService) BulkDelete(ids []uint) error {
@@ -550,13 +902,8 @@ func (s *UserService) BulkDelete(ids []uint) error {
 		return err
 	}
 
-	for _, id := range ids {
-		if err := s.Delete(id); err != nil {
-			return fmt.Errorf("bulk delete failed for ID %d: %w", id, err)
-		}
-	}
-
-	return nil
+	// Continue past individual failures so callers get a per-ID summary
+	return s.RunBulkOperation("delete", ids, s.Delete)
 }
 
 // CrudServiceConfiguration implementation
@@ -568,13 +915,20 @@ func (s *UserService) GetValidationRules() map[string]interface{} {
 	return map[string]interface{}{
 		"name":           "required|string|max:255",
 		"email":          "required|email|max:255",
-		"password":       "string|min:8",
+		"password":       "string",
 		"is_active":      "boolean",
 		"is_super_admin": "boolean",
+		"email_verified": "boolean",
 		"role_id":        "numeric",
 	}
 }
 
+// GetFillableFields lists the fields user create and update write. is_super_admin is guarded and
+// only changes through CreateWithRole and UpdateWithSuperAdmin; role_id goes through SetRole.
+func (s *UserService) GetFillableFields() []string {
+	return []string{"name", "email", "password", "is_active", "email_verified"}
+}
+
 func (s *UserService) GetColumnMapping() map[string]string {
 	return map[string]string{
 		"id":            "id",
@@ -591,6 +945,10 @@ func (s *UserService) GetColumnMapping() map[string]string {
 	}
 }
 
+func (s *UserService) GetRelations() []string {
+	return []string{"Roles"}
+}
+
 // HELPER METHODS
 
 // validateWithRules uses the validation rules from the contract
@@ -613,27 +971,29 @@ func (s *UserService) validateWithRules(data map[string]interface{}, isUpdate bo
 	return s.validateUserData(data, isUpdate)
 }
 
-// validateUserData performs simple validation
+// validateUserData performs simple validation, collecting every failure by field
 func (s *UserService) validateUserData(data map[string]interface{}, isUpdate bool) error {
+	errs := contracts.NewFieldValidationError()
+
 	// Required fields for creation
 	if !isUpdate {
 		requiredFields := []string{"name", "email"}
 		for _, field := range requiredFields {
 			if value, exists := data[field]; !exists || value == "" {
-				return fmt.Errorf("%s is required", field)
+				errs.Add(field, fmt.Sprintf("%s is required", field))
 			}
 		}
-		
+
 		// Password is required for new users
 		if password, exists := data["password"]; !exists || password == "" {
-			return fmt.Errorf("password is required for new users")
+			errs.Add("password", "password is required for new users")
 		}
 	}
 
 	// Validate name if provided
 	if name, ok := data["name"].(string); ok && name != "" {
 		if len(name) < 2 || len(name) > 255 {
-			return fmt.Errorf("name must be between 2 and 255 characters")
+			errs.Add("name", "name must be between 2 and 255 characters")
 		}
 	}
 
@@ -642,19 +1002,19 @@ func (s *UserService) validateUserData(data map[string]interface{}, isUpdate boo
 		// Simple email regex validation
 		emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 		if !emailRegex.MatchString(email) {
-			return fmt.Errorf("invalid email format")
+			errs.Add("email", "invalid email format")
 		}
 		if len(email) > 255 {
-			return fmt.Errorf("email cannot exceed 255 characters")
+			errs.Add("email", "email cannot exceed 255 characters")
 		}
 	}
 
-	// Validate password if provided
+	// Validate password against the configured policy, one message per failed rule
 	if password, ok := data["password"].(string); ok && password != "" {
-		if len(password) < 8 {
-			return fmt.Errorf("password must be at least 8 characters")
+		for _, message := range ValidatePassword(password) {
+			errs.Add("password", message)
 		}
 	}
 
-	return nil
+	return errs.Err()
 }
\ No newline at end of file
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"players/app/models"
+	"players/tests/testdb"
+)
+
+// TestRecordFailedLoginCountsInTheDatabase replays a burst of wrong-password requests that all
+// loaded the user before any of them was recorded: every one must count toward the lockout.
+func TestRecordFailedLoginCountsInTheDatabase(t *testing.T) {
+	db := testdb.Open(t, &models.Permission{}, &models.Role{}, &models.User{}, &models.UserRole{})
+	db.Config["auth.lockout.max_attempts"] = 3
+
+	user := models.User{Name: "Reader", Email: "reader@example.com", Password: "x", IsActive: true}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	service := NewUserService()
+	for i := 0; i < 3; i++ {
+		stale := user
+		if err := service.RecordFailedLogin(&stale); err != nil {
+			t.Fatalf("RecordFailedLogin: %v", err)
+		}
+	}
+
+	var stored models.User
+	db.First(&stored, user.ID)
+	if stored.FailedLoginAttempts != 3 || !stored.IsLocked(time.Now()) {
+		t.Fatalf("after 3 stale failures: attempts = %d, locked until %v; want 3 and locked", stored.FailedLoginAttempts, stored.LockedUntil)
+	}
+
+	// Once the lock has expired the count starts over
+	expired := time.Now().Add(-time.Minute)
+	db.Model(&models.User{}).Where("id = ?", user.ID).Update("locked_until", expired)
+	if err := service.RecordFailedLogin(&stored); err != nil {
+		t.Fatalf("RecordFailedLogin: %v", err)
+	}
+	if stored.FailedLoginAttempts != 1 || stored.LockedUntil != nil {
+		t.Errorf("after an expired lock: attempts = %d, locked until %v; want 1 and unlocked", stored.FailedLoginAttempts, stored.LockedUntil)
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"time"
+
+	"github.com/goravel/framework/facades"
+)
+
+// WebhookPayload is the JSON body POSTed for a lifecycle event
+type WebhookPayload struct {
+	ID         string      `json:"id"`    // unique per delivery, for receivers to drop duplicates
+	Event      string      `json:"event"` // resource.event, e.g. books.updated
+	Resource   string      `json:"resource"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"` // the record after the change; its last state for deletes
+}
+
+// WebhookService sends lifecycle events to the URL configured for their resource under
+// webhooks.endpoints, retrying failures with exponential backoff
+type WebhookService struct {
+	client *nethttp.Client
+}
+
+// NewWebhookService creates a webhook service using webhooks.timeout_seconds per request
+func NewWebhookService() *WebhookService {
+	timeout := facades.Config().GetInt("webhooks.timeout_seconds", 10)
+	return &WebhookService{client: &nethttp.Client{Timeout: time.Duration(timeout) * time.Second}}
+}
+
+// URL returns the webhook URL configured for resource, or "" when it has none
+func (s *WebhookService) URL(resource string) string {
+	return facades.Config().GetString("webhooks.endpoints." + resource)
+}
+
+// Dispatch is a contracts.LifecycleListener. It builds the payload straight away, while record
+// still holds the saved state, then delivers it in the background so requests never wait on it.
+func (s *WebhookService) Dispatch(resource, event string, record interface{}) {
+	url := s.URL(resource)
+	if url == "" {
+		return
+	}
+
+	payload := WebhookPayload{
+		ID:         newWebhookDeliveryID(),
+		Event:      resource + "." + event,
+		Resource:   resource,
+		OccurredAt: time.Now().UTC(),
+		Data:       record,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		facades.Log().Error("Failed to encode webhook payload", map[string]interface{}{
+			"event": payload.Event,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	go s.deliver(url, payload, body)
+}
+
+// deliver sends body until it is accepted or webhooks.max_attempts is used up, then dead-letters it
+func (s *WebhookService) deliver(url string, payload WebhookPayload, body []byte) {
+	attempts := facades.Config().GetInt("webhooks.max_attempts", 5)
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := time.Duration(facades.Config().GetInt("webhooks.backoff_seconds", 2)) * time.Second
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = s.send(url, payload, body); err == nil {
+			return
+		}
+		if attempt < attempts {
+			time.Sleep(backoff << (attempt - 1))
+		}
+	}
+
+	channel := facades.Config().GetString("webhooks.dead_letter_log", "webhooks")
+	facades.Log().Channel(channel).Error("Webhook delivery failed", map[string]interface{}{
+		"delivery_id": payload.ID,
+		"event":       payload.Event,
+		"url":         url,
+		"attempts":    attempts,
+		"error":       err.Error(),
+		"payload":     string(body),
+	})
+}
+
+// send makes one delivery attempt; anything but a 2xx response is a failure
+func (s *WebhookService) send(url string, payload WebhookPayload, body []byte) error {
+	request, err := nethttp.NewRequest(nethttp.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Webhook-Event", payload.Event)
+	request.Header.Set("X-Webhook-Delivery", payload.ID)
+	if secret := facades.Config().GetString("webhooks.secret"); secret != "" {
+		request.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(secret, body))
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint responded %d", response.StatusCode)
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of body with secret. Receivers recompute it over
+// the raw request body and compare it with the X-Webhook-Signature header.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookDeliveryID returns a random 32 character hex ID
+func newWebhookDeliveryID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
@@ -72,6 +72,13 @@ func init() {
 		// the language folders that are provided through your application.
 		"fallback_locale": "en",
 
+		// Supported Locales
+		//
+		// The locales a request may ask for with ?lang= or Accept-Language,
+		// comma separated. Anything else is answered in the default locale,
+		// and lines missing from a locale's files fall back to English.
+		"locales": config.Env("APP_LOCALES", "en"),
+
 		// Application Lang Path
 		//
 		// The path to the language files for the application. You may change
@@ -84,6 +91,16 @@ func init() {
 		// will not be safe. Please do this before deploying an application!
 		"key": config.Env("APP_KEY", ""),
 
+		// Library settings
+		//
+		// A borrowed book is due back loan_days after it was borrowed; open
+		// loans past that date are listed by GET /api/books/overdue. A user
+		// may hold at most max_loans books at once.
+		"books": map[string]any{
+			"loan_days": config.Env("BOOK_LOAN_DAYS", 14),
+			"max_loans": config.Env("BOOK_MAX_LOANS", 5),
+		},
+
 		// Autoload service providers
 		//
 		// The service providers listed here will be automatically loaded on the
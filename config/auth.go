@@ -32,5 +32,36 @@ func init() {
 				"driver": "jwt",
 			},
 		},
+
+		// Account Lockout
+		//
+		// After max_attempts failed logins in a row the account is locked for
+		// the given number of minutes. The count and lock are stored on the
+		// users table, so they survive restarts. Set max_attempts to 0 to disable.
+		"lockout": map[string]any{
+			"max_attempts": config.Env("AUTH_MAX_LOGIN_ATTEMPTS", 5),
+			"minutes":      config.Env("AUTH_LOCKOUT_MINUTES", 15),
+		},
+
+		// Email Verification
+		//
+		// New users who were not created by an admin get a signed verification
+		// link, valid for ttl minutes. When required is true, unverified users
+		// cannot log in until they follow it.
+		"verification": map[string]any{
+			"required": config.Env("AUTH_REQUIRE_EMAIL_VERIFICATION", false),
+			"ttl":      config.Env("AUTH_VERIFICATION_TTL", 1440),
+		},
+
+		// Password Policy
+		//
+		// Enforced whenever a password is set through the user service or the
+		// user:create-admin command. Each failed rule is reported separately.
+		"password": map[string]any{
+			"min_length":     config.Env("AUTH_PASSWORD_MIN_LENGTH", 8),
+			"require_upper":  config.Env("AUTH_PASSWORD_REQUIRE_UPPER", true),
+			"require_digit":  config.Env("AUTH_PASSWORD_REQUIRE_DIGIT", true),
+			"require_symbol": config.Env("AUTH_PASSWORD_REQUIRE_SYMBOL", true),
+		},
 	})
 }
@@ -26,6 +26,17 @@ func init() {
 			},
 		},
 
+		// Record Cache
+		//
+		// Services that opt in with SetRecordCache(true) cache GetByID results
+		// under {resource}:{id} for ttl seconds (0 disables record caching).
+		// Point store at a Redis store to share the cache between instances;
+		// an empty store uses the default store above.
+		"records": map[string]any{
+			"ttl":   config.Env("CACHE_RECORD_TTL", 300),
+			"store": config.Env("CACHE_RECORD_STORE", ""),
+		},
+
 		// Cache Key Prefix
 		//
 		// When utilizing a RAM based store such as APC or Memcached, there might
@@ -14,12 +14,23 @@ func init() {
 		// in web browsers. You are free to adjust these settings as needed.
 		//
 		// To learn more: https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS
-		"paths":                []string{"*"},
-		"allowed_methods":      []string{"*"},
-		"allowed_origins":      []string{"*"},
-		"allowed_headers":      []string{"*"},
-		"exposed_headers":      []string{""},
-		"max_age":              0,
-		"supports_credentials": false,
+		//
+		// The policy is applied by middleware.Cors, so "paths", which the framework's built-in
+		// handler reads, stays empty to keep that handler from answering with its own defaults.
+		"paths": []string{},
+
+		// Comma separated path patterns the policy applies to; a trailing "*" matches a prefix
+		"routes": config.Env("CORS_PATHS", "api/*"),
+
+		// Comma separated origins, e.g. "https://admin.example.com,https://*.example.com".
+		// A bare "*" allows any origin, which is ignored while APP_ENV is production unless
+		// allow_wildcard_in_production is set.
+		"allowed_origins":              config.Env("CORS_ALLOWED_ORIGINS", "*"),
+		"allow_wildcard_in_production": config.Env("CORS_ALLOW_WILDCARD_IN_PRODUCTION", false),
+		"allowed_methods":              config.Env("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE"),
+		"allowed_headers":              config.Env("CORS_ALLOWED_HEADERS", "Accept,Accept-Language,Authorization,Content-Type,If-None-Match,X-Requested-With"),
+		"exposed_headers":              config.Env("CORS_EXPOSED_HEADERS", "ETag,Retry-After,X-RateLimit-Limit,X-RateLimit-Remaining"),
+		"max_age":                      config.Env("CORS_MAX_AGE", 0),
+		"supports_credentials":         config.Env("CORS_SUPPORTS_CREDENTIALS", false),
 	})
 }
@@ -91,8 +91,15 @@ func init() {
 		},
 
 		// Sets the threshold for slow queries in milliseconds, the slow query will be logged.
+		// The ORM logs every statement slower than this as a warning with its SQL and duration;
+		// 0 or less falls back to 200.
 		// Unit: Millisecond
-		"slow_threshold": 200,
+		"slow_threshold": config.Env("DB_SLOW_THRESHOLD", 200),
+
+		// Deadline for the list queries of the CRUD services; a query still running when it passes,
+		// or when the client disconnects, is cancelled and the request answered with a 504.
+		// Unit: Second
+		"query_timeout": config.Env("DB_QUERY_TIMEOUT", 30),
 
 		// Migration Repository Table
 		//
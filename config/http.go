@@ -21,7 +21,8 @@ func init() {
 		// HTTP Drivers
 		"drivers": map[string]any{
 			"gin": map[string]any{
-				// Optional, default is 4096 KB
+				// Optional, default is 4096 KB. Gin's multipart memory: larger uploads spill to
+				// temp files rather than being rejected. Request size limits are max_request_body.
 				"body_limit":   4096,
 				"header_limit": 4096,
 				"route": func() (route.Route, error) {
@@ -78,6 +79,31 @@ func init() {
 		"port": config.Env("APP_PORT", "3500"),
 		// HTTP Timeout, default is 3 seconds
 		"request_timeout": 3,
+		// Per-IP rate limits, used by middleware.RateLimit(name). Each client may make
+		// "requests" requests per "window" seconds, refilled continuously.
+		"rate_limit": map[string]any{
+			// Public, unauthenticated book lookups
+			"public": map[string]any{
+				"requests": config.Env("RATE_LIMIT_PUBLIC_REQUESTS", 60),
+				"window":   config.Env("RATE_LIMIT_PUBLIC_WINDOW", 60),
+			},
+		},
+		// Largest request body in KB, enforced by middleware.BodyLimit before any handler
+		// reads it; larger requests get a 413. Imports and bulk operations carry more
+		// rows than a single create or update, so they have their own limits. Unlike
+		// drivers.gin.body_limit, which only sizes multipart buffering, this rejects.
+		"max_request_body": map[string]any{
+			"default": config.Env("HTTP_BODY_LIMIT", 1024),
+			"import":  config.Env("HTTP_IMPORT_BODY_LIMIT", 10240),
+			"bulk":    config.Env("HTTP_BULK_BODY_LIMIT", 4096),
+		},
+		// Hard cap on the pageSize of every list endpoint; larger requests are clamped to it
+		"pagination": map[string]any{
+			"max_page_size": config.Env("PAGINATION_MAX_PAGE_SIZE", 100),
+		},
+		// Reject create/update bodies holding fields the resource doesn't accept with a 422
+		// listing them, instead of dropping them; useful to catch field-name mismatches
+		"strict_fields": config.Env("HTTP_STRICT_FIELDS", false),
 		// HTTPS Configuration
 		"tls": map[string]any{
 			// HTTPS Host
@@ -37,6 +37,14 @@ func init() {
 				"days":   7,
 				"print":  false,
 			},
+			// Dead letter log for webhook deliveries that failed every retry
+			"webhooks": map[string]any{
+				"driver": "daily",
+				"path":   "storage/logs/webhooks.log",
+				"level":  "warning",
+				"days":   30,
+				"print":  false,
+			},
 		},
 	})
 }
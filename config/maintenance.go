@@ -0,0 +1,37 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("maintenance", map[string]any{
+		// Maintenance Mode
+		//
+		// `artisan down` takes the application down by writing the file below and
+		// `artisan up` brings it back by removing it, so every server process
+		// sharing the storage directory follows the flag. MAINTENANCE_MODE=true
+		// keeps the application down whatever the file says.
+		"enabled": config.Env("MAINTENANCE_MODE", false),
+		"file":    config.Env("MAINTENANCE_FILE", "storage/framework/down"),
+
+		// While down, requests get a 503 except from super admins and from these
+		// comma separated IPs or CIDR ranges, e.g. "10.0.0.0/8,203.0.113.7".
+		// They are matched against the connection's remote address, not the
+		// X-Forwarded-For header, which any client can set.
+		"allowed_ips": config.Env("MAINTENANCE_ALLOWED_IPS", ""),
+
+		// Behind a load balancer or reverse proxy, list its IPs or CIDR ranges
+		// here: X-Forwarded-For is then read, from the right, only on
+		// connections from them, and only past hops that are themselves listed.
+		"trusted_proxies": config.Env("MAINTENANCE_TRUSTED_PROXIES", ""),
+
+		// Comma separated paths that stay up, so admins can still log in and
+		// probes keep reporting; a trailing "*" matches a prefix
+		"except": config.Env("MAINTENANCE_EXCEPT", "login,logout,healthz,readyz,api/auth/login,api/auth/refresh,api/auth/logout"),
+
+		// Seconds clients are told to wait in the Retry-After header (0 sends none)
+		"retry_after": config.Env("MAINTENANCE_RETRY_AFTER", 60),
+	})
+}
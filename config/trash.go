@@ -0,0 +1,20 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("trash", map[string]any{
+		// Trash Retention
+		//
+		// trash:purge permanently deletes records that have been soft-deleted
+		// for longer than these many days; resources not listed keep theirs
+		// for 30. An explicit --days overrides them for that run.
+		"retention_days": map[string]any{
+			"books": config.Env("TRASH_BOOKS_RETENTION_DAYS", 30),
+			"users": config.Env("TRASH_USERS_RETENTION_DAYS", 90),
+		},
+	})
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("webhooks", map[string]any{
+		// Resource Webhooks
+		//
+		// After a record is created, updated, deleted, restored or force
+		// deleted, its event is POSTed as JSON to the URL configured for the
+		// resource here. Resources without a URL send nothing. Each body is
+		// signed with an HMAC-SHA256 of the secret, sent as
+		// "X-Webhook-Signature: sha256=<hex>".
+		"endpoints": map[string]any{
+			"books": config.Env("WEBHOOK_BOOKS_URL", ""),
+		},
+		"secret": config.Env("WEBHOOK_SECRET", ""),
+
+		// Failed deliveries are retried up to max_attempts times in total,
+		// waiting backoff_seconds before the first retry and doubling after
+		// each one. Deliveries that still fail are written to the dead letter
+		// log channel with their full payload so they can be replayed.
+		"timeout_seconds": config.Env("WEBHOOK_TIMEOUT", 10),
+		"max_attempts":    config.Env("WEBHOOK_MAX_ATTEMPTS", 5),
+		"backoff_seconds": config.Env("WEBHOOK_BACKOFF", 2),
+		"dead_letter_log": "webhooks",
+	})
+}
@@ -21,6 +21,19 @@ func (kernel Kernel) Migrations() []schema.Migration {
 		&migrations.M20250626020339CreateUserRolesTable{},
 		&migrations.M20250626020345CreateRolePermissionsTable{},
 		&migrations.M20250628091858AddIsSuperAdminToUsersTable{},
+		&migrations.M20250701090000CreateAuditLogsTable{},
+		&migrations.M20250702090000CreateBooksSearchIndex{},
+		&migrations.M20250703090000CreateRefreshTokensTable{},
+		&migrations.M20250704090000AddLockoutFieldsToUsersTable{},
+		&migrations.M20250705090000AddEmailVerifiedAtToUsersTable{},
+		&migrations.M20250706090000CreateBookLoansTable{},
+		&migrations.M20250707090000CreateCategoriesTable{},
+		&migrations.M20250707090001AddCategoryIdToBooksTable{},
+		&migrations.M20250708090000NormalizeBookIsbns{},
+		&migrations.M20250709090000AddMetadataToBooksTable{},
+		&migrations.M20250710090000CreateImpersonationsTable{},
+		&migrations.M20250711090000CreateApiKeysTable{},
+		&migrations.M20250712090000RebuildBooksSearchIndex{},
 	}
 }
 
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250701090000CreateAuditLogsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250701090000CreateAuditLogsTable) Signature() string {
+	return "20250701090000_create_audit_logs_table"
+}
+
+// Up Run the migrations.
+func (r *M20250701090000CreateAuditLogsTable) Up() error {
+	return facades.Schema().Create("audit_logs", func(table schema.Blueprint) {
+		table.ID()
+		table.UnsignedBigInteger("user_id").Nullable()
+		table.String("resource_type", 100)
+		table.UnsignedBigInteger("resource_id")
+		table.String("action", 50)
+		table.Text("changes").Nullable()
+		table.Timestamp("created_at").Nullable()
+
+		// Audit trails are read per record, newest first
+		table.Index("resource_type", "resource_id")
+		table.Index("user_id")
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250701090000CreateAuditLogsTable) Down() error {
+	return facades.Schema().DropIfExists("audit_logs")
+}
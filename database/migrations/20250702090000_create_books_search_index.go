@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250702090000CreateBooksSearchIndex struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250702090000CreateBooksSearchIndex) Signature() string {
+	return "20250702090000_create_books_search_index"
+}
+
+// Up Run the migrations.
+//
+// BookService searches in full-text mode. On SQLite that queries the books_fts FTS5 table,
+// an external-content index over books kept in sync by triggers. On Postgres the search runs
+// against to_tsvector over the searchable fields, so only a GIN index on that expression is
+// needed. Other drivers fall back to LIKE and need nothing here.
+func (r *M20250702090000CreateBooksSearchIndex) Up() error {
+	var statements []string
+	switch facades.Orm().Query().Driver() {
+	case database.DriverSqlite:
+		statements = []string{
+			`CREATE VIRTUAL TABLE IF NOT EXISTS books_fts USING fts5(title, author, description, isbn, content='books', content_rowid='id')`,
+			`CREATE TRIGGER IF NOT EXISTS books_fts_insert AFTER INSERT ON books BEGIN
+				INSERT INTO books_fts(rowid, title, author, description, isbn) VALUES (new.id, new.title, new.author, new.description, new.isbn);
+			END`,
+			`CREATE TRIGGER IF NOT EXISTS books_fts_delete AFTER DELETE ON books BEGIN
+				INSERT INTO books_fts(books_fts, rowid, title, author, description, isbn) VALUES ('delete', old.id, old.title, old.author, old.description, old.isbn);
+			END`,
+			`CREATE TRIGGER IF NOT EXISTS books_fts_update AFTER UPDATE ON books BEGIN
+				INSERT INTO books_fts(books_fts, rowid, title, author, description, isbn) VALUES ('delete', old.id, old.title, old.author, old.description, old.isbn);
+				INSERT INTO books_fts(rowid, title, author, description, isbn) VALUES (new.id, new.title, new.author, new.description, new.isbn);
+			END`,
+			// Index the books that already exist
+			`INSERT INTO books_fts(books_fts) VALUES ('rebuild')`,
+		}
+	case database.DriverPostgres:
+		// Must match the expression BaseCrudService.searchDocument builds for the searchable fields
+		statements = []string{
+			`CREATE INDEX IF NOT EXISTS books_search_idx ON books USING GIN (to_tsvector('simple', ` +
+				`coalesce(title::text, '') || ' ' || coalesce(author::text, '') || ' ' || ` +
+				`coalesce(description::text, '') || ' ' || coalesce(isbn::text, '')))`,
+		}
+	}
+
+	for _, statement := range statements {
+		if err := facades.Schema().Sql(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250702090000CreateBooksSearchIndex) Down() error {
+	var statements []string
+	switch facades.Orm().Query().Driver() {
+	case database.DriverSqlite:
+		statements = []string{
+			`DROP TRIGGER IF EXISTS books_fts_insert`,
+			`DROP TRIGGER IF EXISTS books_fts_delete`,
+			`DROP TRIGGER IF EXISTS books_fts_update`,
+			`DROP TABLE IF EXISTS books_fts`,
+		}
+	case database.DriverPostgres:
+		statements = []string{`DROP INDEX IF EXISTS books_search_idx`}
+	}
+
+	for _, statement := range statements {
+		if err := facades.Schema().Sql(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
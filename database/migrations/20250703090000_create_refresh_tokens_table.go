@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250703090000CreateRefreshTokensTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250703090000CreateRefreshTokensTable) Signature() string {
+	return "20250703090000_create_refresh_tokens_table"
+}
+
+// Up Run the migrations.
+func (r *M20250703090000CreateRefreshTokensTable) Up() error {
+	return facades.Schema().Create("refresh_tokens", func(table schema.Blueprint) {
+		table.ID()
+		table.UnsignedBigInteger("user_id")
+		table.String("token_hash", 64)
+		table.Timestamp("expires_at")
+		table.Timestamp("revoked_at").Nullable()
+		table.Timestamps()
+
+		// Tokens are looked up by hash on refresh and revoked per user on reuse or logout
+		table.Unique("token_hash")
+		table.Index("user_id")
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250703090000CreateRefreshTokensTable) Down() error {
+	return facades.Schema().DropIfExists("refresh_tokens")
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250704090000AddLockoutFieldsToUsersTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250704090000AddLockoutFieldsToUsersTable) Signature() string {
+	return "20250704090000_add_lockout_fields_to_users_table"
+}
+
+// Up Run the migrations.
+func (r *M20250704090000AddLockoutFieldsToUsersTable) Up() error {
+	return facades.Schema().Table("users", func(table schema.Blueprint) {
+		table.Integer("failed_login_attempts").Default(0)
+		table.Timestamp("locked_until").Nullable()
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250704090000AddLockoutFieldsToUsersTable) Down() error {
+	return facades.Schema().Table("users", func(table schema.Blueprint) {
+		table.DropColumn("failed_login_attempts", "locked_until")
+	})
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250705090000AddEmailVerifiedAtToUsersTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250705090000AddEmailVerifiedAtToUsersTable) Signature() string {
+	return "20250705090000_add_email_verified_at_to_users_table"
+}
+
+// Up Run the migrations.
+func (r *M20250705090000AddEmailVerifiedAtToUsersTable) Up() error {
+	if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+		table.Timestamp("email_verified_at").Nullable()
+	}); err != nil {
+		return err
+	}
+
+	// Users already flagged as verified keep that status. The update runs on the schema connection
+	// so it sees the new column inside the migration transaction.
+	_, err := facades.Schema().Orm().Query().Exec("UPDATE users SET email_verified_at = updated_at WHERE email_verified = ? AND email_verified_at IS NULL", true)
+	return err
+}
+
+// Down Reverse the migrations.
+func (r *M20250705090000AddEmailVerifiedAtToUsersTable) Down() error {
+	return facades.Schema().Table("users", func(table schema.Blueprint) {
+		table.DropColumn("email_verified_at")
+	})
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250706090000CreateBookLoansTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250706090000CreateBookLoansTable) Signature() string {
+	return "20250706090000_create_book_loans_table"
+}
+
+// Up Run the migrations.
+func (r *M20250706090000CreateBookLoansTable) Up() error {
+	return facades.Schema().Create("book_loans", func(table schema.Blueprint) {
+		table.ID()
+		table.UnsignedBigInteger("book_id")
+		table.UnsignedBigInteger("user_id")
+		table.Timestamp("borrowed_at")
+		table.Timestamp("due_at")
+		table.Timestamp("returned_at").Nullable()
+		table.Timestamps()
+
+		// Returns close the open loan of a book; the overdue list scans open loans by due date
+		table.Index("book_id", "returned_at")
+		table.Index("returned_at", "due_at")
+		table.Index("user_id")
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250706090000CreateBookLoansTable) Down() error {
+	return facades.Schema().DropIfExists("book_loans")
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250707090000CreateCategoriesTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250707090000CreateCategoriesTable) Signature() string {
+	return "20250707090000_create_categories_table"
+}
+
+// Up Run the migrations.
+func (r *M20250707090000CreateCategoriesTable) Up() error {
+	return facades.Schema().Create("categories", func(table schema.Blueprint) {
+		table.ID()
+		table.String("name")
+		table.String("slug")
+		table.Text("description").Nullable()
+		table.Timestamps()
+
+		table.Unique("name")
+		table.Unique("slug")
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250707090000CreateCategoriesTable) Down() error {
+	return facades.Schema().DropIfExists("categories")
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250707090001AddCategoryIdToBooksTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250707090001AddCategoryIdToBooksTable) Signature() string {
+	return "20250707090001_add_category_id_to_books_table"
+}
+
+// Up Run the migrations.
+func (r *M20250707090001AddCategoryIdToBooksTable) Up() error {
+	// Existing books stay uncategorized until they are assigned one
+	return facades.Schema().Table("books", func(table schema.Blueprint) {
+		table.UnsignedBigInteger("category_id").Nullable()
+		table.Foreign("category_id").References("id").On("categories").NullOnDelete()
+		table.Index("category_id")
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250707090001AddCategoryIdToBooksTable) Down() error {
+	return facades.Schema().Table("books", func(table schema.Blueprint) {
+		table.DropForeign("category_id")
+		table.DropIndex("category_id")
+		table.DropColumn("category_id")
+	})
+}
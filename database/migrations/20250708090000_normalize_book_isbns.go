@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+type M20250708090000NormalizeBookIsbns struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250708090000NormalizeBookIsbns) Signature() string {
+	return "20250708090000_normalize_book_isbns"
+}
+
+// Up Run the migrations.
+func (r *M20250708090000NormalizeBookIsbns) Up() error {
+	// Books are looked up by their hyphen-free ISBN, so existing rows are brought to that form.
+	// The update runs on the schema connection so it stays inside the migration transaction.
+	_, err := facades.Schema().Orm().Query().Exec("UPDATE books SET isbn = UPPER(REPLACE(REPLACE(isbn, '-', ''), ' ', '')) WHERE isbn LIKE '%-%' OR isbn LIKE '% %'")
+	return err
+}
+
+// Down Reverse the migrations.
+func (r *M20250708090000NormalizeBookIsbns) Down() error {
+	// The original hyphenation is not recorded, so there is nothing to restore
+	return nil
+}
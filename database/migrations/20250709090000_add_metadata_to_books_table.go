@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250709090000AddMetadataToBooksTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250709090000AddMetadataToBooksTable) Signature() string {
+	return "20250709090000_add_metadata_to_books_table"
+}
+
+// Up Run the migrations.
+func (r *M20250709090000AddMetadataToBooksTable) Up() error {
+	// Free-form details such as edition or format that don't warrant their own columns
+	return facades.Schema().Table("books", func(table schema.Blueprint) {
+		table.Json("metadata").Nullable()
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250709090000AddMetadataToBooksTable) Down() error {
+	return facades.Schema().Table("books", func(table schema.Blueprint) {
+		table.DropColumn("metadata")
+	})
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250710090000CreateImpersonationsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250710090000CreateImpersonationsTable) Signature() string {
+	return "20250710090000_create_impersonations_table"
+}
+
+// Up Run the migrations.
+func (r *M20250710090000CreateImpersonationsTable) Up() error {
+	return facades.Schema().Create("impersonations", func(table schema.Blueprint) {
+		table.ID()
+		table.UnsignedBigInteger("impersonator_id")
+		table.UnsignedBigInteger("impersonated_id")
+		table.String("token_hash", 64)
+		table.Timestamp("started_at")
+		table.Timestamp("expires_at")
+		table.Timestamp("ended_at").Nullable()
+		table.Timestamps()
+
+		// Requests look the session up by the hash of their access token
+		table.Unique("token_hash")
+		table.Index("impersonator_id")
+		table.Index("impersonated_id")
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250710090000CreateImpersonationsTable) Down() error {
+	return facades.Schema().DropIfExists("impersonations")
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250711090000CreateApiKeysTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250711090000CreateApiKeysTable) Signature() string {
+	return "20250711090000_create_api_keys_table"
+}
+
+// Up Run the migrations.
+func (r *M20250711090000CreateApiKeysTable) Up() error {
+	return facades.Schema().Create("api_keys", func(table schema.Blueprint) {
+		table.ID()
+		table.String("name")
+		table.String("prefix", 16)
+		table.String("key_hash", 64)
+		table.UnsignedBigInteger("user_id")
+		table.Json("scopes").Nullable()
+		table.Timestamp("last_used_at").Nullable()
+		table.Timestamp("revoked_at").Nullable()
+		table.Timestamps()
+
+		// Requests look the key up by its hash
+		table.Unique("key_hash")
+		table.Index("user_id")
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20250711090000CreateApiKeysTable) Down() error {
+	return facades.Schema().DropIfExists("api_keys")
+}
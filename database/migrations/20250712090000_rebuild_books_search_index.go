@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250712090000RebuildBooksSearchIndex struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250712090000RebuildBooksSearchIndex) Signature() string {
+	return "20250712090000_rebuild_books_search_index"
+}
+
+// Up Run the migrations.
+//
+// BookService matches ISBNs exactly, so they are no longer part of the full-text document on
+// Postgres. The GIN index is rebuilt on the expression without isbn to keep searches using it.
+// SQLite limits its FTS5 match to the other columns and needs no change.
+func (r *M20250712090000RebuildBooksSearchIndex) Up() error {
+	return r.rebuild(`coalesce(title::text, '') || ' ' || coalesce(author::text, '') || ' ' || ` +
+		`coalesce(description::text, '')`)
+}
+
+// Down Reverse the migrations.
+func (r *M20250712090000RebuildBooksSearchIndex) Down() error {
+	return r.rebuild(`coalesce(title::text, '') || ' ' || coalesce(author::text, '') || ' ' || ` +
+		`coalesce(description::text, '') || ' ' || coalesce(isbn::text, '')`)
+}
+
+// rebuild replaces books_search_idx with a GIN index on document, which must match the
+// expression BaseCrudService.searchDocument builds
+func (r *M20250712090000RebuildBooksSearchIndex) rebuild(document string) error {
+	if facades.Orm().Query().Driver() != database.DriverPostgres {
+		return nil
+	}
+
+	for _, statement := range []string{
+		`DROP INDEX IF EXISTS books_search_idx`,
+		`CREATE INDEX IF NOT EXISTS books_search_idx ON books USING GIN (to_tsvector('simple', ` + document + `))`,
+	} {
+		if err := facades.Schema().Sql(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
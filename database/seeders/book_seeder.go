@@ -15,6 +15,36 @@ func (s *BookSeeder) Signature() string {
 
 // Run executes the seeder logic.
 func (s *BookSeeder) Run() error {
+	categories := []models.Category{
+		{Name: "Classic Literature", Slug: "classic-literature"},
+		{Name: "Science Fiction", Slug: "science-fiction"},
+		{Name: "Fantasy", Slug: "fantasy"},
+		{Name: "Mystery & Thriller", Slug: "mystery-thriller"},
+		{Name: "Non-Fiction", Slug: "non-fiction"},
+		{Name: "Contemporary Fiction", Slug: "contemporary-fiction"},
+		{Name: "Horror", Slug: "horror"},
+		{Name: "Romance", Slug: "romance"},
+		{Name: "Young Adult", Slug: "young-adult"},
+		{Name: "Historical Fiction", Slug: "historical-fiction"},
+		{Name: "Biography", Slug: "biography"},
+		{Name: "Philosophy", Slug: "philosophy"},
+		{Name: "Business", Slug: "business"},
+		{Name: "Technology", Slug: "technology"},
+	}
+
+	// Categories are matched by slug so reseeding reuses them
+	categoryIDs := make(map[string]uint, len(categories))
+	for _, category := range categories {
+		if err := facades.Orm().Query().FirstOrCreate(&category, models.Category{Slug: category.Slug}); err != nil {
+			return err
+		}
+		categoryIDs[category.Slug] = category.ID
+	}
+	category := func(slug string) *uint {
+		id := categoryIDs[slug]
+		return &id
+	}
+
 	books := []models.Book{
 		// Classic Literature
 		{
@@ -25,6 +55,7 @@ func (s *BookSeeder) Run() error {
 			Price:       14.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1960-07-11",
+			CategoryID:  category("classic-literature"),
 		},
 		{
 			Title:       "1984",
@@ -34,6 +65,7 @@ func (s *BookSeeder) Run() error {
 			Price:       13.99,
 			Status:      "BORROWED",
 			PublishedAt: "1949-06-08",
+			CategoryID:  category("classic-literature"),
 		},
 		{
 			Title:       "Pride and Prejudice",
@@ -43,6 +75,7 @@ func (s *BookSeeder) Run() error {
 			Price:       12.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1813-01-28",
+			CategoryID:  category("classic-literature"),
 		},
 		{
 			Title:       "The Great Gatsby",
@@ -52,6 +85,7 @@ func (s *BookSeeder) Run() error {
 			Price:       15.99,
 			Status:      "MAINTENANCE",
 			PublishedAt: "1925-04-10",
+			CategoryID:  category("classic-literature"),
 		},
 		{
 			Title:       "Jane Eyre",
@@ -61,6 +95,7 @@ func (s *BookSeeder) Run() error {
 			Price:       11.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1847-10-16",
+			CategoryID:  category("classic-literature"),
 		},
 
 		// Science Fiction
@@ -72,24 +107,27 @@ func (s *BookSeeder) Run() error {
 			Price:       16.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1965-08-01",
+			CategoryID:  category("science-fiction"),
 		},
 		{
 			Title:       "Foundation",
 			Author:      "Isaac Asimov",
-			ISBN:        "978-0-553-29335-0",
+			ISBN:        "978-0-553-29335-7",
 			Description: "A cycle of five interrelated short stories, first published as a single book in 1951.",
 			Price:       14.99,
 			Status:      "BORROWED",
 			PublishedAt: "1951-05-01",
+			CategoryID:  category("science-fiction"),
 		},
 		{
 			Title:       "Neuromancer",
 			Author:      "William Gibson",
-			ISBN:        "978-0-441-56956-9",
+			ISBN:        "978-0-441-56956-4",
 			Description: "A 1984 science fiction novel. It is one of the best-known works in the cyberpunk genre.",
 			Price:       13.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1984-07-01",
+			CategoryID:  category("science-fiction"),
 		},
 		{
 			Title:       "The Hitchhiker's Guide to the Galaxy",
@@ -99,6 +137,7 @@ func (s *BookSeeder) Run() error {
 			Price:       12.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1979-10-12",
+			CategoryID:  category("science-fiction"),
 		},
 		{
 			Title:       "Ender's Game",
@@ -108,6 +147,7 @@ func (s *BookSeeder) Run() error {
 			Price:       15.99,
 			Status:      "BORROWED",
 			PublishedAt: "1985-01-15",
+			CategoryID:  category("science-fiction"),
 		},
 
 		// Fantasy
@@ -119,15 +159,17 @@ func (s *BookSeeder) Run() error {
 			Price:       18.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1954-07-29",
+			CategoryID:  category("fantasy"),
 		},
 		{
 			Title:       "Harry Potter and the Philosopher's Stone",
 			Author:      "J.K. Rowling",
-			ISBN:        "978-0-439-70818-8",
+			ISBN:        "978-0-439-70818-0",
 			Description: "A fantasy novel written by British author J. K. Rowling. The first novel in the Harry Potter series.",
 			Price:       17.99,
 			Status:      "BORROWED",
 			PublishedAt: "1997-06-26",
+			CategoryID:  category("fantasy"),
 		},
 		{
 			Title:       "A Game of Thrones",
@@ -137,6 +179,7 @@ func (s *BookSeeder) Run() error {
 			Price:       19.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1996-08-01",
+			CategoryID:  category("fantasy"),
 		},
 		{
 			Title:       "The Name of the Wind",
@@ -146,6 +189,7 @@ func (s *BookSeeder) Run() error {
 			Price:       16.99,
 			Status:      "MAINTENANCE",
 			PublishedAt: "2007-03-27",
+			CategoryID:  category("fantasy"),
 		},
 		{
 			Title:       "The Way of Kings",
@@ -155,17 +199,19 @@ func (s *BookSeeder) Run() error {
 			Price:       21.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2010-08-31",
+			CategoryID:  category("fantasy"),
 		},
 
 		// Mystery/Thriller
 		{
 			Title:       "The Girl with the Dragon Tattoo",
 			Author:      "Stieg Larsson",
-			ISBN:        "978-0-307-49892-6",
+			ISBN:        "978-0-307-49892-2",
 			Description: "A psychological thriller novel. It is the first book of the Millennium series.",
 			Price:       15.99,
 			Status:      "BORROWED",
 			PublishedAt: "2005-08-01",
+			CategoryID:  category("mystery-thriller"),
 		},
 		{
 			Title:       "Gone Girl",
@@ -175,6 +221,7 @@ func (s *BookSeeder) Run() error {
 			Price:       16.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2012-06-05",
+			CategoryID:  category("mystery-thriller"),
 		},
 		{
 			Title:       "The Da Vinci Code",
@@ -184,24 +231,27 @@ func (s *BookSeeder) Run() error {
 			Price:       14.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2003-03-18",
+			CategoryID:  category("mystery-thriller"),
 		},
 		{
 			Title:       "And Then There Were None",
 			Author:      "Agatha Christie",
-			ISBN:        "978-0-06-207348-6",
+			ISBN:        "978-0-06-207348-8",
 			Description: "A mystery novel. It was first published in the United Kingdom by the Collins Crime Club.",
 			Price:       13.99,
 			Status:      "BORROWED",
 			PublishedAt: "1939-11-06",
+			CategoryID:  category("mystery-thriller"),
 		},
 		{
 			Title:       "The Big Sleep",
 			Author:      "Raymond Chandler",
-			ISBN:        "978-0-394-75828-5",
+			ISBN:        "978-0-394-75828-2",
 			Description: "A hardboiled crime novel. It has been adapted for film twice, in 1946 and again in 1978.",
 			Price:       12.99,
 			Status:      "MAINTENANCE",
 			PublishedAt: "1939-01-01",
+			CategoryID:  category("mystery-thriller"),
 		},
 
 		// Non-Fiction
@@ -213,6 +263,7 @@ func (s *BookSeeder) Run() error {
 			Price:       18.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2011-01-01",
+			CategoryID:  category("non-fiction"),
 		},
 		{
 			Title:       "Educated",
@@ -222,6 +273,7 @@ func (s *BookSeeder) Run() error {
 			Price:       17.99,
 			Status:      "BORROWED",
 			PublishedAt: "2018-02-20",
+			CategoryID:  category("non-fiction"),
 		},
 		{
 			Title:       "The Immortal Life of Henrietta Lacks",
@@ -231,6 +283,7 @@ func (s *BookSeeder) Run() error {
 			Price:       16.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2010-02-02",
+			CategoryID:  category("non-fiction"),
 		},
 		{
 			Title:       "Thinking, Fast and Slow",
@@ -240,6 +293,7 @@ func (s *BookSeeder) Run() error {
 			Price:       19.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2011-10-25",
+			CategoryID:  category("non-fiction"),
 		},
 		{
 			Title:       "The Power of Habit",
@@ -249,6 +303,7 @@ func (s *BookSeeder) Run() error {
 			Price:       15.99,
 			Status:      "MAINTENANCE",
 			PublishedAt: "2012-02-28",
+			CategoryID:  category("non-fiction"),
 		},
 
 		// Contemporary Fiction
@@ -260,6 +315,7 @@ func (s *BookSeeder) Run() error {
 			Price:       14.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2003-05-29",
+			CategoryID:  category("contemporary-fiction"),
 		},
 		{
 			Title:       "Life of Pi",
@@ -269,6 +325,7 @@ func (s *BookSeeder) Run() error {
 			Price:       13.99,
 			Status:      "BORROWED",
 			PublishedAt: "2001-09-11",
+			CategoryID:  category("contemporary-fiction"),
 		},
 		{
 			Title:       "The Book Thief",
@@ -278,44 +335,49 @@ func (s *BookSeeder) Run() error {
 			Price:       15.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2005-03-14",
+			CategoryID:  category("contemporary-fiction"),
 		},
 		{
 			Title:       "Where the Crawdads Sing",
 			Author:      "Delia Owens",
-			ISBN:        "978-0-735-21953-0",
+			ISBN:        "978-0-735-21953-3",
 			Description: "A 2018 novel by American zoologist Delia Owens.",
 			Price:       16.99,
 			Status:      "BORROWED",
 			PublishedAt: "2018-08-14",
+			CategoryID:  category("contemporary-fiction"),
 		},
 		{
 			Title:       "The Seven Husbands of Evelyn Hugo",
 			Author:      "Taylor Jenkins Reid",
-			ISBN:        "978-1-501-16134-8",
+			ISBN:        "978-1-501-16134-6",
 			Description: "A novel by American author Taylor Jenkins Reid and published in 2017.",
 			Price:       14.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2017-06-13",
+			CategoryID:  category("contemporary-fiction"),
 		},
 
 		// Horror
 		{
 			Title:       "The Shining",
 			Author:      "Stephen King",
-			ISBN:        "978-0-307-74365-9",
+			ISBN:        "978-0-307-74365-7",
 			Description: "A horror novel by American author Stephen King.",
 			Price:       15.99,
 			Status:      "MAINTENANCE",
 			PublishedAt: "1977-01-28",
+			CategoryID:  category("horror"),
 		},
 		{
 			Title:       "Dracula",
 			Author:      "Bram Stoker",
-			ISBN:        "978-0-486-41109-7",
+			ISBN:        "978-0-486-41109-5",
 			Description: "An 1897 Gothic horror novel by Irish author Bram Stoker.",
 			Price:       11.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1897-05-26",
+			CategoryID:  category("horror"),
 		},
 		{
 			Title:       "Frankenstein",
@@ -325,26 +387,29 @@ func (s *BookSeeder) Run() error {
 			Price:       10.99,
 			Status:      "BORROWED",
 			PublishedAt: "1818-01-01",
+			CategoryID:  category("horror"),
 		},
 
 		// Romance
 		{
 			Title:       "The Notebook",
 			Author:      "Nicholas Sparks",
-			ISBN:        "978-0-446-60523-4",
+			ISBN:        "978-0-446-60523-6",
 			Description: "A 1996 romantic novel by American novelist Nicholas Sparks.",
 			Price:       13.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1996-10-01",
+			CategoryID:  category("romance"),
 		},
 		{
 			Title:       "Me Before You",
 			Author:      "Jojo Moyes",
-			ISBN:        "978-0-14-312454-1",
+			ISBN:        "978-0-14-312454-2",
 			Description: "A romance novel written by Jojo Moyes.",
 			Price:       14.99,
 			Status:      "BORROWED",
 			PublishedAt: "2012-01-05",
+			CategoryID:  category("romance"),
 		},
 
 		// Young Adult
@@ -356,6 +421,7 @@ func (s *BookSeeder) Run() error {
 			Price:       12.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2008-09-14",
+			CategoryID:  category("young-adult"),
 		},
 		{
 			Title:       "The Fault in Our Stars",
@@ -365,6 +431,7 @@ func (s *BookSeeder) Run() error {
 			Price:       13.99,
 			Status:      "BORROWED",
 			PublishedAt: "2012-01-10",
+			CategoryID:  category("young-adult"),
 		},
 		{
 			Title:       "Divergent",
@@ -374,26 +441,29 @@ func (s *BookSeeder) Run() error {
 			Price:       14.99,
 			Status:      "MAINTENANCE",
 			PublishedAt: "2011-04-25",
+			CategoryID:  category("young-adult"),
 		},
 
 		// Historical Fiction
 		{
 			Title:       "All Quiet on the Western Front",
 			Author:      "Erich Maria Remarque",
-			ISBN:        "978-0-449-21394-8",
+			ISBN:        "978-0-449-21394-0",
 			Description: "A novel by Erich Maria Remarque, a German veteran of World War I.",
 			Price:       12.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "1929-01-29",
+			CategoryID:  category("historical-fiction"),
 		},
 		{
 			Title:       "The Pillars of the Earth",
 			Author:      "Ken Follett",
-			ISBN:        "978-0-451-16689-5",
+			ISBN:        "978-0-451-16689-0",
 			Description: "A historical novel by Welsh author Ken Follett published in 1989.",
 			Price:       17.99,
 			Status:      "BORROWED",
 			PublishedAt: "1989-01-01",
+			CategoryID:  category("historical-fiction"),
 		},
 		{
 			Title:       "The Help",
@@ -403,6 +473,7 @@ func (s *BookSeeder) Run() error {
 			Price:       15.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2009-02-10",
+			CategoryID:  category("historical-fiction"),
 		},
 
 		// Biography
@@ -414,35 +485,39 @@ func (s *BookSeeder) Run() error {
 			Price:       19.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2011-10-24",
+			CategoryID:  category("biography"),
 		},
 		{
 			Title:       "Long Walk to Freedom",
 			Author:      "Nelson Mandela",
-			ISBN:        "978-0-316-54585-6",
+			ISBN:        "978-0-316-54585-3",
 			Description: "An autobiographical work written by South African President Nelson Mandela.",
 			Price:       18.99,
 			Status:      "MAINTENANCE",
 			PublishedAt: "1994-10-01",
+			CategoryID:  category("biography"),
 		},
 
 		// Philosophy
 		{
 			Title:       "Meditations",
 			Author:      "Marcus Aurelius",
-			ISBN:        "978-0-486-29823-2",
+			ISBN:        "978-0-486-29823-8",
 			Description: "A series of personal writings by Marcus Aurelius, Roman Emperor from 161 to 180 AD.",
 			Price:       9.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "0171-01-01",
+			CategoryID:  category("philosophy"),
 		},
 		{
 			Title:       "The Art of War",
 			Author:      "Sun Tzu",
-			ISBN:        "978-1-59030-963-7",
+			ISBN:        "978-1-59030-963-6",
 			Description: "An ancient Chinese military treatise dating from the Late Spring and Autumn Period.",
 			Price:       8.99,
 			Status:      "BORROWED",
 			PublishedAt: "0500-01-01",
+			CategoryID:  category("philosophy"),
 		},
 
 		// Business
@@ -454,6 +529,7 @@ func (s *BookSeeder) Run() error {
 			Price:       17.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2001-10-16",
+			CategoryID:  category("business"),
 		},
 		{
 			Title:       "The Lean Startup",
@@ -463,6 +539,7 @@ func (s *BookSeeder) Run() error {
 			Price:       16.99,
 			Status:      "BORROWED",
 			PublishedAt: "2011-09-13",
+			CategoryID:  category("business"),
 		},
 
 		// Technology
@@ -474,6 +551,7 @@ func (s *BookSeeder) Run() error {
 			Price:       24.99,
 			Status:      "AVAILABLE",
 			PublishedAt: "2008-08-01",
+			CategoryID:  category("technology"),
 		},
 		{
 			Title:       "The Pragmatic Programmer",
@@ -483,15 +561,17 @@ func (s *BookSeeder) Run() error {
 			Price:       23.99,
 			Status:      "MAINTENANCE",
 			PublishedAt: "1999-10-30",
+			CategoryID:  category("technology"),
 		},
 	}
 
 	// Insert all books into database
 	for _, book := range books {
+		book.ISBN = models.NormalizeISBN(book.ISBN)
 		if err := facades.Orm().Query().Create(&book); err != nil {
 			return err
 		}
 	}
 
 	return nil
-}
\ No newline at end of file
+}
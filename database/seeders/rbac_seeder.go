@@ -2,10 +2,12 @@ package seeders
 
 import (
 	"fmt"
+	"time"
 	
 	"github.com/goravel/framework/facades"
 	"players/app/auth"
 	"players/app/models"
+	"players/app/services"
 )
 
 // RBACSeeder seeds the database with default roles and permissions
@@ -16,33 +18,13 @@ func (s *RBACSeeder) Signature() string {
 	return "rbac"
 }
 
-// Run seeds default roles and permissions
+// Run seeds default roles and permissions. Every step upserts by slug, so re-running the
+// seeder keeps roles, permissions and grants that were created by hand.
 func (s *RBACSeeder) Run() error {
 	facades.Log().Info("Starting RBAC Seeder...")
 	
-	// Clear existing data first
-	facades.Orm().Query().Exec("DELETE FROM role_permissions")
-	facades.Orm().Query().Exec("DELETE FROM user_roles")
-	facades.Orm().Query().Exec("DELETE FROM permissions")
-	facades.Orm().Query().Exec("DELETE FROM roles")
-	
-	// Create roles directly with raw SQL
-	rolesSQL := []string{
-		"INSERT INTO roles (name, slug, description, level, is_active, created_at, updated_at) VALUES ('Super Administrator', 'super-admin', 'Full system access with all permissions', 100, 1, datetime('now'), datetime('now'))",
-		"INSERT INTO roles (name, slug, description, level, is_active, created_at, updated_at) VALUES ('Administrator', 'admin', 'Administrative access to most features', 80, 1, datetime('now'), datetime('now'))",
-		"INSERT INTO roles (name, slug, description, level, is_active, created_at, updated_at) VALUES ('Librarian', 'librarian', 'Full book management access', 60, 1, datetime('now'), datetime('now'))",
-		"INSERT INTO roles (name, slug, description, level, is_active, created_at, updated_at) VALUES ('Moderator', 'moderator', 'Limited administrative access', 40, 1, datetime('now'), datetime('now'))",
-		"INSERT INTO roles (name, slug, description, level, is_active, created_at, updated_at) VALUES ('Member', 'member', 'Regular user with borrowing privileges', 20, 1, datetime('now'), datetime('now'))",
-		"INSERT INTO roles (name, slug, description, level, is_active, created_at, updated_at) VALUES ('Guest', 'guest', 'Basic read-only access', 10, 1, datetime('now'), datetime('now'))",
-	}
-	
-	for _, sql := range rolesSQL {
-		if _, err := facades.Orm().Query().Exec(sql); err != nil {
-			facades.Log().Error("Failed to create role", map[string]interface{}{
-				"error": err.Error(),
-				"sql": sql[:minInt(50, len(sql))],
-			})
-		}
+	if err := s.createRoles(); err != nil {
+		return err
 	}
 	
 	// Create permissions dynamically from registered services
@@ -54,42 +36,101 @@ func (s *RBACSeeder) Run() error {
 		s.createHardcodedPermissions()
 	}
 	
+	// Gate permissions (books.borrow, roles.assign, ...) are checked by name, so seed them too
+	if err := services.NewPermissionsService().SyncPermissionsFromGates(); err != nil {
+		facades.Log().Error("Failed to sync gate permissions", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	
 	// Assign all permissions to super-admin role
-	_, err := facades.Orm().Query().Exec(`
-		INSERT INTO role_permissions (role_id, permission_id, is_active, created_at, updated_at)
-		SELECT r.id, p.id, 1, datetime('now'), datetime('now')
-		FROM roles r, permissions p
-		WHERE r.slug = 'super-admin'
-	`)
-	if err != nil {
+	if err := s.assignAllPermissionsToRole("super-admin"); err != nil {
 		facades.Log().Error("Failed to assign permissions to super-admin", map[string]interface{}{
 			"error": err.Error(),
 		})
 	}
 	
+	// Default grants for the other built-in roles
+	if err := s.assignPermissionsToRoles(); err != nil {
+		facades.Log().Error("Failed to assign default role permissions", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	
 	// Assign admin user (if exists) to super-admin role
+	s.assignAdminUser()
+	
+	facades.Log().Info("RBAC seeding completed")
+	return nil
+}
+
+// assignAdminUser gives the legacy ADMIN user the super-admin role unless they already hold it
+func (s *RBACSeeder) assignAdminUser() {
 	var adminUser models.User
-	if err := facades.Orm().Query().Where("role = ?", "ADMIN").First(&adminUser); err == nil {
-		_, err = facades.Orm().Query().Exec(`
-			INSERT INTO user_roles (user_id, role_id, assigned_at, is_active, notes, created_at, updated_at)
-			SELECT ?, r.id, datetime('now'), 1, 'Assigned during RBAC seeding', datetime('now'), datetime('now')
-			FROM roles r
-			WHERE r.slug = 'super-admin'
-		`, adminUser.ID)
-		if err != nil {
-			facades.Log().Error("Failed to assign user to super-admin role", map[string]interface{}{
-				"error": err.Error(),
-			})
-		} else {
-			facades.Log().Info("Assigned admin user to super-admin role", map[string]interface{}{
-				"user_id": adminUser.ID,
-				"email": adminUser.Email,
-			})
+	if err := facades.Orm().Query().Where("role = ?", "ADMIN").First(&adminUser); err != nil || adminUser.ID == 0 {
+		return
+	}
+	
+	var role models.Role
+	if err := facades.Orm().Query().Where("slug = ?", "super-admin").First(&role); err != nil || role.ID == 0 {
+		return
+	}
+	
+	var count int64
+	facades.Orm().Query().Model(&models.UserRole{}).Where("user_id = ? AND role_id = ?", adminUser.ID, role.ID).Count(&count)
+	if count > 0 {
+		return
+	}
+	
+	userRole := models.UserRole{
+		UserID:     adminUser.ID,
+		RoleID:     role.ID,
+		AssignedAt: time.Now(),
+		IsActive:   true,
+		Note:       "Assigned during RBAC seeding",
+	}
+	if err := facades.Orm().Query().Create(&userRole); err != nil {
+		facades.Log().Error("Failed to assign user to super-admin role", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	
+	facades.Log().Info("Assigned admin user to super-admin role", map[string]interface{}{
+		"user_id": adminUser.ID,
+		"email": adminUser.Email,
+	})
+}
+
+// upsertPermission creates the permission, or refreshes the descriptive fields of an existing one
+// with the same slug. Delegation and ownership flags set by admins are left alone, and permissions
+// an admin deleted stay deleted.
+func (s *RBACSeeder) upsertPermission(permission models.Permission) error {
+	var existing models.Permission
+	if err := facades.Orm().Query().WithTrashed().Where("slug = ?", permission.Slug).First(&existing); err != nil {
+		return err
+	}
+	if existing.DeletedAt.Valid {
+		return nil
+	}
+	
+	if existing.ID == 0 {
+		permission.IsActive = true
+		if permission.Resource == "" {
+			permission.Resource = permission.Category
 		}
+		return facades.Orm().Query().Create(&permission)
 	}
 	
-	facades.Log().Info("RBAC seeding completed")
-	return nil
+	existing.Name = permission.Name
+	existing.Description = permission.Description
+	existing.Category = permission.Category
+	existing.Action = permission.Action
+	if permission.Resource != "" {
+		existing.Resource = permission.Resource
+	}
+	existing.IsActive = true
+	return facades.Orm().Query().Save(&existing)
 }
 
 // createPermissions creates default permissions
@@ -141,56 +182,12 @@ func (s *RBACSeeder) createPermissions() error {
 	}
 
 	for _, permission := range permissions {
-		var existing models.Permission
-		err := facades.Orm().Query().Where("slug = ?", permission.Slug).First(&existing)
-		if err != nil {
-			// Permission doesn't exist, create it
-			permission.IsActive = true // Make sure it's active
-			permission.Resource = permission.Category // Set resource field
-			permission.RequiresOwnership = false
-			permission.CanDelegate = false
-			
-			// Debug logging
-			facades.Log().Info("Creating permission", map[string]interface{}{
-				"name": permission.Name,
+		if err := s.upsertPermission(permission); err != nil {
+			facades.Log().Error("Failed to create permission", map[string]interface{}{
+				"error": err.Error(),
 				"slug": permission.Slug,
-				"category": permission.Category,
-				"action": permission.Action,
 			})
-			
-			// Create using raw SQL to avoid GORM issues
-			query := `INSERT INTO permissions (name, slug, description, category, resource, action, is_active, requires_ownership, can_delegate, created_at, updated_at) 
-			         VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'))`
-			
-			_, err = facades.Orm().Query().Exec(query, 
-				permission.Name, 
-				permission.Slug, 
-				permission.Description, 
-				permission.Category, 
-				permission.Resource, 
-				permission.Action, 
-				permission.IsActive, 
-				permission.RequiresOwnership, 
-				permission.CanDelegate,
-			)
-			if err != nil {
-				facades.Log().Error("Failed to create permission", map[string]interface{}{
-					"error": err.Error(),
-					"permission": permission,
-				})
-				return err
-			}
-		} else {
-			// Permission exists, update it to ensure it's active and has correct data
-			existing.Name = permission.Name
-			existing.Category = permission.Category
-			existing.Action = permission.Action
-			existing.Description = permission.Description
-			existing.IsActive = true
-			err = facades.Orm().Query().Save(&existing)
-			if err != nil {
-				return err
-			}
+			return err
 		}
 	}
 
@@ -216,15 +213,20 @@ func (s *RBACSeeder) createRoles() error {
 	}
 
 	for _, role := range roles {
+		// Existing roles are left as they are, so admins' edits survive a re-seed
 		var existing models.Role
-		err := facades.Orm().Query().Where("slug = ?", role.Slug).First(&existing)
-		if err != nil {
-			// Role doesn't exist, create it using raw SQL to avoid GORM issues
-			query := `INSERT INTO roles (name, slug, description, level, is_active, created_at, updated_at) 
-			         VALUES (?, ?, ?, ?, 1, datetime('now'), datetime('now'))`
-			
-			_, err = facades.Orm().Query().Exec(query, role.Name, role.Slug, role.Description, role.Level)
-			if err != nil {
+		if err := facades.Orm().Query().WithTrashed().Where("slug = ?", role.Slug).First(&existing); err != nil {
+			return fmt.Errorf("failed to look up role %s: %w", role.Slug, err)
+		}
+		if existing.ID == 0 {
+			created := models.Role{
+				Name:        role.Name,
+				Slug:        role.Slug,
+				Description: role.Description,
+				Level:       role.Level,
+				IsActive:    true,
+			}
+			if err := facades.Orm().Query().Create(&created); err != nil {
 				facades.Log().Error("Failed to create role", map[string]interface{}{
 					"error": err.Error(),
 					"role": role,
@@ -282,19 +284,19 @@ func (s *RBACSeeder) setupRoleHierarchy() error {
 	return nil
 }
 
-// assignPermissionsToRoles assigns permissions to roles based on their level
+// assignPermissionsToRoles assigns permissions to roles based on their level. CRUD checks use the
+// service_action slugs created from the service registry (books_read), while gates and a few
+// built-in checks use the dotted gate slugs (books.borrow, roles.assign), so each list names
+// both where a role needs both. Every slug here exists after createPermissionsFromServices and
+// SyncPermissionsFromGates.
 func (s *RBACSeeder) assignPermissionsToRoles() error {
-	// Super Admin gets all permissions
-	if err := s.assignAllPermissionsToRole("super-admin"); err != nil {
-		return err
-	}
-
 	// Admin permissions
 	adminPerms := []string{
-		"books.viewAny", "books.view", "books.create", "books.update", "books.delete", "books.manage", "books.export",
-		"users.viewAny", "users.view", "users.create", "users.update", "users.manage",
-		"roles.viewAny", "roles.view", "roles.assign",
-		"reports.view", "reports.export", "reports.create",
+		"books_read", "books.viewAny", "books.view", "books.create", "books.update", "books.delete", "books.manage", "books.export",
+		"books_bulk_update", "books_bulk_delete", "books.restore",
+		"users_read", "users.viewAny", "users.view", "users.create", "users.update", "users.manage",
+		"roles_read", "roles_view", "roles.assign",
+		"reports.view", "reports.export",
 	}
 	if err := s.assignPermissionsToRole("admin", adminPerms); err != nil {
 		return err
@@ -302,8 +304,8 @@ func (s *RBACSeeder) assignPermissionsToRoles() error {
 
 	// Librarian permissions
 	librarianPerms := []string{
-		"books.viewAny", "books.view", "books.create", "books.update", "books.delete", "books.manage", "books.export",
-		"users.viewAny", "users.view",
+		"books_read", "books.viewAny", "books.view", "books.create", "books.update", "books.delete", "books.manage", "books.export",
+		"users_read", "users.viewAny", "users.view",
 		"reports.view", "reports.export",
 	}
 	if err := s.assignPermissionsToRole("librarian", librarianPerms); err != nil {
@@ -312,7 +314,7 @@ func (s *RBACSeeder) assignPermissionsToRoles() error {
 
 	// Moderator permissions
 	moderatorPerms := []string{
-		"books.viewAny", "books.view", "books.create", "books.update", "books.borrow", "books.return",
+		"books_read", "books.viewAny", "books.view", "books.create", "books.update", "books.borrow", "books.return",
 		"users.view",
 		"reports.view",
 	}
@@ -322,7 +324,7 @@ func (s *RBACSeeder) assignPermissionsToRoles() error {
 
 	// Member permissions
 	memberPerms := []string{
-		"books.viewAny", "books.view", "books.borrow", "books.return",
+		"books_read", "books.viewAny", "books.view", "books.borrow", "books.return",
 	}
 	if err := s.assignPermissionsToRole("member", memberPerms); err != nil {
 		return err
@@ -330,7 +332,7 @@ func (s *RBACSeeder) assignPermissionsToRoles() error {
 
 	// Guest permissions
 	guestPerms := []string{
-		"books.viewAny", "books.view",
+		"books_read", "books.viewAny", "books.view",
 	}
 	if err := s.assignPermissionsToRole("guest", guestPerms); err != nil {
 		return err
@@ -346,6 +348,9 @@ func (s *RBACSeeder) assignAllPermissionsToRole(roleSlug string) error {
 	if err != nil {
 		return err
 	}
+	if role.ID == 0 {
+		return fmt.Errorf("role %s not found", roleSlug)
+	}
 
 	var permissions []models.Permission
 	err = facades.Orm().Query().Where("is_active = ?", true).Find(&permissions)
@@ -360,22 +365,42 @@ func (s *RBACSeeder) assignAllPermissionsToRole(roleSlug string) error {
 	return nil
 }
 
-// assignPermissionsToRole assigns specific permissions to a role
+// assignPermissionsToRole assigns specific permissions to a role. A slug matches the permission in
+// either slug style (books.create and books_create). Roles that already have grants are left
+// alone, so re-seeding does not undo permissions an admin revoked.
 func (s *RBACSeeder) assignPermissionsToRole(roleSlug string, permissionSlugs []string) error {
 	var role models.Role
-	err := facades.Orm().Query().Where("slug = ?", roleSlug).First(&role)
-	if err != nil {
+	if err := facades.Orm().Query().Where("slug = ?", roleSlug).First(&role); err != nil {
 		return err
 	}
+	if role.ID == 0 {
+		return nil
+	}
+
+	var granted int64
+	facades.Orm().Query().Model(&models.RolePermission{}).Where("role_id = ?", role.ID).Count(&granted)
+	if granted > 0 {
+		return nil
+	}
 
 	for _, permSlug := range permissionSlugs {
-		var permission models.Permission
-		err := facades.Orm().Query().Where("slug = ?", permSlug).First(&permission)
-		if err != nil {
-			continue // Skip if permission doesn't exist
+		var permissions []models.Permission
+		if err := facades.Orm().Query().Where("slug IN ?", models.PermissionSlugVariants(permSlug)).Find(&permissions); err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			facades.Log().Warning("Seeded role references an unknown permission", map[string]interface{}{
+				"role": roleSlug,
+				"permission": permSlug,
+			})
+			continue
 		}
 
-		s.assignPermissionToRole(role.ID, permission.ID)
+		for _, permission := range permissions {
+			if err := s.assignPermissionToRole(role.ID, permission.ID); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -384,19 +409,21 @@ func (s *RBACSeeder) assignPermissionsToRole(roleSlug string, permissionSlugs []
 // assignPermissionToRole creates a role-permission relationship
 func (s *RBACSeeder) assignPermissionToRole(roleID, permissionID uint) error {
 	// Check if relationship already exists
-	var existing models.RolePermission
-	err := facades.Orm().Query().Where("role_id = ? AND permission_id = ?", roleID, permissionID).First(&existing)
-	if err != nil {
-		// Relationship doesn't exist, create it
-		rolePermission := models.RolePermission{
-			RoleID:       roleID,
-			PermissionID: permissionID,
-			IsActive:     true,
-		}
-		return facades.Orm().Query().Create(&rolePermission)
+	var count int64
+	if err := facades.Orm().Query().Model(&models.RolePermission{}).Where("role_id = ? AND permission_id = ?", roleID, permissionID).Count(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
 	}
 
-	return nil
+	rolePermission := models.RolePermission{
+		RoleID:       roleID,
+		PermissionID: permissionID,
+		GrantedAt:    time.Now(),
+		IsActive:     true,
+	}
+	return facades.Orm().Query().Create(&rolePermission)
 }
 
 // createPermissionsFromServices dynamically creates permissions from registered services
@@ -427,11 +454,14 @@ func (s *RBACSeeder) createPermissionsFromServices() error {
 			name := fmt.Sprintf("%s %s", actionName, serviceName)
 			description := fmt.Sprintf("%s %s in the system", actionName, string(service))
 			
-			// Create permission using raw SQL
-			sql := `INSERT INTO permissions (name, slug, description, category, resource, action, is_active, requires_ownership, can_delegate, created_at, updated_at) 
-			       VALUES (?, ?, ?, ?, ?, ?, 1, 0, 0, datetime('now'), datetime('now'))`
-			
-			_, err := facades.Orm().Query().Exec(sql, name, slug, description, string(service), string(service), string(action))
+			err := s.upsertPermission(models.Permission{
+				Name:        name,
+				Slug:        slug,
+				Description: description,
+				Category:    string(service),
+				Resource:    string(service),
+				Action:      string(action),
+			})
 			if err != nil {
 				facades.Log().Error("Failed to create permission", map[string]interface{}{
 					"error": err.Error(),
@@ -440,7 +470,7 @@ func (s *RBACSeeder) createPermissionsFromServices() error {
 					"slug": slug,
 				})
 			} else {
-				facades.Log().Info("Created permission", map[string]interface{}{
+				facades.Log().Info("Seeded permission", map[string]interface{}{
 					"name": name,
 					"slug": slug,
 				})
@@ -479,10 +509,13 @@ func (s *RBACSeeder) createHardcodedPermissions() {
 	}
 	
 	for _, perm := range hardcodedPermissions {
-		sql := `INSERT INTO permissions (name, slug, description, category, resource, action, is_active, requires_ownership, can_delegate, created_at, updated_at) 
-		       VALUES (?, ?, ?, ?, ?, ?, 1, 0, 0, datetime('now'), datetime('now'))`
-		
-		_, err := facades.Orm().Query().Exec(sql, perm.name, perm.slug, perm.description, perm.category, perm.category, perm.action)
+		err := s.upsertPermission(models.Permission{
+			Name:        perm.name,
+			Slug:        perm.slug,
+			Description: perm.description,
+			Category:    perm.category,
+			Action:      perm.action,
+		})
 		if err != nil {
 			facades.Log().Error("Failed to create hardcoded permission", map[string]interface{}{
 				"error": err.Error(),
@@ -491,11 +524,3 @@ func (s *RBACSeeder) createHardcodedPermissions() {
 		}
 	}
 }
-
-// minInt returns the minimum of two integers
-func minInt(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
\ No newline at end of file
@@ -190,7 +190,7 @@ export function BookCreateForm({ onSuccess, onError, onCancel, isLoading }: Crud
           <Input
             value={formData.isbn}
             onChange={(e) => setFormData({ ...formData, isbn: e.target.value })}
-            placeholder="978-0123456789"
+            placeholder="978-0-13-235088-4"
             className="pl-10"
           />
           <Hash className="absolute left-3 top-1/2 transform -translate-y-1/2 h-4 w-4 text-gray-400" />
@@ -440,7 +440,7 @@ export function BookEditForm({ item, onSuccess, onError, onCancel, isLoading }:
           <Input
             value={formData.isbn}
             onChange={(e) => setFormData({ ...formData, isbn: e.target.value })}
-            placeholder="978-0123456789"
+            placeholder="978-0-13-235088-4"
           />
         </FormField>
 
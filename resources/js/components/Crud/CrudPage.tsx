@@ -48,6 +48,7 @@ import { CrudDrawer } from './CrudDrawer';
 import { useDebounce } from '@/hooks/useDebounce';
 import { useCrudSelection } from '@/hooks/useCrudSelection';
 import { usePageSize } from '@/hooks/usePageSize';
+import { useResourceSchema } from '@/hooks/useResourceSchema';
 import { usePermissions } from '@/contexts/PermissionsContext';
 import { PermissionGate } from '@/components/Permissions/PermissionGate';
 import { toast } from 'sonner';
@@ -139,6 +140,17 @@ export function CrudPage<T extends { id: number }>({
   // Page size management with localStorage persistence
   const { pageSize, setPageSize, allowedSizes } = usePageSize(paginationConfig);
 
+  // Only offer sorting on columns the backend really sorts by, so a click never silently does nothing
+  const schema = useResourceSchema(resourceName);
+  const tableColumns = React.useMemo(() => {
+    if (!schema) {
+      return columns;
+    }
+    return columns.map((column) =>
+      column.sortable && !schema.sortable.includes(column.key) ? { ...column, sortable: false } : column
+    );
+  }, [columns, schema]);
+
   // Generic error handler
   const handleError = React.useCallback((error: any, operation: string) => {
     console.error(`${operation} error:`, error);
@@ -731,7 +743,7 @@ export function CrudPage<T extends { id: number }>({
         <div className="min-w-0 overflow-hidden">
           <CrudDataTable
             data={data.data}
-            columns={columns}
+            columns={tableColumns}
             actions={finalActions}
             sortField={filters?.sort}
             sortDirection={filters?.direction}
@@ -29,14 +29,14 @@ export function FormField({
         {children}
       </div>
       
-      {hint && !error && (
+      {hint && !(error && error.length > 0) && (
         <p className="text-xs text-gray-500">{hint}</p>
       )}
       
-      {error && (
+      {error && error.length > 0 && (
         <p className="text-sm text-red-600 flex items-center">
           <span className="mr-1">⚠</span>
-          {error}
+          {Array.isArray(error) ? error.join(' ') : error}
         </p>
       )}
     </div>
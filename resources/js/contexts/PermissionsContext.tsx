@@ -45,6 +45,14 @@ export interface PermissionsContextType {
 
 const PermissionsContext = createContext<PermissionsContextType | undefined>(undefined);
 
+// Slugs exist as both service_action and service.action; compare them in one form
+const permissionKey = (slug: string): string => slug.replace(/\./g, '_');
+
+const includesPermission = (permissions: string[] | undefined, slug: string): boolean => {
+  const key = permissionKey(slug);
+  return permissions?.some((permission) => permissionKey(permission) === key) || false;
+};
+
 export function PermissionsProvider({ children }: { children: ReactNode }) {
   const { props } = usePage();
   const auth = props.auth as { user: UserPermissions | null; permissions?: Record<string, ServicePermissions> };
@@ -52,7 +60,7 @@ export function PermissionsProvider({ children }: { children: ReactNode }) {
   const hasPermission = (permission: string): boolean => {
     if (!auth?.user) return false;
     if (auth.user.isSuperAdmin) return true;
-    return auth.user.permissions?.includes(permission) || false;
+    return includesPermission(auth.user.permissions, permission);
   };
 
   const hasServicePermission = (service: string, action: string): boolean => {
@@ -61,7 +69,7 @@ export function PermissionsProvider({ children }: { children: ReactNode }) {
     
     // Check specific permission in format "service_action"
     const permissionSlug = `${service}_${action}`;
-    return auth.user.permissions?.includes(permissionSlug) || false;
+    return includesPermission(auth.user.permissions, permissionSlug);
   };
 
   const canPerformAction = (service: string, action: 'create' | 'read' | 'update' | 'delete' | 'export' | 'bulk_update' | 'bulk_delete' | 'write' | 'manage'): boolean => {
@@ -78,7 +86,7 @@ export function PermissionsProvider({ children }: { children: ReactNode }) {
     if (!servicePerms) {
       // Fallback to checking user's permission array
       const permissionSlug = `${service}_${action}`;
-      const hasPermission = auth.user.permissions?.includes(permissionSlug) || false;
+      const hasPermission = includesPermission(auth.user.permissions, permissionSlug);
       return hasPermission;
     }
 
@@ -0,0 +1,49 @@
+import { useEffect, useState } from 'react';
+import axios from '@/lib/axios';
+
+// Field capabilities of a resource's list, as served by GET /api/{resource}/schema
+export interface ResourceSchema {
+  sortable: string[];
+  filterable: string[];
+  searchable: string[];
+  filterTypes: Record<string, string>;
+  defaultSort: { field: string; direction: string };
+  columns: Record<string, string>;
+}
+
+// Schemas only change with a deploy, so each resource is fetched once per page load
+const schemas = new Map<string, Promise<ResourceSchema | null>>();
+
+function fetchSchema(resourceName: string): Promise<ResourceSchema | null> {
+  let schema = schemas.get(resourceName);
+  if (!schema) {
+    schema = axios
+      .get(`/api/${resourceName}/schema`)
+      .then((response) => response.data?.data ?? null)
+      .catch(() => {
+        // Resources without the endpoint keep the columns they declare
+        schemas.delete(resourceName);
+        return null;
+      });
+    schemas.set(resourceName, schema);
+  }
+  return schema;
+}
+
+export function useResourceSchema(resourceName: string) {
+  const [schema, setSchema] = useState<ResourceSchema | null>(null);
+
+  useEffect(() => {
+    let active = true;
+    fetchSchema(resourceName).then((result) => {
+      if (active) {
+        setSchema(result);
+      }
+    });
+    return () => {
+      active = false;
+    };
+  }, [resourceName]);
+
+  return schema;
+}
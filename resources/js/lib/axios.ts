@@ -1,4 +1,4 @@
-import axios from 'axios';
+import axios, { AxiosError, InternalAxiosRequestConfig } from 'axios';
 
 // Configure axios defaults
 axios.defaults.headers.common['X-Requested-With'] = 'XMLHttpRequest';
@@ -10,11 +10,47 @@ if (token) {
   axios.defaults.headers.common['X-CSRF-TOKEN'] = token;
 }
 
-// Add interceptor to handle authentication errors
+const REFRESH_URL = '/api/auth/refresh';
+
+type RetriableConfig = InternalAxiosRequestConfig & { _retried?: boolean };
+
+// One refresh at a time: requests that fail while a refresh is running wait for it
+let refreshing: Promise<void> | null = null;
+
+const refreshSession = (): Promise<void> => {
+  if (!refreshing) {
+    refreshing = axios
+      .post(REFRESH_URL)
+      .then(() => undefined)
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+};
+
+// The access token expired: API calls get 401 and Inertia visits get a 409 pointing at /una
+const isSessionExpired = (error: AxiosError): boolean =>
+  error.response?.status === 401 ||
+  (error.response?.status === 409 && error.response.headers['x-inertia-location'] === '/una');
+
+// Add interceptor to refresh the session once before giving up on authentication errors
 axios.interceptors.response.use(
   response => response,
-  error => {
-    if (error.response?.status === 401) {
+  async (error: AxiosError) => {
+    const config = error.config as RetriableConfig | undefined;
+
+    if (config && !config._retried && config.url !== REFRESH_URL && isSessionExpired(error)) {
+      config._retried = true;
+      try {
+        await refreshSession();
+        return axios(config);
+      } catch {
+        // Refresh token missing or expired; fall through to the usual handling
+      }
+    }
+
+    if (error.response?.status === 401 && config?.url !== REFRESH_URL) {
       // Redirect to login page
       window.location.href = '/login';
     }
@@ -22,4 +58,4 @@ axios.interceptors.response.use(
   }
 );
 
-export default axios;
\ No newline at end of file
+export default axios;
@@ -123,21 +123,24 @@ export default function BooksIndex({
     }
   };
 
+  // Query parameters for the list as it is shown, so an export holds the same books: the search,
+  // the sort and each active filter
+  const exportParams = (format: string) => {
+    const params = new URLSearchParams({ format });
+    if (filters?.search) params.set('search', filters.search);
+    if (filters?.sort) params.set('sort', filters.sort);
+    if (filters?.direction) params.set('direction', filters.direction);
+    Object.entries(filters?.filters || {}).forEach(([key, value]) => {
+      if (value === undefined || value === null || value === '') return;
+      params.set(key, Array.isArray(value) ? value.join(',') : String(value));
+    });
+    return params;
+  };
+
   const handleBulkExport = (bookIds: number[]) => {
     const format = prompt('Export format (csv, json, excel):') || 'csv';
-    const options: BookExportOptions = {
-      format: format as any,
-      filters: filters,
-    };
-    
-    // Build params including bookIds separately
-    const params = new URLSearchParams({
-      format: format,
-      bookIds: bookIds.join(','),
-      ...Object.fromEntries(
-        Object.entries(filters || {}).map(([key, value]) => [key, String(value)])
-      ),
-    });
+    const params = exportParams(format);
+    params.set('bookIds', bookIds.join(','));
     
     // Trigger download
     window.open(`/api/books/export?${params.toString()}`);
@@ -177,14 +180,9 @@ export default function BooksIndex({
 
   // Handle export
   const handleExport = async (options: BookExportOptions) => {
-    const params = new URLSearchParams({
-      format: options.format,
-      ...(options.fields && { fields: options.fields.join(',') }),
-      ...(options.includeStats && { includeStats: 'true' }),
-      ...Object.fromEntries(
-        Object.entries(options.filters || {}).map(([key, value]) => [key, String(value)])
-      ),
-    });
+    const params = exportParams(options.format);
+    if (options.fields) params.set('fields', options.fields.join(','));
+    if (options.includeStats) params.set('includeStats', 'true');
 
     window.open(`/api/books/export?${params.toString()}`);
   };
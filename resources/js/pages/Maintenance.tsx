@@ -0,0 +1,28 @@
+import React from 'react';
+// @ts-ignore
+import { Head } from '@inertiajs/react';
+import AuthLayout from "@/layouts/Auth";
+
+interface MaintenanceProps {
+  message: string;
+  retryAfter?: number;
+}
+
+const Maintenance: React.FC<MaintenanceProps> = ({ message, retryAfter }) => {
+  return (
+    <AuthLayout>
+      <Head title="Down for maintenance" />
+      <div className="bg-white py-8 px-4 shadow sm:rounded-lg sm:px-10">
+        <h1 className="text-2xl font-bold text-center text-gray-800 mb-6">Down for maintenance</h1>
+        <p className="text-center text-gray-600">{message}</p>
+        {retryAfter ? (
+          <p className="mt-4 text-center text-sm text-gray-500">
+            Please try again in a {retryAfter < 120 ? 'minute' : 'few minutes'}.
+          </p>
+        ) : null}
+      </div>
+    </AuthLayout>
+  );
+};
+
+export default Maintenance;
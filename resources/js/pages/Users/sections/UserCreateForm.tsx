@@ -46,8 +46,6 @@ export const UserCreateForm = forwardRef<any, UserCreateFormProps>(({
     }
     if (!formData.password.trim()) {
       newErrors.password = 'Password is required';
-    } else if (formData.password.length < 8) {
-      newErrors.password = 'Password must be at least 8 characters';
     }
     
     if (Object.keys(newErrors).length > 0) {
@@ -75,6 +73,10 @@ export const UserCreateForm = forwardRef<any, UserCreateFormProps>(({
         onSuccess('User created successfully');
       } else {
         const errorData = await response.json().catch(() => ({}));
+        // The password policy reports each failed rule; show them as a checklist under the field
+        if (Array.isArray(errorData.errors?.password)) {
+          setErrors({ password: errorData.errors.password.join('\n') });
+        }
         onError?.(errorData);
       }
     } catch (error) {
@@ -159,11 +161,11 @@ export const UserCreateForm = forwardRef<any, UserCreateFormProps>(({
                   type="password"
                   value={formData.password}
                   onChange={(e) => setFormData({ ...formData, password: e.target.value })}
-                  placeholder="Enter password (min 8 characters)"
+                  placeholder="Enter password"
                   className={errors.password ? 'border-destructive' : ''}
                 />
                 {errors.password && (
-                  <p className="text-sm text-destructive">{errors.password}</p>
+                  <p className="text-sm text-destructive whitespace-pre-line">{errors.password}</p>
                 )}
               </div>
             </div>
@@ -46,9 +46,6 @@ export const UserEditForm = forwardRef<any, UserEditFormProps>(({
     if (!formData.email.trim()) {
       newErrors.email = 'Email is required';
     }
-    if (formData.password && formData.password.length < 8) {
-      newErrors.password = 'Password must be at least 8 characters';
-    }
     
     if (Object.keys(newErrors).length > 0) {
       setErrors(newErrors);
@@ -75,6 +72,10 @@ export const UserEditForm = forwardRef<any, UserEditFormProps>(({
         onSuccess('User updated successfully');
       } else {
         const errorData = await response.json().catch(() => ({}));
+        // The password policy reports each failed rule; show them as a checklist under the field
+        if (Array.isArray(errorData.errors?.password)) {
+          setErrors({ password: errorData.errors.password.join('\n') });
+        }
         onError?.(errorData);
       }
     } catch (error) {
@@ -169,9 +170,9 @@ export const UserEditForm = forwardRef<any, UserEditFormProps>(({
                   className={errors.password ? 'border-destructive' : ''}
                 />
                 {errors.password && (
-                  <p className="text-sm text-destructive">{errors.password}</p>
+                  <p className="text-sm text-destructive whitespace-pre-line">{errors.password}</p>
                 )}
-                <p className="text-xs text-muted-foreground">Minimum 8 characters if changing</p>
+                <p className="text-xs text-muted-foreground">Must meet the password policy if changing</p>
               </div>
             </div>
 
@@ -11,6 +11,7 @@ export interface Book extends BaseModel {
   status: BookStatus;
   publishedAt?: string;
   tags?: string[];
+  metadata?: Record<string, any> | null;
   // Additional computed fields that might come from the backend
   isAvailable?: boolean;
   borrowedBy?: string;
@@ -89,15 +90,16 @@ export interface BookReturnData {
 }
 
 // Form validation types
+// Server-side validation lists every message for a field; client-side checks set one
 export interface BookFormErrors {
-  title?: string;
-  author?: string;
-  isbn?: string;
-  description?: string;
-  price?: string;
-  status?: string;
-  publishedAt?: string;
-  tags?: string;
+  title?: string | string[];
+  author?: string | string[];
+  isbn?: string | string[];
+  description?: string | string[];
+  price?: string | string[];
+  status?: string | string[];
+  publishedAt?: string | string[];
+  tags?: string | string[];
   general?: string;
 }
 
@@ -194,7 +194,8 @@ export interface DrawerProps {
 export interface FormFieldProps {
   children: React.ReactNode;
   label?: string;
-  error?: string;
+  // Validation failures list every message for a field; one string also works
+  error?: string | string[];
   required?: boolean;
   className?: string;
   description?: string;
@@ -25,56 +25,104 @@ func Api(router route.Router) {
 
 	bookController := books.NewBookController()
 	authController := auth.NewAuthController()
-	rolesController := &auth.RolesController{}
-	permissionsController := &auth.PermissionsController{}
+	rolesController := auth.NewRolesController()
+	permissionsController := auth.NewPermissionsController()
 	searchController := controllers.NewSearchController()
+	apiKeysController := auth.NewApiKeysController()
 	jwtAuth := middleware.JwtAuth()
+	// Protected routes also accept API keys, limited to each key's scopes
+	apiKeyOrJwtAuth := middleware.ApiKeyAuth()
 
 	// Book resource routes
 	router.Get("/books", bookController.Index)
 	router.Get("/books/{id}", bookController.Show)
-	router.Get("/books/isbn/{isbn}", bookController.GetByISBN)
-	router.Get("/books/author/{author}", bookController.GetByAuthor)
-	router.Get("/books/available", bookController.GetAvailable)
-	router.Get("/books/advanced", bookController.Advanced)
+
+	// Public book lookups are unauthenticated, so they are rate limited per client IP
+	router.Middleware(middleware.RateLimit("public")).Group(func(publicRouter route.Router) {
+		publicRouter.Get("/books/isbn/{isbn}", bookController.GetByISBN)
+		publicRouter.Get("/books/author/{author}", bookController.GetByAuthor)
+		publicRouter.Get("/books/available", bookController.GetAvailable)
+		publicRouter.Get("/books/advanced", bookController.Advanced)
+	})
 
 	// Protected routes (require authentication)
-	router.Middleware(jwtAuth).Group(func(protectedRouter route.Router) {
+	router.Middleware(apiKeyOrJwtAuth).Group(func(protectedRouter route.Router) {
 		// Global search
 		protectedRouter.Get("/search", searchController.GlobalSearch)
 		
 		// Book routes
 		protectedRouter.Post("/books", bookController.Store)
+		protectedRouter.Post("/books/import", bookController.Import)
+		protectedRouter.Post("/books/bulk/status", bookController.BulkStatus)
+		protectedRouter.Get("/books/trashed", bookController.Trashed)
+		protectedRouter.Get("/books/exists", bookController.Exists)
+		protectedRouter.Get("/books/schema", bookController.Schema)
+		protectedRouter.Get("/books/overdue", bookController.Overdue)
 		protectedRouter.Put("/books/{id}", bookController.Update)
+		protectedRouter.Patch("/books/{id}", bookController.Update)
 		protectedRouter.Delete("/books/{id}", bookController.Delete)
+		protectedRouter.Post("/books/{id}/restore", bookController.Restore)
+		protectedRouter.Get("/books/{id}/audit", bookController.Audit)
+		protectedRouter.Get("/books/{id}/related/{relation}", bookController.Related)
 		protectedRouter.Post("/books/{id}/borrow", bookController.Borrow)
 		protectedRouter.Post("/books/{id}/return", bookController.Return)
 
 		// Role management routes
-		protectedRouter.Get("/roles", rolesController.Index)
-		protectedRouter.Post("/roles", rolesController.Store)
-		protectedRouter.Get("/roles/{id}", rolesController.Show)
-		protectedRouter.Put("/roles/{id}", rolesController.Update)
-		protectedRouter.Delete("/roles/{id}", rolesController.Destroy)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.read")).Get("/roles", rolesController.Index)
+		protectedRouter.Get("/roles/matrix", rolesController.Matrix)
+		protectedRouter.Get("/roles/assignable", rolesController.Assignable)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.create")).Post("/roles", rolesController.Store)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.create")).Post("/roles/{id}/clone", rolesController.Clone)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.read")).Get("/roles/{id}", rolesController.Show)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.update")).Put("/roles/{id}", rolesController.Update)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.delete")).Delete("/roles/{id}", rolesController.Destroy)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.delete")).Post("/roles/{id}/restore", rolesController.Restore)
 		protectedRouter.Put("/roles/{id}/permissions", rolesController.UpdatePermissions)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.read")).Get("/roles/{id}/users", rolesController.Users)
+		protectedRouter.Middleware(middleware.RequirePermission("roles.assign")).Post("/roles/{id}/users", rolesController.AssignUsers)
 
 		// Permission assignment routes
-		protectedRouter.Post("/permissions/assign", permissionsController.Assign)
-		protectedRouter.Delete("/permissions/revoke", permissionsController.Revoke)
+		protectedRouter.Middleware(middleware.RequirePermission("permissions.read")).Get("/permissions", permissionsController.Index)
+		protectedRouter.Middleware(middleware.RequirePermission("permissions.update")).Post("/permissions/assign", permissionsController.Assign)
+		protectedRouter.Middleware(middleware.RequirePermission("permissions.update")).Put("/permissions/matrix", permissionsController.UpdateMatrix)
+		protectedRouter.Middleware(middleware.RequirePermission("permissions.update")).Delete("/permissions/revoke", permissionsController.Revoke)
 
 		// User management routes (super admin only)
 		protectedRouter.Get("/users", userController.Index)
+		protectedRouter.Get("/users/trashed", userController.Trashed)
+		protectedRouter.Get("/users/exists", userController.Exists)
+		protectedRouter.Get("/users/schema", userController.Schema)
 		protectedRouter.Get("/users/{id}", userController.Show)
 		protectedRouter.Post("/users", userController.Store)
 		protectedRouter.Put("/users/{id}", userController.Update)
+		protectedRouter.Patch("/users/{id}", userController.Patch)
 		protectedRouter.Delete("/users/{id}", userController.Delete)
+		protectedRouter.Post("/users/{id}/restore", userController.Restore)
+		protectedRouter.Post("/users/{id}/activate", userController.Activate)
+		protectedRouter.Post("/users/{id}/deactivate", userController.Deactivate)
+		protectedRouter.Post("/users/{id}/unlock", userController.Unlock)
+		protectedRouter.Get("/users/{id}/audit", userController.Audit)
+		protectedRouter.Get("/users/{id}/related/{relation}", userController.Related)
+		protectedRouter.Get("/users/{id}/permissions", userController.Permissions)
 		protectedRouter.Get("/users/roles", userController.GetRoles)
+
+		// API keys for service-to-service calls
+		protectedRouter.Middleware(middleware.RequirePermission("api_keys.manage")).Get("/api-keys", apiKeysController.Index)
+		protectedRouter.Middleware(middleware.RequirePermission("api_keys.manage")).Post("/api-keys", apiKeysController.Store)
+		protectedRouter.Middleware(middleware.RequirePermission("api_keys.manage")).Delete("/api-keys/{id}", apiKeysController.Destroy)
 	})
 
+	// Impersonation hands out a session token, so it takes a signed-in user rather than an API key
+	router.Middleware(jwtAuth, middleware.RequirePermission("users.impersonate")).Post("/users/{id}/impersonate", authController.Impersonate)
+
 	// This Prefix("auth") group will also be relative to the router passed in.
 	// If called from RouteServiceProvider's /api group, this becomes /api/auth
 	router.Prefix("auth").Group(func(authRouter route.Router) {
 		authRouter.Post("/login", authController.Login)
+		authRouter.Post("/refresh", authController.Refresh)
+		authRouter.Get("/verify/{token}", authController.Verify)
 		authRouter.Middleware(jwtAuth).Post("/logout", authController.Logout)
+		authRouter.Middleware(jwtAuth).Get("/me", authController.Me)
+		authRouter.Middleware(jwtAuth).Post("/stop-impersonating", authController.StopImpersonating)
 	})
 }
@@ -23,6 +23,11 @@ func Web() {
 	permissionsPageController := auth.NewPermissionsPageController()
 	userPageController := auth.NewUserPageController()
 
+	// Kubernetes liveness and readiness probes; unauthenticated so probes need no JWT
+	healthController := controllers.NewHealthController()
+	facades.Route().Get("/healthz", healthController.Liveness)
+	facades.Route().Get("/readyz", healthController.Readiness)
+
 	facades.Route().Post("/login", authController.Login)
 	facades.Route().Get("/login", func(ctx http.Context) http.Response {
 		return inertiaHelper.Render(ctx, "auth/Login", map[string]interface{}{
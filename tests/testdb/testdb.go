@@ -0,0 +1,130 @@
+// Package testdb lets unit tests run services against a throwaway SQLite database without booting
+// the application. Open points facades.Orm, facades.Config, facades.Log, facades.Lang and the JSON
+// codec at a test application for the rest of the test, so code under test reaches the database
+// the way it does in production.
+package testdb
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	contractsconfig "github.com/goravel/framework/contracts/config"
+	contractsdatabase "github.com/goravel/framework/contracts/database"
+	contractsorm "github.com/goravel/framework/contracts/database/orm"
+	contractslog "github.com/goravel/framework/contracts/log"
+	contractstranslation "github.com/goravel/framework/contracts/translation"
+	"github.com/goravel/framework/database/gorm"
+	databaseorm "github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/foundation"
+	foundationjson "github.com/goravel/framework/foundation/json"
+	goravellog "github.com/goravel/framework/log"
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	gormio "gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Database is the test database; Config holds config values that override the defaults code asks for
+type Database struct {
+	*gormio.DB
+	Config Config
+}
+
+// Open creates a database with tables for models and installs the test application, which is
+// restored when the test ends
+func Open(t *testing.T, models ...any) *Database {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)"
+	db, err := gormio.Open(sqlite.Open(dsn), &gormio.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	database := &Database{DB: db, Config: Config{}}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	log := discardLog{goravellog.NewWriter(logrus.NewEntry(logger))}
+	fullConfig := contractsdatabase.FullConfig{Driver: contractsdatabase.DriverSqlite, Connection: "sqlite"}
+	query := gorm.NewQuery(context.Background(), database.Config, fullConfig, db, log, nil, nil)
+	orm := databaseorm.NewOrm(context.Background(), database.Config, "sqlite", query, map[string]contractsorm.Query{"sqlite": query}, log, nil, nil)
+
+	app := &mocksfoundation.Application{}
+	app.On("MakeOrm").Return(orm).Maybe()
+	app.On("MakeConfig").Return(database.Config).Maybe()
+	app.On("MakeLog").Return(log).Maybe()
+	app.On("GetJson").Return(foundationjson.NewJson()).Maybe()
+	app.On("MakeLang", mock.Anything).Return(keyTranslator{}).Maybe()
+
+	previous := foundation.App
+	foundation.App = app
+	t.Cleanup(func() { foundation.App = previous })
+
+	return database
+}
+
+// Config answers config lookups from the map, falling back to the caller's default
+type Config map[string]any
+
+var _ contractsconfig.Config = Config{}
+
+func (c Config) Env(envName string, defaultValue ...any) any {
+	return c.Get(envName, defaultValue...)
+}
+
+func (c Config) Add(name string, configuration any) {
+	c[name] = configuration
+}
+
+func (c Config) Get(path string, defaultValue ...any) any {
+	if value, ok := c[path]; ok {
+		return value
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return nil
+}
+
+func (c Config) GetString(path string, defaultValue ...any) string {
+	value, _ := c.Get(path, defaultValue...).(string)
+	return value
+}
+
+func (c Config) GetInt(path string, defaultValue ...any) int {
+	value, _ := c.Get(path, defaultValue...).(int)
+	return value
+}
+
+func (c Config) GetBool(path string, defaultValue ...any) bool {
+	value, _ := c.Get(path, defaultValue...).(bool)
+	return value
+}
+
+// discardLog drops everything code under test logs
+type discardLog struct {
+	contractslog.Writer
+}
+
+func (l discardLog) WithContext(context.Context) contractslog.Writer { return l.Writer }
+func (l discardLog) Channel(string) contractslog.Writer              { return l.Writer }
+func (l discardLog) Stack([]string) contractslog.Writer              { return l.Writer }
+
+// keyTranslator answers every lookup with the key itself, as Trans does for a key with no line
+type keyTranslator struct {
+	contractstranslation.Translator
+}
+
+func (keyTranslator) Get(key string, _ ...contractstranslation.Option) string { return key }